@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	metricTypeCounter   = "counter"
+	metricTypeGauge     = "gauge"
+	metricTypeHistogram = "histogram"
+)
+
+// defaultBuckets are the bounds of the histograms, the default buckets of the Prometheus clients.
+var defaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+const (
+	defaultMaxSeries        = 10000
+	defaultSeriesExpiration = 5 * time.Minute
+)
+
+// Config defines the configuration of the connector
+type Config struct {
+	// Operators parse the logs before the metrics are extracted from their fields.
+	Operators []operator.Config `mapstructure:"operators"`
+	// Metrics are the metrics to extract from the logs, by name.
+	Metrics map[string]MetricConfig `mapstructure:"metrics"`
+	// MaxSeries is the maximum number of series kept in memory, all metrics and resources included.
+	// The measures of new series are dropped once it is reached.
+	MaxSeries int `mapstructure:"max_series"`
+	// SeriesExpiration is the duration after which a series that wasn't measured is removed.
+	// 0 keeps the series for the lifetime of the connector.
+	SeriesExpiration time.Duration `mapstructure:"series_expiration"`
+}
+
+// MetricConfig defines a metric extracted from the logs
+type MetricConfig struct {
+	Description string `mapstructure:"description"`
+	Unit        string `mapstructure:"unit"`
+	// Type is one of counter, gauge or histogram.
+	Type string `mapstructure:"type"`
+	// Value is the field holding the value of the metric.
+	// Without a value, a counter counts the logs.
+	Value *entry.Field `mapstructure:"value"`
+	// Buckets are the explicit bounds of a histogram.
+	Buckets []float64 `mapstructure:"buckets"`
+	// If is an expression selecting the logs from which the metric is extracted.
+	If string `mapstructure:"if"`
+	// Attributes are the dimensions of the metric.
+	Attributes []AttributeConfig `mapstructure:"attributes"`
+}
+
+// AttributeConfig defines a dimension of a metric
+type AttributeConfig struct {
+	Key string `mapstructure:"key"`
+	// Field holds the value of the attribute. Defaults to the log attribute named after Key.
+	Field *entry.Field `mapstructure:"field"`
+	// DefaultValue is used when the field is missing.
+	// Without a default value, a log missing the field is not measured.
+	DefaultValue string `mapstructure:"default_value"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid
+func (c *Config) Validate() error {
+	if len(c.Metrics) == 0 {
+		return errors.New("at least one metric must be configured")
+	}
+
+	names := make([]string, 0, len(c.Metrics))
+	for name := range c.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "" {
+			return errors.New("metric name missing")
+		}
+		if err := c.Metrics[name].validate(); err != nil {
+			return fmt.Errorf("metric %q: %w", name, err)
+		}
+	}
+
+	if c.MaxSeries <= 0 {
+		return errors.New("max_series must be positive")
+	}
+	if c.SeriesExpiration < 0 {
+		return errors.New("series_expiration must not be negative")
+	}
+	return nil
+}
+
+func (m MetricConfig) validate() error {
+	switch m.Type {
+	case metricTypeCounter:
+	case metricTypeGauge, metricTypeHistogram:
+		if m.Value == nil {
+			return fmt.Errorf("value is required for a %s", m.Type)
+		}
+	default:
+		return fmt.Errorf("invalid type %q, must be one of %q, %q or %q", m.Type, metricTypeCounter, metricTypeGauge, metricTypeHistogram)
+	}
+
+	if len(m.Buckets) > 0 {
+		if m.Type != metricTypeHistogram {
+			return fmt.Errorf("buckets can only be set for a %s", metricTypeHistogram)
+		}
+		if !sort.Float64sAreSorted(m.Buckets) {
+			return errors.New("buckets must be sorted in increasing order")
+		}
+	}
+
+	if m.If != "" {
+		if _, err := helper.ExprCompileBool(m.If); err != nil {
+			return fmt.Errorf("invalid if expression: %w", err)
+		}
+	}
+
+	keys := make(map[string]struct{}, len(m.Attributes))
+	for _, attr := range m.Attributes {
+		if attr.Key == "" {
+			return errors.New("attribute key missing")
+		}
+		if _, ok := keys[attr.Key]; ok {
+			return fmt.Errorf("duplicate attribute %q", attr.Key)
+		}
+		keys[attr.Key] = struct{}{}
+	}
+	return nil
+}
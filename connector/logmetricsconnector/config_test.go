@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+)
+
+func fieldPtr(field entry.Field) *entry.Field {
+	return &field
+}
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		expect *Config
+	}{
+		{
+			name: "",
+			expect: &Config{
+				Operators: []operator.Config{
+					{
+						Builder: func() *regex.Config {
+							cfg := regex.NewConfig()
+							cfg.Regex = `^(?P<method>[A-Z]+) (?P<path>\S+) (?P<status>\d{3}) (?P<duration>[\d.]+)$`
+							return cfg
+						}(),
+					},
+				},
+				Metrics: map[string]MetricConfig{
+					"http.server.requests": {
+						Description: "The number of requests.",
+						Type:        metricTypeCounter,
+						Attributes: []AttributeConfig{
+							{Key: "method"},
+							{Key: "status", DefaultValue: "unknown"},
+						},
+					},
+					"http.server.duration": {
+						Description: "The duration of the requests.",
+						Unit:        "s",
+						Type:        metricTypeHistogram,
+						Value:       fieldPtr(entry.NewAttributeField("duration")),
+						Buckets:     []float64{0.1, 0.5, 1},
+						If:          `attributes.status matches "^5"`,
+						Attributes: []AttributeConfig{
+							{Key: "http.route", Field: fieldPtr(entry.NewAttributeField("path"))},
+						},
+					},
+				},
+				MaxSeries:        defaultMaxSeries,
+				SeriesExpiration: defaultSeriesExpiration,
+			},
+		},
+		{
+			name: "no_operators",
+			expect: &Config{
+				Operators: []operator.Config{},
+				Metrics: map[string]MetricConfig{
+					"queue.size": {
+						Type:  metricTypeGauge,
+						Value: fieldPtr(entry.NewBodyField("size")),
+					},
+				},
+				MaxSeries:        100,
+				SeriesExpiration: time.Minute,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(component.NewIDWithName(metadata.Type, tc.name).String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tc.expect, cfg)
+		})
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  *Config
+		expect string
+	}{
+		{
+			name:   "no_metrics",
+			input:  &Config{},
+			expect: "at least one metric must be configured",
+		},
+		{
+			name: "missing_metric_name",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"": {Type: metricTypeCounter},
+				},
+			},
+			expect: "metric name missing",
+		},
+		{
+			name: "invalid_type",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: "summary"},
+				},
+			},
+			expect: `metric "my.metric": invalid type "summary", must be one of "counter", "gauge" or "histogram"`,
+		},
+		{
+			name: "missing_value",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeGauge},
+				},
+			},
+			expect: `metric "my.metric": value is required for a gauge`,
+		},
+		{
+			name: "buckets_not_histogram",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeCounter, Buckets: []float64{1, 2}},
+				},
+			},
+			expect: `metric "my.metric": buckets can only be set for a histogram`,
+		},
+		{
+			name: "unsorted_buckets",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeHistogram, Value: fieldPtr(entry.NewBodyField()), Buckets: []float64{2, 1}},
+				},
+			},
+			expect: `metric "my.metric": buckets must be sorted in increasing order`,
+		},
+		{
+			name: "invalid_if",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeCounter, If: "body =="},
+				},
+			},
+			expect: `metric "my.metric": invalid if expression`,
+		},
+		{
+			name: "missing_attribute_key",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeCounter, Attributes: []AttributeConfig{{DefaultValue: "value"}}},
+				},
+			},
+			expect: `metric "my.metric": attribute key missing`,
+		},
+		{
+			name: "duplicate_attribute",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeCounter, Attributes: []AttributeConfig{{Key: "env"}, {Key: "env"}}},
+				},
+			},
+			expect: `metric "my.metric": duplicate attribute "env"`,
+		},
+		{
+			name: "no_max_series",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeCounter},
+				},
+			},
+			expect: "max_series must be positive",
+		},
+		{
+			name: "negative_series_expiration",
+			input: &Config{
+				Metrics: map[string]MetricConfig{
+					"my.metric": {Type: metricTypeCounter},
+				},
+				MaxSeries:        defaultMaxSeries,
+				SeriesExpiration: -time.Minute,
+			},
+			expect: "series_expiration must not be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.input.Validate()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expect)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+)
+
+const scopeName = "otelcol/logmetricsconnector"
+
+// logMetrics parses logs with stanza operators and extracts metrics
+// from the fields of the parsed entries onto a metrics pipeline.
+type logMetrics struct {
+	logger          *zap.Logger
+	metricsConsumer consumer.Metrics
+	aggregator      *aggregator
+
+	pipe          *pipeline.DirectedPipeline
+	firstOperator operator.Operator
+	emitter       *adapter.LogEmitter
+	fromConverter *adapter.FromPdataConverter
+
+	cancel      context.CancelFunc
+	converterWg sync.WaitGroup
+	emitterWg   sync.WaitGroup
+}
+
+func newConnector(cfg *Config, nextConsumer consumer.Metrics, set connector.CreateSettings) (*logMetrics, error) {
+	metricDefs, err := newMetricDefs(cfg.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &logMetrics{
+		logger:          set.Logger,
+		metricsConsumer: nextConsumer,
+		aggregator:      newAggregator(metricDefs, cfg.MaxSeries, cfg.SeriesExpiration),
+		emitter:         adapter.NewLogEmitter(set.Logger.Sugar()),
+		cancel:          func() {},
+	}
+
+	// Without operators, the entries are measured as they are
+	if len(cfg.Operators) > 0 {
+		c.pipe, err = pipeline.Config{
+			Operators:        cfg.Operators,
+			DefaultOutput:    c.emitter,
+			MeterProvider:    set.MeterProvider,
+			MetricAttributes: []attribute.KeyValue{attribute.String("connector", set.ID.String())},
+		}.Build(set.Logger.Sugar())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (c *logMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logMetrics) Start(_ context.Context, _ component.Host) error {
+	if c.pipe != nil {
+		// There is no need for this connector to use storage
+		if err := c.pipe.Start(storage.NewNopClient()); err != nil {
+			return err
+		}
+		c.firstOperator = c.pipe.Operators()[0]
+	} else {
+		if err := c.emitter.Start(nil); err != nil {
+			return err
+		}
+		c.firstOperator = c.emitter
+	}
+
+	wkrCount := int(math.Max(1, float64(runtime.NumCPU())))
+	c.fromConverter = adapter.NewFromPdataConverter(wkrCount, c.logger)
+	c.fromConverter.Start()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	// * first loop reads the entries translated by the fromConverter and forwards them to the pipeline
+	c.converterWg.Add(1)
+	go c.converterLoop(ctx)
+
+	// * second loop reads the entries emitted by the pipeline and places their metrics on the next consumer,
+	//   until the emitter is stopped
+	c.emitterWg.Add(1)
+	go c.emitterLoop()
+
+	return nil
+}
+
+func (c *logMetrics) Shutdown(_ context.Context) error {
+	if c.fromConverter == nil {
+		// Not started
+		return nil
+	}
+
+	c.fromConverter.Stop()
+	c.cancel()
+	// The pipeline must not receive entries once stopped
+	c.converterWg.Wait()
+
+	var err error
+	if c.pipe != nil {
+		err = c.pipe.Stop()
+	} else {
+		err = c.emitter.Stop()
+	}
+	c.emitterWg.Wait()
+	return err
+}
+
+func (c *logMetrics) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	return c.fromConverter.Batch(ld)
+}
+
+// converterLoop reads the log entries produced by the fromConverter and sends them
+// into the pipeline
+func (c *logMetrics) converterLoop(ctx context.Context) {
+	defer c.converterWg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case entries, ok := <-c.fromConverter.OutChannel():
+			if !ok {
+				return
+			}
+
+			for _, e := range entries {
+				if err := c.firstOperator.Process(ctx, e); err != nil {
+					c.logger.Debug("Failed to process entry", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// emitterLoop reads the log entries emitted by the pipeline, aggregates their
+// metrics and sends them to the next consumer
+func (c *logMetrics) emitterLoop() {
+	defer c.emitterWg.Done()
+
+	for entries := range c.emitter.OutChannel() {
+		c.consumeEntries(context.Background(), entries)
+	}
+}
+
+func (c *logMetrics) consumeEntries(ctx context.Context, entries []*entry.Entry) {
+	c.aggregator.expire(time.Now())
+	for _, e := range entries {
+		if err := c.aggregator.update(e); err != nil {
+			c.logger.Debug("Failed to extract metrics from entry", zap.Error(err))
+		}
+	}
+	if c.aggregator.empty() {
+		return
+	}
+
+	if err := c.metricsConsumer.ConsumeMetrics(ctx, c.aggregator.metrics(scopeName)); err != nil {
+		c.logger.Error("Failed to send metrics to the next consumer", zap.Error(err))
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+)
+
+func TestLogsToMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Operators = []operator.Config{
+		{
+			Builder: func() *regex.Config {
+				cfg := regex.NewConfig()
+				cfg.Regex = `^(?P<method>[A-Z]+) (?P<status>\d{3}) (?P<duration>[\d.]+)$`
+				return cfg
+			}(),
+		},
+	}
+	cfg.Metrics = map[string]MetricConfig{
+		"http.server.requests": {
+			Type:       metricTypeCounter,
+			Attributes: []AttributeConfig{{Key: "method"}, {Key: "status"}},
+		},
+		"http.server.duration": {
+			Type:    metricTypeHistogram,
+			Unit:    "s",
+			Value:   fieldPtr(entry.NewAttributeField("duration")),
+			Buckets: []float64{0.5},
+		},
+	}
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "frontend")
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range []string{"GET 200 0.1", "GET 200 0.7", "POST 500 0.2", "not an access log"} {
+		lrs.AppendEmpty().Body().SetStr(body)
+	}
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	// The values are cumulative, the last data point of each series holds its total
+	metrics := map[string]pmetric.Metric{}
+	requests := map[string]int64{}
+	observations := map[string]int64{}
+	for _, md := range sink.AllMetrics() {
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			service, ok := rm.Resource().Attributes().Get("service.name")
+			require.True(t, ok)
+			assert.Equal(t, "frontend", service.Str())
+
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			for j := 0; j < ms.Len(); j++ {
+				m := ms.At(j)
+				metrics[m.Name()] = m
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					for k := 0; k < m.Sum().DataPoints().Len(); k++ {
+						dp := m.Sum().DataPoints().At(k)
+						requests[fmt.Sprint(dp.Attributes().AsRaw())] = dp.IntValue()
+					}
+				case pmetric.MetricTypeHistogram:
+					for k := 0; k < m.Histogram().DataPoints().Len(); k++ {
+						dp := m.Histogram().DataPoints().At(k)
+						observations[fmt.Sprint(dp.Attributes().AsRaw())] = int64(dp.Count())
+					}
+				}
+			}
+		}
+	}
+
+	require.Contains(t, metrics, "http.server.requests")
+	require.Contains(t, metrics, "http.server.duration")
+	assert.Equal(t, "s", metrics["http.server.duration"].Unit())
+	// The log failing to parse is not measured
+	assert.Equal(t, int64(3), total(requests))
+	assert.Equal(t, int64(3), total(observations))
+}
+
+func total(values map[string]int64) int64 {
+	var sum int64
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+func TestLogsToMetricsWithoutOperators(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics = map[string]MetricConfig{
+		"logs.count": {
+			Type:       metricTypeCounter,
+			Attributes: []AttributeConfig{{Key: "level"}},
+		},
+	}
+
+	sink := &consumertest.MetricsSink{}
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lr := lrs.AppendEmpty()
+	lr.Body().SetStr("first")
+	lr.Attributes().PutStr("level", "info")
+	lr = lrs.AppendEmpty()
+	lr.Body().SetStr("second")
+	lr.Attributes().PutStr("level", "info")
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+
+	require.Eventually(t, func() bool {
+		return sink.DataPointCount() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, conn.Shutdown(context.Background()))
+
+	var count int64
+	for _, md := range sink.AllMetrics() {
+		dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+		level, _ := dp.Attributes().Get("level")
+		assert.Equal(t, "info", level.Str())
+		// The values are cumulative
+		count = dp.IntValue()
+	}
+	assert.Equal(t, int64(2), count)
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Metrics = map[string]MetricConfig{"logs.count": {Type: metricTypeCounter}}
+
+	conn, err := NewFactory().CreateLogsToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.NoError(t, conn.Shutdown(context.Background()))
+}
@@ -0,0 +1,459 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/logmetricsconnector"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/antonmedv/expr/vm"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// metricDef is a metric extracted from the log entries
+type metricDef struct {
+	name      string
+	desc      string
+	unit      string
+	typ       string
+	value     *entry.Field
+	buckets   []float64
+	condition *vm.Program
+	attrs     []attributeDef
+}
+
+type attributeDef struct {
+	key          string
+	field        entry.Field
+	defaultValue string
+}
+
+func newMetricDefs(metrics map[string]MetricConfig) ([]metricDef, error) {
+	defs := make([]metricDef, 0, len(metrics))
+	for name, cfg := range metrics {
+		md := metricDef{
+			name:  name,
+			desc:  cfg.Description,
+			unit:  cfg.Unit,
+			typ:   cfg.Type,
+			value: cfg.Value,
+		}
+		if md.typ == metricTypeHistogram {
+			md.buckets = cfg.Buckets
+			if len(md.buckets) == 0 {
+				md.buckets = defaultBuckets
+			}
+		}
+		if cfg.If != "" {
+			condition, err := helper.ExprCompileBool(cfg.If)
+			if err != nil {
+				return nil, fmt.Errorf("metric %q: invalid if expression: %w", name, err)
+			}
+			md.condition = condition
+		}
+		for _, attr := range cfg.Attributes {
+			field := entry.NewAttributeField(attr.Key)
+			if attr.Field != nil {
+				field = *attr.Field
+			}
+			md.attrs = append(md.attrs, attributeDef{key: attr.Key, field: field, defaultValue: attr.DefaultValue})
+		}
+		defs = append(defs, md)
+	}
+	// Emit the metrics in a stable order
+	sort.Slice(defs, func(i, j int) bool { return defs[i].name < defs[j].name })
+	return defs, nil
+}
+
+// matches returns whether the metric is extracted from the entry
+func (md *metricDef) matches(e *entry.Entry) (bool, error) {
+	if md.condition == nil {
+		return true, nil
+	}
+
+	env := helper.GetExprEnv(e)
+	defer helper.PutExprEnv(env)
+
+	matches, err := vm.Run(md.condition, env)
+	if err != nil {
+		return false, fmt.Errorf("running if expr: %w", err)
+	}
+	return matches.(bool), nil
+}
+
+// attributes returns the dimensions of the metric for the entry,
+// or false if the entry is missing an attribute without default value
+func (md *metricDef) attributes(e *entry.Entry) (pcommon.Map, bool) {
+	attrs := pcommon.NewMap()
+	for _, attr := range md.attrs {
+		val, ok := e.Get(attr.field)
+		switch {
+		case ok && val != nil:
+			attrs.PutStr(attr.key, toString(val))
+		case attr.defaultValue != "":
+			attrs.PutStr(attr.key, attr.defaultValue)
+		default:
+			return attrs, false
+		}
+	}
+	return attrs, true
+}
+
+// measure returns the value of the metric for the entry
+func (md *metricDef) measure(e *entry.Entry) (float64, error) {
+	if md.value == nil {
+		return 1, nil
+	}
+	val, ok := e.Get(*md.value)
+	if !ok {
+		return 0, fmt.Errorf("missing value field %s", md.value.String())
+	}
+	f, err := toFloat(val)
+	if err != nil {
+		return 0, fmt.Errorf("value field %s: %w", md.value.String(), err)
+	}
+	if md.typ == metricTypeCounter && f < 0 {
+		return 0, fmt.Errorf("value field %s: negative value %v for a counter", md.value.String(), f)
+	}
+	return f, nil
+}
+
+func toString(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func toFloat(val interface{}) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %q as a number", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to a number", val)
+	}
+}
+
+// errSeriesLimit is returned for the measures of new series once the series limit is reached
+var errSeriesLimit = errors.New("max_series reached, measure of a new series dropped")
+
+// aggregator accumulates the measures of the metrics taken from the entries. The state of each
+// series is kept across the batches of entries, so that counters and histograms are cumulative,
+// until the series expires. The number of series is bounded by maxSeries.
+type aggregator struct {
+	metricDefs []metricDef
+	maxSeries  int
+	expiration time.Duration
+	resources  map[[16]byte]*resourceMetrics
+	// order of the resources, to emit them in the order they were seen
+	order []*resourceMetrics
+	// series is the number of series kept, all metrics and resources included
+	series int
+}
+
+type resourceMetrics struct {
+	key      [16]byte
+	resource pcommon.Map
+	points   map[string]*metricPoints
+	// updated is set when a measure of the resource was recorded since the metrics were last emitted
+	updated bool
+}
+
+// metricPoints are the data points of a metric, in the order they were seen
+type metricPoints struct {
+	byAttrs map[[16]byte]*dataPoint
+	order   []*dataPoint
+}
+
+type dataPoint struct {
+	key   [16]byte
+	attrs pcommon.Map
+	// start is the time the series was first measured, the start of the cumulative values
+	start time.Time
+	// seen is the time the series was last measured, from which it expires
+	seen      time.Time
+	updated   bool
+	count     uint64
+	sum       float64
+	min       float64
+	max       float64
+	last      float64
+	lastTime  time.Time
+	bucketCts []uint64
+}
+
+func newAggregator(metricDefs []metricDef, maxSeries int, expiration time.Duration) *aggregator {
+	return &aggregator{
+		metricDefs: metricDefs,
+		maxSeries:  maxSeries,
+		expiration: expiration,
+		resources:  make(map[[16]byte]*resourceMetrics),
+	}
+}
+
+// update records the measures of the entry. Metrics for which the entry
+// is missing an attribute are skipped.
+func (a *aggregator) update(e *entry.Entry) error {
+	var errs error
+	var resource *pcommon.Map
+	var resourceKey [16]byte
+	for i := range a.metricDefs {
+		md := &a.metricDefs[i]
+		matches, err := md.matches(e)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("metric %q: %w", md.name, err))
+			continue
+		}
+		if !matches {
+			continue
+		}
+
+		attrs, ok := md.attributes(e)
+		if !ok {
+			continue
+		}
+
+		value, err := md.measure(e)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("metric %q: %w", md.name, err))
+			continue
+		}
+
+		if resource == nil {
+			m := pcommon.NewMap()
+			_ = m.FromRaw(e.Resource)
+			resource, resourceKey = &m, pdatautil.MapHash(m)
+		}
+		dp := a.dataPoint(resourceKey, *resource, md, attrs)
+		if dp == nil {
+			errs = errors.Join(errs, fmt.Errorf("metric %q: %w", md.name, errSeriesLimit))
+			continue
+		}
+		dp.record(md, value, entryTime(e))
+	}
+	return errs
+}
+
+// dataPoint returns the series of a metric for the resource and attributes, and marks the resource
+// as updated. A new series is created unless the series limit is reached, in which case nil is returned.
+func (a *aggregator) dataPoint(resourceKey [16]byte, resource pcommon.Map, md *metricDef, attrs pcommon.Map) *dataPoint {
+	key := pdatautil.MapHash(attrs)
+	rm := a.resources[resourceKey]
+	if rm != nil {
+		if points, ok := rm.points[md.name]; ok {
+			if dp, ok := points.byAttrs[key]; ok {
+				rm.updated = true
+				return dp
+			}
+		}
+	}
+	if a.series >= a.maxSeries {
+		return nil
+	}
+
+	if rm == nil {
+		rm = &resourceMetrics{
+			key:      resourceKey,
+			resource: resource,
+			points:   make(map[string]*metricPoints),
+		}
+		a.resources[resourceKey] = rm
+		a.order = append(a.order, rm)
+	}
+	points, ok := rm.points[md.name]
+	if !ok {
+		points = &metricPoints{byAttrs: make(map[[16]byte]*dataPoint)}
+		rm.points[md.name] = points
+	}
+	dp := &dataPoint{key: key, attrs: attrs, start: time.Now(), min: math.Inf(1), max: math.Inf(-1)}
+	if md.typ == metricTypeHistogram {
+		dp.bucketCts = make([]uint64, len(md.buckets)+1)
+	}
+	points.byAttrs[key] = dp
+	points.order = append(points.order, dp)
+	a.series++
+	rm.updated = true
+	return dp
+}
+
+// expire removes the series not measured within the expiration before now, and the resources left
+// without series. A series measured again after it expired starts over from zero.
+func (a *aggregator) expire(now time.Time) {
+	if a.expiration <= 0 {
+		return
+	}
+	resources := a.order[:0]
+	for _, rm := range a.order {
+		for name, points := range rm.points {
+			kept := points.order[:0]
+			for _, dp := range points.order {
+				if now.Sub(dp.seen) > a.expiration {
+					delete(points.byAttrs, dp.key)
+					a.series--
+					continue
+				}
+				kept = append(kept, dp)
+			}
+			points.order = kept
+			if len(kept) == 0 {
+				delete(rm.points, name)
+			}
+		}
+		if len(rm.points) == 0 {
+			delete(a.resources, rm.key)
+			continue
+		}
+		resources = append(resources, rm)
+	}
+	a.order = resources
+}
+
+func (dp *dataPoint) record(md *metricDef, value float64, t time.Time) {
+	dp.updated = true
+	dp.seen = time.Now()
+	dp.count++
+	dp.sum += value
+	dp.min = math.Min(dp.min, value)
+	dp.max = math.Max(dp.max, value)
+	if !t.Before(dp.lastTime) {
+		dp.last = value
+		dp.lastTime = t
+	}
+	if md.typ == metricTypeHistogram {
+		dp.bucketCts[sort.SearchFloat64s(md.buckets, value)]++
+	}
+}
+
+func entryTime(e *entry.Entry) time.Time {
+	if e.Timestamp.IsZero() {
+		return e.ObservedTimestamp
+	}
+	return e.Timestamp
+}
+
+// empty returns whether no measure was recorded since the metrics were last emitted
+func (a *aggregator) empty() bool {
+	for _, rm := range a.order {
+		if rm.updated {
+			return false
+		}
+	}
+	return true
+}
+
+// metrics returns the series updated since the metrics were last emitted, grouped by resource
+func (a *aggregator) metrics(scopeName string) pmetric.Metrics {
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	md := pmetric.NewMetrics()
+	for _, rm := range a.order {
+		if !rm.updated {
+			continue
+		}
+		rm.updated = false
+		resourceMetrics := md.ResourceMetrics().AppendEmpty()
+		rm.resource.CopyTo(resourceMetrics.Resource().Attributes())
+		scopeMetrics := resourceMetrics.ScopeMetrics().AppendEmpty()
+		scopeMetrics.Scope().SetName(scopeName)
+
+		for i := range a.metricDefs {
+			def := &a.metricDefs[i]
+			points, ok := rm.points[def.name]
+			if !ok {
+				continue
+			}
+			updated := make([]*dataPoint, 0, len(points.order))
+			for _, point := range points.order {
+				if point.updated {
+					point.updated = false
+					updated = append(updated, point)
+				}
+			}
+			if len(updated) == 0 {
+				continue
+			}
+			metric := scopeMetrics.Metrics().AppendEmpty()
+			metric.SetName(def.name)
+			metric.SetDescription(def.desc)
+			metric.SetUnit(def.unit)
+			appendDataPoints(metric, def, updated, timestamp)
+		}
+	}
+	return md
+}
+
+func appendDataPoints(metric pmetric.Metric, def *metricDef, points []*dataPoint, timestamp pcommon.Timestamp) {
+	switch def.typ {
+	case metricTypeCounter:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, point := range points {
+			dp := sum.DataPoints().AppendEmpty()
+			point.attrs.CopyTo(dp.Attributes())
+			dp.SetStartTimestamp(pcommon.NewTimestampFromTime(point.start))
+			dp.SetTimestamp(timestamp)
+			if def.value == nil {
+				dp.SetIntValue(int64(point.count))
+			} else {
+				dp.SetDoubleValue(point.sum)
+			}
+		}
+	case metricTypeGauge:
+		gauge := metric.SetEmptyGauge()
+		for _, point := range points {
+			dp := gauge.DataPoints().AppendEmpty()
+			point.attrs.CopyTo(dp.Attributes())
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(point.lastTime))
+			dp.SetDoubleValue(point.last)
+		}
+	case metricTypeHistogram:
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, point := range points {
+			dp := histogram.DataPoints().AppendEmpty()
+			point.attrs.CopyTo(dp.Attributes())
+			dp.SetStartTimestamp(pcommon.NewTimestampFromTime(point.start))
+			dp.SetTimestamp(timestamp)
+			dp.SetCount(point.count)
+			dp.SetSum(point.sum)
+			dp.SetMin(point.min)
+			dp.SetMax(point.max)
+			dp.ExplicitBounds().FromRaw(def.buckets)
+			dp.BucketCounts().FromRaw(point.bucketCts)
+		}
+	}
+}
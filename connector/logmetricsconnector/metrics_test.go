@@ -0,0 +1,312 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logmetricsconnector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+)
+
+func newTestEntry(attrs map[string]interface{}) *entry.Entry {
+	e := entry.New()
+	e.Timestamp = time.Unix(1000, 0)
+	e.Attributes = attrs
+	e.Resource = map[string]interface{}{"host.name": "host1"}
+	return e
+}
+
+func aggregate(t *testing.T, metrics map[string]MetricConfig, entries ...*entry.Entry) pmetric.Metrics {
+	defs, err := newMetricDefs(metrics)
+	require.NoError(t, err)
+	agg := newAggregator(defs, defaultMaxSeries, defaultSeriesExpiration)
+	for _, e := range entries {
+		assert.NoError(t, agg.update(e))
+	}
+	return agg.metrics(scopeName)
+}
+
+func TestAggregateCounter(t *testing.T) {
+	md := aggregate(t, map[string]MetricConfig{
+		"requests": {
+			Type:       metricTypeCounter,
+			Attributes: []AttributeConfig{{Key: "method"}},
+		},
+		"bytes": {
+			Type:  metricTypeCounter,
+			Value: fieldPtr(entry.NewAttributeField("size")),
+		},
+	},
+		newTestEntry(map[string]interface{}{"method": "GET", "size": "100"}),
+		newTestEntry(map[string]interface{}{"method": "POST", "size": 50}),
+		newTestEntry(map[string]interface{}{"method": "GET", "size": 25.5}),
+	)
+
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	host, ok := rm.Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	assert.Equal(t, "host1", host.Str())
+
+	sm := rm.ScopeMetrics().At(0)
+	assert.Equal(t, scopeName, sm.Scope().Name())
+	require.Equal(t, 2, sm.Metrics().Len())
+
+	bytes := sm.Metrics().At(0)
+	assert.Equal(t, "bytes", bytes.Name())
+	require.Equal(t, pmetric.MetricTypeSum, bytes.Type())
+	assert.True(t, bytes.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, bytes.Sum().AggregationTemporality())
+	require.Equal(t, 1, bytes.Sum().DataPoints().Len())
+	assert.Equal(t, 175.5, bytes.Sum().DataPoints().At(0).DoubleValue())
+	assert.NotZero(t, bytes.Sum().DataPoints().At(0).StartTimestamp())
+
+	requests := sm.Metrics().At(1)
+	assert.Equal(t, "requests", requests.Name())
+	require.Equal(t, 2, requests.Sum().DataPoints().Len())
+	get := requests.Sum().DataPoints().At(0)
+	method, _ := get.Attributes().Get("method")
+	assert.Equal(t, "GET", method.Str())
+	assert.Equal(t, int64(2), get.IntValue())
+	post := requests.Sum().DataPoints().At(1)
+	method, _ = post.Attributes().Get("method")
+	assert.Equal(t, "POST", method.Str())
+	assert.Equal(t, int64(1), post.IntValue())
+}
+
+func TestAggregateGauge(t *testing.T) {
+	first := newTestEntry(map[string]interface{}{"size": 3})
+	last := newTestEntry(map[string]interface{}{"size": 7})
+	last.Timestamp = first.Timestamp.Add(time.Second)
+
+	md := aggregate(t, map[string]MetricConfig{
+		"queue.size": {
+			Type:  metricTypeGauge,
+			Value: fieldPtr(entry.NewAttributeField("size")),
+		},
+	}, last, first)
+
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeGauge, metric.Type())
+	require.Equal(t, 1, metric.Gauge().DataPoints().Len())
+	dp := metric.Gauge().DataPoints().At(0)
+	assert.Equal(t, 7.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(last.Timestamp), dp.Timestamp())
+}
+
+func TestAggregateHistogram(t *testing.T) {
+	md := aggregate(t, map[string]MetricConfig{
+		"duration": {
+			Type:    metricTypeHistogram,
+			Value:   fieldPtr(entry.NewAttributeField("duration")),
+			Buckets: []float64{0.1, 1},
+		},
+	},
+		newTestEntry(map[string]interface{}{"duration": "0.05"}),
+		newTestEntry(map[string]interface{}{"duration": "0.1"}),
+		newTestEntry(map[string]interface{}{"duration": "0.5"}),
+		newTestEntry(map[string]interface{}{"duration": "2"}),
+	)
+
+	metric := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeHistogram, metric.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, metric.Histogram().AggregationTemporality())
+	dp := metric.Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.InDelta(t, 2.65, dp.Sum(), 1e-9)
+	assert.Equal(t, 0.05, dp.Min())
+	assert.Equal(t, 2.0, dp.Max())
+	assert.Equal(t, []float64{0.1, 1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 1, 1}, dp.BucketCounts().AsRaw())
+}
+
+func TestAggregateCumulative(t *testing.T) {
+	defs, err := newMetricDefs(map[string]MetricConfig{
+		"requests": {
+			Type:       metricTypeCounter,
+			Attributes: []AttributeConfig{{Key: "method"}},
+		},
+		"duration": {
+			Type:    metricTypeHistogram,
+			Value:   fieldPtr(entry.NewAttributeField("duration")),
+			Buckets: []float64{1},
+		},
+	})
+	require.NoError(t, err)
+	agg := newAggregator(defs, defaultMaxSeries, defaultSeriesExpiration)
+
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "GET", "duration": 0.5})))
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "POST", "duration": 2})))
+	first := agg.metrics(scopeName).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, first.At(1).Sum().DataPoints().Len())
+	start := first.At(1).Sum().DataPoints().At(0).StartTimestamp()
+	assert.True(t, agg.empty())
+
+	// Only the series updated since the last emission are emitted, with their values since the start
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "GET", "duration": 0.5})))
+	second := agg.metrics(scopeName).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+
+	duration := second.At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), duration.Count())
+	assert.Equal(t, []uint64{2, 1}, duration.BucketCounts().AsRaw())
+
+	requests := second.At(1).Sum().DataPoints()
+	require.Equal(t, 1, requests.Len())
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, requests.At(0).Attributes().AsRaw())
+	assert.Equal(t, int64(2), requests.At(0).IntValue())
+	assert.Equal(t, start, requests.At(0).StartTimestamp())
+	assert.True(t, requests.At(0).StartTimestamp() <= requests.At(0).Timestamp())
+}
+
+func TestAggregateDefaultBuckets(t *testing.T) {
+	md := aggregate(t, map[string]MetricConfig{
+		"duration": {
+			Type:  metricTypeHistogram,
+			Value: fieldPtr(entry.NewAttributeField("duration")),
+		},
+	}, newTestEntry(map[string]interface{}{"duration": 0.2}))
+
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, defaultBuckets, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, len(defaultBuckets)+1, dp.BucketCounts().Len())
+}
+
+func TestAggregateAttributes(t *testing.T) {
+	md := aggregate(t, map[string]MetricConfig{
+		"requests": {
+			Type: metricTypeCounter,
+			Attributes: []AttributeConfig{
+				{Key: "http.route", Field: fieldPtr(entry.NewAttributeField("path"))},
+				{Key: "status", DefaultValue: "unknown"},
+			},
+		},
+	},
+		newTestEntry(map[string]interface{}{"path": "/users", "status": 200}),
+		newTestEntry(map[string]interface{}{"path": "/users"}),
+		// Not measured, the route has no default value
+		newTestEntry(map[string]interface{}{"status": 200}),
+	)
+
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, map[string]interface{}{"http.route": "/users", "status": "200"}, dps.At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"http.route": "/users", "status": "unknown"}, dps.At(1).Attributes().AsRaw())
+}
+
+func TestAggregateIf(t *testing.T) {
+	md := aggregate(t, map[string]MetricConfig{
+		"errors": {
+			Type: metricTypeCounter,
+			If:   `attributes.status matches "^5"`,
+		},
+	},
+		newTestEntry(map[string]interface{}{"status": "200"}),
+		newTestEntry(map[string]interface{}{"status": "500"}),
+		newTestEntry(map[string]interface{}{"status": "503"}),
+	)
+
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(2), dps.At(0).IntValue())
+}
+
+func TestAggregateResources(t *testing.T) {
+	other := newTestEntry(nil)
+	other.Resource = map[string]interface{}{"host.name": "host2"}
+
+	md := aggregate(t, map[string]MetricConfig{
+		"logs": {Type: metricTypeCounter},
+	}, newTestEntry(nil), other, newTestEntry(nil))
+
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	for i, expected := range []struct {
+		host  string
+		count int64
+	}{{"host1", 2}, {"host2", 1}} {
+		rm := md.ResourceMetrics().At(i)
+		host, _ := rm.Resource().Attributes().Get("host.name")
+		assert.Equal(t, expected.host, host.Str())
+		assert.Equal(t, expected.count, rm.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	defs, err := newMetricDefs(map[string]MetricConfig{
+		"bytes": {
+			Type:  metricTypeCounter,
+			Value: fieldPtr(entry.NewAttributeField("size")),
+		},
+	})
+	require.NoError(t, err)
+	agg := newAggregator(defs, defaultMaxSeries, defaultSeriesExpiration)
+
+	assert.EqualError(t, agg.update(newTestEntry(nil)), `metric "bytes": missing value field attributes.size`)
+	assert.EqualError(t, agg.update(newTestEntry(map[string]interface{}{"size": "abc"})),
+		`metric "bytes": value field attributes.size: cannot parse "abc" as a number`)
+	assert.EqualError(t, agg.update(newTestEntry(map[string]interface{}{"size": -1})),
+		`metric "bytes": value field attributes.size: negative value -1 for a counter`)
+	assert.True(t, agg.empty())
+}
+
+func TestAggregateSeriesLimit(t *testing.T) {
+	defs, err := newMetricDefs(map[string]MetricConfig{
+		"requests": {
+			Type:       metricTypeCounter,
+			Attributes: []AttributeConfig{{Key: "method"}},
+		},
+	})
+	require.NoError(t, err)
+	agg := newAggregator(defs, 2, defaultSeriesExpiration)
+
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "GET"})))
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "POST"})))
+	assert.ErrorIs(t, agg.update(newTestEntry(map[string]interface{}{"method": "PUT"})), errSeriesLimit)
+	other := newTestEntry(map[string]interface{}{"method": "GET"})
+	other.Resource = map[string]interface{}{"host.name": "host2"}
+	assert.ErrorIs(t, agg.update(other), errSeriesLimit)
+	// The existing series are still measured
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "GET"})))
+
+	md := agg.metrics(scopeName)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	requests := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 2, requests.Len())
+	assert.Equal(t, int64(2), requests.At(0).IntValue())
+	assert.Equal(t, int64(1), requests.At(1).IntValue())
+}
+
+func TestAggregateExpiration(t *testing.T) {
+	defs, err := newMetricDefs(map[string]MetricConfig{
+		"requests": {
+			Type:       metricTypeCounter,
+			Attributes: []AttributeConfig{{Key: "method"}},
+		},
+	})
+	require.NoError(t, err)
+	agg := newAggregator(defs, 1, time.Minute)
+
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "GET"})))
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "GET"})))
+	first := agg.metrics(scopeName).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(2), first.IntValue())
+
+	// A series measured within the expiration is kept
+	agg.expire(time.Now())
+	assert.ErrorIs(t, agg.update(newTestEntry(map[string]interface{}{"method": "POST"})), errSeriesLimit)
+
+	// An expired series frees its place and starts over when measured again
+	agg.expire(time.Now().Add(2 * time.Minute))
+	assert.Empty(t, agg.resources)
+	assert.Empty(t, agg.order)
+	require.NoError(t, agg.update(newTestEntry(map[string]interface{}{"method": "GET"})))
+	second := agg.metrics(scopeName).ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(1), second.IntValue())
+	assert.True(t, second.StartTimestamp() >= first.StartTimestamp())
+}
@@ -5,6 +5,7 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -12,14 +13,19 @@ import (
 )
 
 const (
-	rotationFieldName = "rotation"
-	backupsFieldName  = "max_backups"
+	rotationFieldName  = "rotation"
+	backupsFieldName   = "max_backups"
+	partitionFieldName = "partition"
 )
 
 // Config defines configuration for file exporter.
 type Config struct {
 
 	// Path of the file to write to. Path is relative to current directory.
+	// Path may be a template partitioning telemetry into several files, with
+	// {resource.<key>} placeholders replaced by the value of resource attributes
+	// and %Y, %m, %d, %H, %M and %S directives replaced by the current UTC time.
+	// A literal { or % is written {{ or %%.
 	Path string `mapstructure:"path"`
 
 	// Rotation defines an option about rotation of telemetry files
@@ -38,6 +44,9 @@ type Config struct {
 	// FlushInterval is the duration between flushes.
 	// See time.ParseDuration for valid values.
 	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// Partition defines how the files are managed when Path is a template
+	Partition *Partition `mapstructure:"partition"`
 }
 
 // Rotation an option to rolling log files
@@ -63,6 +72,18 @@ type Rotation struct {
 	LocalTime bool `mapstructure:"localtime"`
 }
 
+// Partition an option to manage the files of a templated path
+type Partition struct {
+	// MaxOpenFiles is the maximum number of files kept open. When a file is opened
+	// beyond this limit, the least recently written file is closed. It defaults to 100 files
+	// when unset or zero.
+	MaxOpenFiles int `mapstructure:"max_open_files"`
+
+	// IdleTimeout is the duration after which a file that has not been written to
+	// is closed. It defaults to 1 minute when unset or zero.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
@@ -79,6 +100,21 @@ func (cfg *Config) Validate() error {
 	if cfg.FlushInterval < 0 {
 		return errors.New("flush_interval must be larger than zero")
 	}
+	if isPathTemplate(cfg.Path) {
+		if _, err := newPathTemplate(cfg.Path); err != nil {
+			return fmt.Errorf("invalid path: %w", err)
+		}
+	} else if cfg.Partition != nil {
+		return errors.New("partition can only be set when path is a template")
+	}
+	if cfg.Partition != nil {
+		if cfg.Partition.MaxOpenFiles < 0 {
+			return errors.New("max_open_files must not be negative")
+		}
+		if cfg.Partition.IdleTimeout < 0 {
+			return errors.New("idle_timeout must not be negative")
+		}
+	}
 	return nil
 }
 
@@ -98,6 +134,9 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if !componentParser.IsSet(rotationFieldName) {
 		cfg.Rotation = nil
 	}
+	if !componentParser.IsSet(partitionFieldName) {
+		cfg.Partition = nil
+	}
 
 	// set flush interval to 1 second if not set.
 	if cfg.FlushInterval == 0 {
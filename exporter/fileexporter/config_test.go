@@ -119,6 +119,49 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "path must be non-empty",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition_with_default_settings"),
+			expected: &Config{
+				Path:          "./data/{resource.service.name}/%Y/%m/%d/%H.jsonl",
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition_with_custom_settings"),
+			expected: &Config{
+				Path:          "./data/{resource.service.name}/%Y/%m/%d/%H.jsonl",
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+				Partition: &Partition{
+					MaxOpenFiles: 10,
+					IdleTimeout:  5 * time.Minute,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "partition_without_template"),
+			errorMessage: "partition can only be set when path is a template",
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition_partial_settings"),
+			expected: &Config{
+				Path:          "./data/{resource.service.name}.jsonl",
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+				Partition: &Partition{
+					MaxOpenFiles: 10,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "partition_max_open_files_error"),
+			errorMessage: "max_open_files must not be negative",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "path_template_error"),
+			errorMessage: "invalid path: unsupported placeholder {service.name} in path template, must be {resource.<key>}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -7,11 +7,13 @@ import (
 	"context"
 	"io"
 	"os"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/zap"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter/internal/metadata"
@@ -22,6 +24,11 @@ const (
 	// the number of old log files to retain
 	defaultMaxBackups = 100
 
+	// the number of partition files kept open
+	defaultMaxOpenFiles = 100
+	// the duration after which an idle partition file is closed
+	defaultIdleTimeout = time.Minute
+
 	// the format of encoded telemetry data
 	formatTypeJSON  = "json"
 	formatTypeProto = "proto"
@@ -58,7 +65,7 @@ func createTracesExporter(
 		return nil, err
 	}
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
+		return newFileExporter(conf, writer, set.Logger)
 	})
 	return exporterhelper.NewTracesExporter(
 		ctx,
@@ -82,7 +89,7 @@ func createMetricsExporter(
 		return nil, err
 	}
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
+		return newFileExporter(conf, writer, set.Logger)
 	})
 	return exporterhelper.NewMetricsExporter(
 		ctx,
@@ -106,7 +113,7 @@ func createLogsExporter(
 		return nil, err
 	}
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return newFileExporter(conf, writer)
+		return newFileExporter(conf, writer, set.Logger)
 	})
 	return exporterhelper.NewLogsExporter(
 		ctx,
@@ -119,8 +126,8 @@ func createLogsExporter(
 	)
 }
 
-func newFileExporter(conf *Config, writer io.WriteCloser) *fileExporter {
-	return &fileExporter{
+func newFileExporter(conf *Config, writer io.WriteCloser, logger *zap.Logger) *fileExporter {
+	fe := &fileExporter{
 		path:             conf.Path,
 		formatType:       conf.FormatType,
 		file:             writer,
@@ -132,9 +139,19 @@ func newFileExporter(conf *Config, writer io.WriteCloser) *fileExporter {
 		compressor:       buildCompressor(conf.Compression),
 		flushInterval:    conf.FlushInterval,
 	}
+	if isPathTemplate(conf.Path) {
+		// the path is validated with the config
+		template, _ := newPathTemplate(conf.Path)
+		fe.partitions = newPartitionedWriter(conf, template, logger)
+	}
+	return fe
 }
 
 func buildFileWriter(cfg *Config) (io.WriteCloser, error) {
+	if isPathTemplate(cfg.Path) {
+		// the files are opened by partition as telemetry is written
+		return nil, nil
+	}
 	if cfg.Rotation == nil {
 		f, err := os.OpenFile(cfg.Path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 		if err != nil {
@@ -142,13 +159,17 @@ func buildFileWriter(cfg *Config) (io.WriteCloser, error) {
 		}
 		return newBufferedWriteCloser(f), nil
 	}
+	return newRotatingWriter(cfg.Path, cfg.Rotation), nil
+}
+
+func newRotatingWriter(path string, rotation *Rotation) io.WriteCloser {
 	return &lumberjack.Logger{
-		Filename:   cfg.Path,
-		MaxSize:    cfg.Rotation.MaxMegabytes,
-		MaxAge:     cfg.Rotation.MaxDays,
-		MaxBackups: cfg.Rotation.MaxBackups,
-		LocalTime:  cfg.Rotation.LocalTime,
-	}, nil
+		Filename:   path,
+		MaxSize:    rotation.MaxMegabytes,
+		MaxAge:     rotation.MaxDays,
+		MaxBackups: rotation.MaxBackups,
+		LocalTime:  rotation.LocalTime,
+	}
 }
 
 // This is the map of already created File exporters for particular configurations.
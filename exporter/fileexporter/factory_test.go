@@ -6,10 +6,13 @@ package fileexporter
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -19,6 +22,10 @@ func TestCreateDefaultConfig(t *testing.T) {
 	cfg := createDefaultConfig()
 	assert.NotNil(t, cfg, "failed to create default config")
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+
+	// A default config is valid with a plain path
+	cfg.(*Config).Path = "./foo"
+	assert.NoError(t, component.ValidateConfig(cfg))
 }
 
 func TestCreateMetricsExporterError(t *testing.T) {
@@ -93,6 +100,25 @@ func TestCreateLogsExporterError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestCreatePartitionedExporter(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{
+		FormatType: formatTypeJSON,
+		Path:       filepath.Join(dir, "{resource.service.name}.json"),
+	}
+	exp, err := createLogsExporter(
+		context.Background(),
+		exportertest.NewNopCreateSettings(),
+		cfg)
+	assert.NoError(t, err)
+	require.NotNil(t, exp)
+
+	// The files are only created as telemetry is written to them
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
 func TestBuildFileWriter(t *testing.T) {
 	type args struct {
 		cfg *Config
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
 )
 
 // Marshaler configuration used for marhsaling Protobuf
@@ -33,6 +34,9 @@ var logsMarshalers = map[string]plog.Marshaler{
 // exportFunc defines how to export encoded telemetry data.
 type exportFunc func(e *fileExporter, buf []byte) error
 
+// writeFunc defines how to write encoded telemetry data to a file.
+type writeFunc func(w io.Writer, buf []byte) error
+
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
 type fileExporter struct {
 	path  string
@@ -52,9 +56,25 @@ type fileExporter struct {
 	flushInterval time.Duration
 	flushTicker   *time.Ticker
 	stopTicker    chan struct{}
+
+	// partitions writes telemetry data when the path is a template
+	partitions *partitionedWriter
 }
 
 func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	if e.partitions != nil {
+		paths, partitions := e.partitions.partitionTraces(td)
+		var errs error
+		for _, path := range paths {
+			buf, err := e.tracesMarshaler.MarshalTraces(partitions[path])
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			errs = multierr.Append(errs, e.partitions.writeMessage(path, e.compressor(buf)))
+		}
+		return errs
+	}
 	buf, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
@@ -64,6 +84,19 @@ func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error
 }
 
 func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if e.partitions != nil {
+		paths, partitions := e.partitions.partitionMetrics(md)
+		var errs error
+		for _, path := range paths {
+			buf, err := e.metricsMarshaler.MarshalMetrics(partitions[path])
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			errs = multierr.Append(errs, e.partitions.writeMessage(path, e.compressor(buf)))
+		}
+		return errs
+	}
 	buf, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
@@ -73,6 +106,19 @@ func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) err
 }
 
 func (e *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	if e.partitions != nil {
+		paths, partitions := e.partitions.partitionLogs(ld)
+		var errs error
+		for _, path := range paths {
+			buf, err := e.logsMarshaler.MarshalLogs(partitions[path])
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			errs = multierr.Append(errs, e.partitions.writeMessage(path, e.compressor(buf)))
+		}
+		return errs
+	}
 	buf, err := e.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
@@ -85,25 +131,33 @@ func exportMessageAsLine(e *fileExporter, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if _, err := e.file.Write(buf); err != nil {
+	return writeMessageAsLine(e.file, buf)
+}
+
+func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
+	// Ensure only one write operation happens at a time.
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return writeMessageAsBuffer(e.file, buf)
+}
+
+func writeMessageAsLine(w io.Writer, buf []byte) error {
+	if _, err := w.Write(buf); err != nil {
 		return err
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
+	if _, err := io.WriteString(w, "\n"); err != nil {
 		return err
 	}
 	return nil
 }
 
-func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
-	// Ensure only one write operation happens at a time.
-	e.mutex.Lock()
-	defer e.mutex.Unlock()
+func writeMessageAsBuffer(w io.Writer, buf []byte) error {
 	// write the size of each message before writing the message itself.  https://developers.google.com/protocol-buffers/docs/techniques
 	// each encoded object is preceded by 4 bytes (an unsigned 32 bit integer)
 	data := make([]byte, 4, 4+len(buf))
 	binary.BigEndian.PutUint32(data, uint32(len(buf)))
 
-	return binary.Write(e.file, binary.BigEndian, append(data, buf...))
+	return binary.Write(w, binary.BigEndian, append(data, buf...))
 }
 
 // startFlusher starts the flusher.
@@ -111,10 +165,14 @@ func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
 func (e *fileExporter) startFlusher() {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	ff, ok := e.file.(interface{ flush() error })
-	if !ok {
-		// Just in case.
-		return
+	var ff interface{ flush() error } = e.partitions
+	if e.partitions == nil {
+		var ok bool
+		ff, ok = e.file.(interface{ flush() error })
+		if !ok {
+			// Just in case.
+			return
+		}
 	}
 
 	// Create the stop channel.
@@ -135,8 +193,12 @@ func (e *fileExporter) startFlusher() {
 	}()
 }
 
-// Start starts the flush timer if set.
+// Start starts the flush timer if set, and the closing of idle partition files
+// if the path is a template.
 func (e *fileExporter) Start(context.Context, component.Host) error {
+	if e.partitions != nil {
+		e.partitions.start()
+	}
 	if e.flushInterval > 0 {
 		e.startFlusher()
 	}
@@ -144,7 +206,7 @@ func (e *fileExporter) Start(context.Context, component.Host) error {
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
-// It stops the flush ticker if set.
+// It stops the flush ticker if set, and closes the partition files if the path is a template.
 func (e *fileExporter) Shutdown(context.Context) error {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
@@ -154,9 +216,19 @@ func (e *fileExporter) Shutdown(context.Context) error {
 		// Stop the go routine.
 		close(e.stopTicker)
 	}
+	if e.partitions != nil {
+		return e.partitions.Close()
+	}
 	return e.file.Close()
 }
 
+func buildWriteFunc(cfg *Config) writeFunc {
+	if cfg.FormatType == formatTypeProto || cfg.Compression != "" {
+		return writeMessageAsBuffer
+	}
+	return writeMessageAsLine
+}
+
 func buildExportFunc(cfg *Config) func(e *fileExporter, buf []byte) error {
 	if cfg.FormatType == formatTypeProto {
 		return exportMessageAsBuffer
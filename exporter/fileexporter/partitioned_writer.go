@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// partitionFile is the writer of a partition
+type partitionFile struct {
+	writer   io.WriteCloser
+	lastUsed time.Time
+}
+
+// partitionedWriter writes telemetry data to the files rendered from a path template,
+// keeping a writer open per partition. The least recently used writer is closed when
+// too many files are open, and writers are closed once they have been idle for a while.
+type partitionedWriter struct {
+	template     *pathTemplate
+	rotation     *Rotation
+	write        writeFunc
+	maxOpenFiles int
+	idleTimeout  time.Duration
+	logger       *zap.Logger
+	now          func() time.Time
+
+	mutex sync.Mutex
+	files map[string]*partitionFile
+
+	idleTicker *time.Ticker
+	stopIdle   chan struct{}
+}
+
+func newPartitionedWriter(conf *Config, template *pathTemplate, logger *zap.Logger) *partitionedWriter {
+	pw := &partitionedWriter{
+		template:     template,
+		rotation:     conf.Rotation,
+		write:        buildWriteFunc(conf),
+		maxOpenFiles: defaultMaxOpenFiles,
+		idleTimeout:  defaultIdleTimeout,
+		logger:       logger,
+		now:          time.Now,
+		files:        make(map[string]*partitionFile),
+	}
+	// the settings left unset keep their default value
+	if partition := conf.Partition; partition != nil {
+		if partition.MaxOpenFiles > 0 {
+			pw.maxOpenFiles = partition.MaxOpenFiles
+		}
+		if partition.IdleTimeout > 0 {
+			pw.idleTimeout = partition.IdleTimeout
+		}
+	}
+	return pw
+}
+
+// partitionTraces splits the traces by the partition of their resource, returning
+// the paths of the partitions in the order they were found
+func (pw *partitionedWriter) partitionTraces(td ptrace.Traces) ([]string, map[string]ptrace.Traces) {
+	now := pw.now().UTC()
+	if !pw.template.resource {
+		path := pw.template.render(pcommon.NewMap(), now)
+		return []string{path}, map[string]ptrace.Traces{path: td}
+	}
+
+	var paths []string
+	partitions := make(map[string]ptrace.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		path := pw.template.render(rs.Resource().Attributes(), now)
+		partition, ok := partitions[path]
+		if !ok {
+			partition = ptrace.NewTraces()
+			partitions[path] = partition
+			paths = append(paths, path)
+		}
+		rs.CopyTo(partition.ResourceSpans().AppendEmpty())
+	}
+	return paths, partitions
+}
+
+// partitionMetrics splits the metrics by the partition of their resource, returning
+// the paths of the partitions in the order they were found
+func (pw *partitionedWriter) partitionMetrics(md pmetric.Metrics) ([]string, map[string]pmetric.Metrics) {
+	now := pw.now().UTC()
+	if !pw.template.resource {
+		path := pw.template.render(pcommon.NewMap(), now)
+		return []string{path}, map[string]pmetric.Metrics{path: md}
+	}
+
+	var paths []string
+	partitions := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		path := pw.template.render(rm.Resource().Attributes(), now)
+		partition, ok := partitions[path]
+		if !ok {
+			partition = pmetric.NewMetrics()
+			partitions[path] = partition
+			paths = append(paths, path)
+		}
+		rm.CopyTo(partition.ResourceMetrics().AppendEmpty())
+	}
+	return paths, partitions
+}
+
+// partitionLogs splits the logs by the partition of their resource, returning
+// the paths of the partitions in the order they were found
+func (pw *partitionedWriter) partitionLogs(ld plog.Logs) ([]string, map[string]plog.Logs) {
+	now := pw.now().UTC()
+	if !pw.template.resource {
+		path := pw.template.render(pcommon.NewMap(), now)
+		return []string{path}, map[string]plog.Logs{path: ld}
+	}
+
+	var paths []string
+	partitions := make(map[string]plog.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		path := pw.template.render(rl.Resource().Attributes(), now)
+		partition, ok := partitions[path]
+		if !ok {
+			partition = plog.NewLogs()
+			partitions[path] = partition
+			paths = append(paths, path)
+		}
+		rl.CopyTo(partition.ResourceLogs().AppendEmpty())
+	}
+	return paths, partitions
+}
+
+// writeMessage writes an encoded message to the file of the partition
+func (pw *partitionedWriter) writeMessage(path string, buf []byte) error {
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	file, ok := pw.files[path]
+	if !ok {
+		if len(pw.files) >= pw.maxOpenFiles {
+			pw.closeLeastRecentlyUsed()
+		}
+		writer, err := pw.openFile(path)
+		if err != nil {
+			return err
+		}
+		file = &partitionFile{writer: writer}
+		pw.files[path] = file
+	}
+	file.lastUsed = pw.now()
+	return pw.write(file.writer, buf)
+}
+
+// openFile opens the file of a partition. As a partition may be opened again after
+// its writer was closed, the telemetry data is appended to the file.
+func (pw *partitionedWriter) openFile(path string) (io.WriteCloser, error) {
+	if pw.rotation != nil {
+		return newRotatingWriter(path, pw.rotation), nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return newBufferedWriteCloser(f), nil
+}
+
+func (pw *partitionedWriter) closeLeastRecentlyUsed() {
+	var oldestPath string
+	var oldest *partitionFile
+	for path, file := range pw.files {
+		if oldest == nil || file.lastUsed.Before(oldest.lastUsed) {
+			oldestPath, oldest = path, file
+		}
+	}
+	if oldest != nil {
+		pw.closeFile(oldestPath, oldest)
+	}
+}
+
+func (pw *partitionedWriter) closeFile(path string, file *partitionFile) {
+	delete(pw.files, path)
+	if err := file.writer.Close(); err != nil {
+		pw.logger.Error("failed to close partition file", zap.String("path", path), zap.Error(err))
+	}
+}
+
+// closeIdle closes the writers which have not been used since the idle timeout
+func (pw *partitionedWriter) closeIdle() {
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	deadline := pw.now().Add(-pw.idleTimeout)
+	for path, file := range pw.files {
+		if file.lastUsed.Before(deadline) {
+			pw.closeFile(path, file)
+		}
+	}
+}
+
+// flush flushes the buffered writers
+func (pw *partitionedWriter) flush() error {
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	var errs error
+	for _, file := range pw.files {
+		if ff, ok := file.writer.(interface{ flush() error }); ok {
+			errs = multierr.Append(errs, ff.flush())
+		}
+	}
+	return errs
+}
+
+// start starts closing the idle writers.
+func (pw *partitionedWriter) start() {
+	stop := make(chan struct{})
+	// Writers are closed after being idle between one and one and a half idle timeouts
+	ticker := time.NewTicker(pw.idleTimeout / 2)
+	pw.stopIdle, pw.idleTicker = stop, ticker
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				pw.closeIdle()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops closing the idle writers and closes all the writers.
+func (pw *partitionedWriter) Close() error {
+	if pw.idleTicker != nil {
+		pw.idleTicker.Stop()
+		close(pw.stopIdle)
+		pw.idleTicker = nil
+	}
+
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	var errs error
+	for path, file := range pw.files {
+		delete(pw.files, path)
+		errs = multierr.Append(errs, file.writer.Close())
+	}
+	return errs
+}
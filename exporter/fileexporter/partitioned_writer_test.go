@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+// readPartition reads the messages written to a partition file
+func readPartition(t *testing.T, conf *Config, path string) [][]byte {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var messages [][]byte
+	br := bufio.NewReader(f)
+	for {
+		var buf []byte
+		var isEnd bool
+		if conf.FormatType == formatTypeJSON && conf.Compression == "" {
+			buf, isEnd, err = readJSONMessage(br)
+		} else {
+			buf, isEnd, err = readMessageFromStream(br)
+		}
+		require.NoError(t, err)
+		if isEnd {
+			return messages
+		}
+		buf, err = buildUnCompressor(conf.Compression)(buf)
+		require.NoError(t, err)
+		messages = append(messages, buf)
+	}
+}
+
+func TestPartitionedTracesExporter(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *Config
+	}{
+		{
+			name: "json",
+			conf: &Config{FormatType: formatTypeJSON},
+		},
+		{
+			name: "proto_zstd",
+			conf: &Config{FormatType: formatTypeProto, Compression: compressionZSTD},
+		},
+		{
+			name: "rotation",
+			conf: &Config{FormatType: formatTypeJSON, Rotation: &Rotation{MaxBackups: defaultMaxBackups}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			conf := tt.conf
+			conf.Path = filepath.Join(dir, "{resource.service.name}", "%Y", "traces.data")
+			require.NoError(t, conf.Validate())
+			fe := newFileExporter(conf, nil, zap.NewNop())
+			require.NotNil(t, fe.partitions)
+			fe.partitions.now = func() time.Time { return time.Date(2023, 8, 9, 0, 0, 0, 0, time.UTC) }
+
+			td := ptrace.NewTraces()
+			for _, service := range []string{"checkout", "cart", "checkout"} {
+				rs := td.ResourceSpans().AppendEmpty()
+				rs.Resource().Attributes().PutStr("service.name", service)
+				rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(service + "-span")
+			}
+
+			assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+			assert.NoError(t, fe.consumeTraces(context.Background(), td))
+			assert.NoError(t, fe.consumeTraces(context.Background(), td))
+			assert.NoError(t, fe.Shutdown(context.Background()))
+
+			var unmarshaler ptrace.Unmarshaler = &ptrace.JSONUnmarshaler{}
+			if conf.FormatType == formatTypeProto {
+				unmarshaler = &ptrace.ProtoUnmarshaler{}
+			}
+			for service, spans := range map[string]int{"checkout": 2, "cart": 1} {
+				messages := readPartition(t, conf, filepath.Join(dir, service, "2023", "traces.data"))
+				require.Len(t, messages, 2)
+				for _, buf := range messages {
+					got, err := unmarshaler.UnmarshalTraces(buf)
+					require.NoError(t, err)
+					assert.Equal(t, spans, got.SpanCount())
+					for i := 0; i < got.ResourceSpans().Len(); i++ {
+						name, ok := got.ResourceSpans().At(i).Resource().Attributes().Get("service.name")
+						require.True(t, ok)
+						assert.Equal(t, service, name.Str())
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPartitionedMetricsAndLogsExporter(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:       filepath.Join(dir, "%Y-%m-%d.json"),
+		FormatType: formatTypeJSON,
+	}
+	fe := newFileExporter(conf, nil, zap.NewNop())
+	fe.partitions.now = func() time.Time { return time.Date(2023, 8, 9, 0, 0, 0, 0, time.UTC) }
+
+	md := testdata.GenerateMetricsTwoMetrics()
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.consumeMetrics(context.Background(), md))
+	assert.NoError(t, fe.consumeLogs(context.Background(), ld))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	messages := readPartition(t, conf, filepath.Join(dir, "2023-08-09.json"))
+	require.Len(t, messages, 2)
+	gotMd, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(messages[0])
+	require.NoError(t, err)
+	assert.EqualValues(t, md, gotMd)
+	gotLd, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(messages[1])
+	require.NoError(t, err)
+	assert.EqualValues(t, ld, gotLd)
+}
+
+func TestPartitionedWriterDefaults(t *testing.T) {
+	template, err := newPathTemplate("{resource.service.name}.json")
+	require.NoError(t, err)
+
+	pw := newPartitionedWriter(&Config{FormatType: formatTypeJSON}, template, zap.NewNop())
+	assert.Equal(t, defaultMaxOpenFiles, pw.maxOpenFiles)
+	assert.Equal(t, defaultIdleTimeout, pw.idleTimeout)
+
+	pw = newPartitionedWriter(&Config{FormatType: formatTypeJSON, Partition: &Partition{MaxOpenFiles: 10}}, template, zap.NewNop())
+	assert.Equal(t, 10, pw.maxOpenFiles)
+	assert.Equal(t, defaultIdleTimeout, pw.idleTimeout)
+}
+
+func TestPartitionedWriterMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:       filepath.Join(dir, "{resource.service.name}.json"),
+		FormatType: formatTypeJSON,
+		Partition:  &Partition{MaxOpenFiles: 2, IdleTimeout: time.Minute},
+	}
+	template, err := newPathTemplate(conf.Path)
+	require.NoError(t, err)
+	pw := newPartitionedWriter(conf, template, zap.NewNop())
+	now := time.Now()
+	pw.now = func() time.Time { return now }
+
+	write := func(name string) {
+		now = now.Add(time.Second)
+		require.NoError(t, pw.writeMessage(filepath.Join(dir, name+".json"), []byte(name)))
+	}
+	write("a")
+	write("b")
+	write("a")
+	// Closes b, the least recently written file
+	write("c")
+	assert.Len(t, pw.files, 2)
+	assert.Contains(t, pw.files, filepath.Join(dir, "a.json"))
+	assert.Contains(t, pw.files, filepath.Join(dir, "c.json"))
+	// Appends to b once opened again
+	write("b")
+	assert.Len(t, pw.files, 2)
+	require.NoError(t, pw.Close())
+	assert.Empty(t, pw.files)
+
+	for name, expected := range map[string]int{"a": 2, "b": 2, "c": 1} {
+		messages := readPartition(t, conf, filepath.Join(dir, name+".json"))
+		assert.Len(t, messages, expected, name)
+	}
+}
+
+func TestPartitionedWriterIdleTimeout(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:       filepath.Join(dir, "{resource.service.name}.json"),
+		FormatType: formatTypeJSON,
+		Partition:  &Partition{MaxOpenFiles: 10, IdleTimeout: time.Minute},
+	}
+	template, err := newPathTemplate(conf.Path)
+	require.NoError(t, err)
+	pw := newPartitionedWriter(conf, template, zap.NewNop())
+	now := time.Now()
+	pw.now = func() time.Time { return now }
+
+	require.NoError(t, pw.writeMessage(filepath.Join(dir, "a.json"), []byte("a")))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, pw.writeMessage(filepath.Join(dir, "b.json"), []byte("b")))
+
+	now = now.Add(45 * time.Second)
+	pw.closeIdle()
+	assert.Len(t, pw.files, 1)
+	assert.Contains(t, pw.files, filepath.Join(dir, "b.json"))
+	// The closed file was flushed
+	assert.Len(t, readPartition(t, conf, filepath.Join(dir, "a.json")), 1)
+
+	now = now.Add(time.Minute)
+	pw.closeIdle()
+	assert.Empty(t, pw.files)
+	require.NoError(t, pw.Close())
+}
+
+func TestPartitionedWriterClosesIdleFiles(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:       filepath.Join(dir, "{resource.service.name}.json"),
+		FormatType: formatTypeJSON,
+		Partition:  &Partition{MaxOpenFiles: 10, IdleTimeout: 100 * time.Millisecond},
+	}
+	template, err := newPathTemplate(conf.Path)
+	require.NoError(t, err)
+	pw := newPartitionedWriter(conf, template, zap.NewNop())
+	pw.start()
+	defer func() { require.NoError(t, pw.Close()) }()
+
+	require.NoError(t, pw.writeMessage(filepath.Join(dir, "a.json"), []byte("a")))
+	assert.Eventually(t, func() bool {
+		pw.mutex.Lock()
+		defer pw.mutex.Unlock()
+		return len(pw.files) == 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestPartitionedWriterRotation(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:       filepath.Join(dir, "{resource.service.name}.json"),
+		FormatType: formatTypeJSON,
+		Rotation:   &Rotation{MaxMegabytes: 10},
+	}
+	template, err := newPathTemplate(conf.Path)
+	require.NoError(t, err)
+	pw := newPartitionedWriter(conf, template, zap.NewNop())
+	assert.Equal(t, defaultMaxOpenFiles, pw.maxOpenFiles)
+	assert.Equal(t, defaultIdleTimeout, pw.idleTimeout)
+
+	path := filepath.Join(dir, "a.json")
+	require.NoError(t, pw.writeMessage(path, []byte("a")))
+	writer, ok := pw.files[path].writer.(*lumberjack.Logger)
+	require.True(t, ok)
+	assert.Equal(t, 10, writer.MaxSize)
+	require.NoError(t, pw.Close())
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	resourcePlaceholderPrefix = "resource."
+
+	// the value of a resource placeholder when the attribute is missing
+	unknownPartition = "unknown"
+)
+
+// timeLayouts maps the time directives of a path template to time layouts
+var timeLayouts = map[byte]string{
+	'Y': "2006",
+	'm': "01",
+	'd': "02",
+	'H': "15",
+	'M': "04",
+	'S': "05",
+}
+
+// pathTemplate is a path partitioning telemetry by resource attribute with
+// {resource.<key>} placeholders, and by time with %Y, %m, %d, %H, %M and %S directives.
+// A literal { is written {{, and a literal % is written %%.
+type pathTemplate struct {
+	parts []templatePart
+	// resource is true when the path depends on the resource attributes
+	resource bool
+}
+
+// templatePart is either a literal, a time layout or a resource attribute
+type templatePart struct {
+	literal    string
+	timeLayout string
+	attribute  string
+}
+
+// isPathTemplate returns whether the path holds placeholders or time directives.
+func isPathTemplate(path string) bool {
+	return strings.ContainsAny(path, "{%")
+}
+
+func newPathTemplate(path string) (*pathTemplate, error) {
+	t := &pathTemplate{}
+	var literal strings.Builder
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			t.parts = append(t.parts, templatePart{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '%':
+			if i+1 == len(path) {
+				return nil, errors.New("path template ends with an incomplete time directive")
+			}
+			i++
+			if path[i] == '%' {
+				literal.WriteByte('%')
+				continue
+			}
+			layout, ok := timeLayouts[path[i]]
+			if !ok {
+				return nil, fmt.Errorf("unsupported time directive %%%c in path template", path[i])
+			}
+			flushLiteral()
+			t.parts = append(t.parts, templatePart{timeLayout: layout})
+		case '{':
+			if i+1 < len(path) && path[i+1] == '{' {
+				literal.WriteByte('{')
+				i++
+				continue
+			}
+			end := strings.IndexByte(path[i:], '}')
+			if end < 0 {
+				return nil, errors.New("unclosed placeholder in path template")
+			}
+			placeholder := path[i+1 : i+end]
+			key, ok := strings.CutPrefix(placeholder, resourcePlaceholderPrefix)
+			if !ok || key == "" {
+				return nil, fmt.Errorf("unsupported placeholder {%s} in path template, must be {%s<key>}", placeholder, resourcePlaceholderPrefix)
+			}
+			flushLiteral()
+			t.parts = append(t.parts, templatePart{attribute: key})
+			t.resource = true
+			i += end
+		default:
+			literal.WriteByte(path[i])
+		}
+	}
+	flushLiteral()
+	return t, nil
+}
+
+// render returns the path of the partition of the resource at the given time
+func (t *pathTemplate) render(resource pcommon.Map, now time.Time) string {
+	var path strings.Builder
+	for _, part := range t.parts {
+		switch {
+		case part.timeLayout != "":
+			path.WriteString(now.Format(part.timeLayout))
+		case part.attribute != "":
+			path.WriteString(attributePathSegment(resource, part.attribute))
+		default:
+			path.WriteString(part.literal)
+		}
+	}
+	return path.String()
+}
+
+// attributePathSegment returns the value of the attribute, made safe for use in a path
+func attributePathSegment(resource pcommon.Map, key string) string {
+	value, ok := resource.Get(key)
+	if !ok {
+		return unknownPartition
+	}
+	segment := strings.NewReplacer("/", "_", "\\", "_").Replace(value.AsString())
+	// An attribute cannot point the path to another directory
+	if segment == "" || segment == "." || segment == ".." {
+		return unknownPartition
+	}
+	return segment
+}
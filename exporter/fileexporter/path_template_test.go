@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestPathTemplate(t *testing.T) {
+	now := time.Date(2023, 8, 9, 7, 5, 3, 0, time.UTC)
+	resource := pcommon.NewMap()
+	resource.PutStr("service.name", "checkout")
+	resource.PutInt("shard", 3)
+	resource.PutStr("slashed", "../etc/passwd")
+	resource.PutStr("dots", "..")
+
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		resource bool
+	}{
+		{
+			name:     "time",
+			path:     "/data/%Y/%m/%d/%H-%M-%S.json",
+			expected: "/data/2023/08/09/07-05-03.json",
+		},
+		{
+			name:     "resource",
+			path:     "/data/{resource.service.name}/{resource.shard}.json",
+			expected: "/data/checkout/3.json",
+			resource: true,
+		},
+		{
+			name:     "resource_and_time",
+			path:     "/data/{resource.service.name}/%Y/%m/%d/%H.jsonl",
+			expected: "/data/checkout/2023/08/09/07.jsonl",
+			resource: true,
+		},
+		{
+			name:     "missing_attribute",
+			path:     "/data/{resource.host.name}.json",
+			expected: "/data/unknown.json",
+			resource: true,
+		},
+		{
+			name:     "escaped_percent",
+			path:     "/data/100%%-%Y.json",
+			expected: "/data/100%-2023.json",
+		},
+		{
+			name:     "escaped_brace",
+			path:     "/data/{{literal}/{resource.service.name}-%Y.json",
+			expected: "/data/{literal}/checkout-2023.json",
+			resource: true,
+		},
+		{
+			name:     "escaped_brace_only",
+			path:     "/data/{{literal}.json",
+			expected: "/data/{literal}.json",
+		},
+		{
+			name:     "path_separator_in_attribute",
+			path:     "/data/{resource.slashed}.json",
+			expected: "/data/.._etc_passwd.json",
+			resource: true,
+		},
+		{
+			name:     "parent_directory_attribute",
+			path:     "/data/{resource.dots}/file.json",
+			expected: "/data/unknown/file.json",
+			resource: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, isPathTemplate(tt.path))
+			template, err := newPathTemplate(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.resource, template.resource)
+			assert.Equal(t, tt.expected, template.render(resource, now))
+		})
+	}
+}
+
+func TestPathTemplateErrors(t *testing.T) {
+	tests := []struct {
+		path         string
+		errorMessage string
+	}{
+		{
+			path:         "/data/%Y/%q.json",
+			errorMessage: "unsupported time directive %q in path template",
+		},
+		{
+			path:         "/data/%",
+			errorMessage: "path template ends with an incomplete time directive",
+		},
+		{
+			path:         "/data/{resource.service.name.json",
+			errorMessage: "unclosed placeholder in path template",
+		},
+		{
+			path:         "/data/{attributes.service.name}.json",
+			errorMessage: "unsupported placeholder {attributes.service.name} in path template, must be {resource.<key>}",
+		},
+		{
+			path:         "/data/{resource.}.json",
+			errorMessage: "unsupported placeholder {resource.} in path template, must be {resource.<key>}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := newPathTemplate(tt.path)
+			assert.EqualError(t, err, tt.errorMessage)
+		})
+	}
+}
+
+func TestIsPathTemplate(t *testing.T) {
+	assert.False(t, isPathTemplate("./data/file.json"))
+	assert.True(t, isPathTemplate("./data/%Y.json"))
+	assert.True(t, isPathTemplate("./data/{resource.service.name}.json"))
+}
@@ -6,9 +6,11 @@ package prometheusexporter // import "github.com/open-telemetry/opentelemetry-co
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -20,6 +22,10 @@ import (
 
 const (
 	targetMetricName = "target_info"
+
+	// nativeHistogramZeroThreshold is the width of the zero bucket of native histograms, as exponential
+	// histograms do not carry it yet.
+	nativeHistogramZeroThreshold = 1e-128
 )
 
 var (
@@ -30,20 +36,22 @@ type collector struct {
 	accumulator accumulator
 	logger      *zap.Logger
 
-	sendTimestamps    bool
-	addMetricSuffixes bool
-	namespace         string
-	constLabels       prometheus.Labels
+	sendTimestamps        bool
+	addMetricSuffixes     bool
+	exponentialHistograms string
+	namespace             string
+	constLabels           prometheus.Labels
 }
 
 func newCollector(config *Config, logger *zap.Logger) *collector {
 	return &collector{
-		accumulator:       newAccumulator(logger, config.MetricExpiration),
-		logger:            logger,
-		namespace:         prometheustranslator.CleanUpString(config.Namespace),
-		sendTimestamps:    config.SendTimestamps,
-		constLabels:       config.ConstLabels,
-		addMetricSuffixes: config.AddMetricSuffixes,
+		accumulator:           newAccumulator(logger, config.MetricExpiration),
+		logger:                logger,
+		namespace:             prometheustranslator.CleanUpString(config.Namespace),
+		sendTimestamps:        config.SendTimestamps,
+		constLabels:           config.ConstLabels,
+		addMetricSuffixes:     config.AddMetricSuffixes,
+		exponentialHistograms: config.ExponentialHistograms,
 	}
 }
 
@@ -102,6 +110,8 @@ func (c *collector) convertMetric(metric pmetric.Metric, resourceAttrs pcommon.M
 		return c.convertSum(metric, resourceAttrs)
 	case pmetric.MetricTypeHistogram:
 		return c.convertDoubleHistogram(metric, resourceAttrs)
+	case pmetric.MetricTypeExponentialHistogram:
+		return c.convertExponentialHistogram(metric, resourceAttrs)
 	case pmetric.MetricTypeSummary:
 		return c.convertSummary(metric, resourceAttrs)
 	}
@@ -271,6 +281,151 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	return m, nil
 }
 
+func (c *collector) convertExponentialHistogram(metric pmetric.Metric, resourceAttrs pcommon.Map) (prometheus.Metric, error) {
+	ip := metric.ExponentialHistogram().DataPoints().At(0)
+	desc, attributes := c.getMetricMetadata(metric, ip.Attributes(), resourceAttrs)
+
+	var m prometheus.Metric
+	var err error
+	if c.exponentialHistograms != exponentialHistogramsNative {
+		m, err = prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), exponentialToClassicBuckets(ip), attributes...)
+		if err != nil {
+			return nil, err
+		}
+		if exemplars := convertExemplars(ip.Exemplars()); len(exemplars) > 0 {
+			m, err = prometheus.NewMetricWithExemplars(m, exemplars...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		m, err = newNativeHistogram(desc, ip, attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.sendTimestamps {
+		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
+	}
+	return m, nil
+}
+
+// exponentialToClassicBuckets returns the cumulative counts of the buckets of an exponential histogram,
+// by upper bound. The zero bucket is included in the count of the first positive bucket.
+func exponentialToClassicBuckets(ip pmetric.ExponentialHistogramDataPoint) map[float64]uint64 {
+	scale := ip.Scale()
+	points := make(map[float64]uint64, ip.Negative().BucketCounts().Len()+ip.Positive().BucketCounts().Len())
+	cumCount := uint64(0)
+
+	// negative bucket i holds values in [-base^(i+1), -base^i), from the lowest values to the highest
+	negative := ip.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		cumCount += negative.BucketCounts().At(i)
+		if bound := -exponentialBucketBound(scale, negative.Offset()+int32(i)); !math.IsInf(bound, -1) {
+			points[bound] = cumCount
+		}
+	}
+
+	cumCount += ip.ZeroCount()
+
+	// positive bucket i holds values in (base^i, base^(i+1)]
+	positive := ip.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		cumCount += positive.BucketCounts().At(i)
+		if bound := exponentialBucketBound(scale, positive.Offset()+int32(i)+1); !math.IsInf(bound, 1) {
+			points[bound] = cumCount
+		}
+	}
+	return points
+}
+
+// exponentialBucketBound returns base^index, where base is 2^(2^-scale).
+func exponentialBucketBound(scale int32, index int32) float64 {
+	if scale <= 0 {
+		return math.Ldexp(1, int(index)<<-scale)
+	}
+	return math.Exp2(float64(index) / float64(int32(1)<<scale))
+}
+
+// nativeHistogram is a constant prometheus native histogram. client_golang can only expose native
+// histograms it records itself, so the data point is written directly to the exposition protobuf.
+type nativeHistogram struct {
+	desc       *prometheus.Desc
+	labelPairs []*dto.LabelPair
+	histogram  *dto.Histogram
+}
+
+func newNativeHistogram(desc *prometheus.Desc, ip pmetric.ExponentialHistogramDataPoint, labelValues []string) (prometheus.Metric, error) {
+	scale := ip.Scale()
+	if scale < -4 {
+		return nil, fmt.Errorf("cannot convert exponential histogram to native histogram, scale must be >= -4, was %d", scale)
+	}
+	// native histograms support scales up to 8, higher scales are merged down
+	var scaleDown int32
+	if scale > 8 {
+		scaleDown = scale - 8
+		scale = 8
+	}
+
+	count := ip.Count()
+	sum := ip.Sum()
+	zeroThreshold := nativeHistogramZeroThreshold
+	zeroCount := ip.ZeroCount()
+	h := &dto.Histogram{
+		SampleCount:   &count,
+		SampleSum:     &sum,
+		Schema:        &scale,
+		ZeroThreshold: &zeroThreshold,
+		ZeroCount:     &zeroCount,
+	}
+	h.PositiveSpan, h.PositiveDelta = nativeHistogramBuckets(ip.Positive(), scaleDown)
+	h.NegativeSpan, h.NegativeDelta = nativeHistogramBuckets(ip.Negative(), scaleDown)
+
+	return &nativeHistogram{
+		desc:       desc,
+		labelPairs: prometheus.MakeLabelPairs(desc, labelValues),
+		histogram:  h,
+	}, nil
+}
+
+func (h *nativeHistogram) Desc() *prometheus.Desc {
+	return h.desc
+}
+
+func (h *nativeHistogram) Write(out *dto.Metric) error {
+	out.Label = h.labelPairs
+	out.Histogram = h.histogram
+	return nil
+}
+
+// nativeHistogramBuckets converts the dense buckets of an exponential histogram to a single span of
+// native histogram buckets, with counts encoded as deltas to the previous bucket. Exponential histogram
+// bucket i is (base^i, base^(i+1)] while native histogram bucket i is (base^(i-1), base^i], so indexes
+// are shifted by one. When scaling down, 2^scaleDown buckets are merged into one.
+func nativeHistogramBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]*dto.BucketSpan, []int64) {
+	bucketCounts := buckets.BucketCounts()
+	if bucketCounts.Len() == 0 {
+		return nil, nil
+	}
+
+	first := buckets.Offset()>>scaleDown + 1
+	last := (buckets.Offset()+int32(bucketCounts.Len())-1)>>scaleDown + 1
+	counts := make([]int64, last-first+1)
+	for i := 0; i < bucketCounts.Len(); i++ {
+		counts[(buckets.Offset()+int32(i))>>scaleDown+1-first] += int64(bucketCounts.At(i))
+	}
+
+	deltas := make([]int64, len(counts))
+	var previous int64
+	for i, count := range counts {
+		deltas[i] = count - previous
+		previous = count
+	}
+	length := uint32(len(counts))
+	return []*dto.BucketSpan{{Offset: &first, Length: &length}}, deltas
+}
+
 func (c *collector) createTargetInfoMetrics(resourceAttrs []pcommon.Map) ([]prometheus.Metric, error) {
 	var lastErr error
 
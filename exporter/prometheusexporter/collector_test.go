@@ -582,6 +582,156 @@ func TestAccumulateHistograms(t *testing.T) {
 	}
 }
 
+func TestAccumulateExponentialHistograms(t *testing.T) {
+	tests := []struct {
+		name                  string
+		exponentialHistograms string
+		check                 func(t *testing.T, h *io_prometheus_client.Histogram)
+	}{
+		{
+			name:                  "Native",
+			exponentialHistograms: exponentialHistogramsNative,
+			check: func(t *testing.T, h *io_prometheus_client.Histogram) {
+				require.Empty(t, h.Bucket)
+				require.Equal(t, int32(0), h.GetSchema())
+				require.Equal(t, uint64(1), h.GetZeroCount())
+				require.Equal(t, nativeHistogramZeroThreshold, h.GetZeroThreshold())
+				require.Len(t, h.PositiveSpan, 1)
+				require.Equal(t, int32(2), h.PositiveSpan[0].GetOffset())
+				require.Equal(t, uint32(2), h.PositiveSpan[0].GetLength())
+				require.Equal(t, []int64{2, 1}, h.PositiveDelta)
+				require.Len(t, h.NegativeSpan, 1)
+				require.Equal(t, int32(1), h.NegativeSpan[0].GetOffset())
+				require.Equal(t, []int64{1}, h.NegativeDelta)
+			},
+		},
+		{
+			name:                  "Classic",
+			exponentialHistograms: exponentialHistogramsClassic,
+			check: func(t *testing.T, h *io_prometheus_client.Histogram) {
+				require.Nil(t, h.Schema)
+				points := map[float64]uint64{-1: 1, 4: 4, 8: 7}
+				require.Len(t, h.Bucket, len(points))
+				for _, b := range h.Bucket {
+					require.Equal(t, points[b.GetUpperBound()], b.GetCumulativeCount())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, sendTimestamp := range []bool{true, false} {
+			name := tt.name
+			if sendTimestamp {
+				name += "/WithTimestamp"
+			}
+			t.Run(name, func(t *testing.T) {
+				ts := time.Now()
+				metric := pmetric.NewMetric()
+				metric.SetName("test_metric")
+				metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+				dp.SetScale(0)
+				dp.SetCount(7)
+				dp.SetSum(20)
+				dp.SetZeroCount(1)
+				dp.Positive().SetOffset(1)
+				dp.Positive().BucketCounts().FromRaw([]uint64{2, 3})
+				dp.Negative().BucketCounts().FromRaw([]uint64{1})
+				dp.Attributes().PutStr("label_1", "1")
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+
+				c := collector{
+					accumulator: &mockAccumulator{
+						[]pmetric.Metric{metric},
+						pcommon.NewMap(),
+					},
+					sendTimestamps:        sendTimestamp,
+					exponentialHistograms: tt.exponentialHistograms,
+					logger:                zap.NewNop(),
+				}
+
+				ch := make(chan prometheus.Metric, 1)
+				go func() {
+					c.Collect(ch)
+					close(ch)
+				}()
+
+				n := 0
+				for m := range ch {
+					n++
+					require.Contains(t, m.Desc().String(), "fqName: \"test_metric\"")
+
+					pbMetric := io_prometheus_client.Metric{}
+					require.NoError(t, m.Write(&pbMetric))
+					require.Len(t, pbMetric.Label, 1)
+					require.Equal(t, "label_1", pbMetric.Label[0].GetName())
+					require.Equal(t, "1", pbMetric.Label[0].GetValue())
+
+					if sendTimestamp {
+						require.Equal(t, ts.UnixNano()/1e6, *(pbMetric.TimestampMs))
+					} else {
+						require.Nil(t, pbMetric.TimestampMs)
+					}
+
+					h := pbMetric.Histogram
+					require.NotNil(t, h)
+					require.Equal(t, uint64(7), h.GetSampleCount())
+					require.Equal(t, 20.0, h.GetSampleSum())
+					tt.check(t, h)
+				}
+				require.Equal(t, 1, n)
+			})
+		}
+	}
+}
+
+func TestGatherNativeHistogram(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(2)
+	dp.Positive().BucketCounts().FromRaw([]uint64{2})
+	dp.Attributes().PutStr("label_1", "1")
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(&collector{
+		accumulator:           &mockAccumulator{[]pmetric.Metric{metric}, pcommon.NewMap()},
+		logger:                zap.NewNop(),
+		exponentialHistograms: exponentialHistogramsNative,
+	}))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	require.Equal(t, io_prometheus_client.MetricType_HISTOGRAM, families[0].GetType())
+	require.Equal(t, []int64{2}, families[0].Metric[0].Histogram.PositiveDelta)
+}
+
+func TestNativeHistogramScaleDown(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(10)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1, 1, 1})
+	dp.Negative().SetOffset(-3)
+	dp.Negative().BucketCounts().FromRaw([]uint64{1, 1, 1, 1})
+
+	m, err := newNativeHistogram(prometheus.NewDesc("test_metric", "", nil, nil), dp, nil)
+	require.NoError(t, err)
+	pbMetric := io_prometheus_client.Metric{}
+	require.NoError(t, m.Write(&pbMetric))
+
+	h := pbMetric.Histogram
+	require.Equal(t, int32(8), h.GetSchema())
+	require.Equal(t, int32(1), h.PositiveSpan[0].GetOffset())
+	require.Equal(t, []int64{4, -3}, h.PositiveDelta)
+	require.Equal(t, int32(0), h.NegativeSpan[0].GetOffset())
+	require.Equal(t, []int64{3, -2}, h.NegativeDelta)
+
+	dp.SetScale(-5)
+	_, err = newNativeHistogram(prometheus.NewDesc("test_metric", "", nil, nil), dp, nil)
+	require.ErrorContains(t, err, "scale must be >= -4, was -5")
+}
+
 func TestAccumulateSummary(t *testing.T) {
 	fillQuantileValue := func(pN, value float64, dest pmetric.SummaryDataPointValueAtQuantile) {
 		dest.SetQuantile(pN)
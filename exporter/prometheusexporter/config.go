@@ -4,6 +4,7 @@
 package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -37,11 +38,26 @@ type Config struct {
 
 	// AddMetricSuffixes controls whether suffixes are added to metric names. Defaults to true.
 	AddMetricSuffixes bool `mapstructure:"add_metric_suffixes"`
+
+	// ExponentialHistograms controls how exponential histograms are exposed: converted to classic histograms,
+	// or as native histograms, which are only available with the protobuf exposition format.
+	// Defaults to classic.
+	ExponentialHistograms string `mapstructure:"exponential_histograms"`
 }
 
+const (
+	exponentialHistogramsNative  = "native"
+	exponentialHistogramsClassic = "classic"
+)
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	switch cfg.ExponentialHistograms {
+	case "", exponentialHistogramsNative, exponentialHistogramsClassic:
+		return nil
+	default:
+		return fmt.Errorf("exponential_histograms must be one of %s or %s, got %q", exponentialHistogramsNative, exponentialHistogramsClassic, cfg.ExponentialHistograms)
+	}
 }
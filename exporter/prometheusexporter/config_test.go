@@ -50,9 +50,10 @@ func TestLoadConfig(t *testing.T) {
 					"label1":        "value1",
 					"another label": "spaced value",
 				},
-				SendTimestamps:    true,
-				MetricExpiration:  60 * time.Minute,
-				AddMetricSuffixes: false,
+				SendTimestamps:        true,
+				MetricExpiration:      60 * time.Minute,
+				AddMetricSuffixes:     false,
+				ExponentialHistograms: "native",
 			},
 		},
 	}
@@ -71,3 +72,14 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateExponentialHistograms(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ExponentialHistograms = exponentialHistogramsClassic
+	assert.NoError(t, cfg.Validate())
+
+	cfg.ExponentialHistograms = "sparse"
+	assert.EqualError(t, cfg.Validate(), `exponential_histograms must be one of native or classic, got "sparse"`)
+}
@@ -5,6 +5,7 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 
 import (
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -45,6 +46,35 @@ type Config struct {
 
 	// AddMetricSuffixes controls whether unit and type suffixes are added to metrics on export
 	AddMetricSuffixes bool `mapstructure:"add_metric_suffixes"`
+
+	// SendMetadata controls whether the type, unit and help of each metric family are sent
+	// along with the time series in the write requests
+	SendMetadata bool `mapstructure:"send_metadata"`
+
+	// Tenant allows fanning out the metrics to the tenants of a multi-tenant backend
+	Tenant *TenantConfig `mapstructure:"tenant"`
+}
+
+// TenantConfig configures how the tenant of the metrics is resolved and sent.
+type TenantConfig struct {
+	// ResourceAttribute is the resource attribute holding the tenant of the metrics
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// Header is the HTTP header the tenant is sent in. Default is X-Scope-OrgID.
+	Header string `mapstructure:"header"`
+
+	// Default is the tenant of the metrics without the resource attribute. If empty,
+	// these metrics are sent without tenant header.
+	Default string `mapstructure:"default"`
+}
+
+const defaultTenantHeader = "X-Scope-OrgID"
+
+func (tc *TenantConfig) header() string {
+	if tc.Header != "" {
+		return tc.Header
+	}
+	return defaultTenantHeader
 }
 
 type CreatedMetric struct {
@@ -90,6 +120,17 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("remote write consumer number can't be negative")
 	}
 
+	if cfg.Tenant != nil {
+		if cfg.Tenant.ResourceAttribute == "" {
+			return fmt.Errorf("tenant resource_attribute must be set")
+		}
+		for name := range cfg.HTTPClientSettings.Headers {
+			if strings.EqualFold(name, cfg.Tenant.header()) {
+				return fmt.Errorf("tenant header %q can't also be set in headers", name)
+			}
+		}
+	}
+
 	if cfg.TargetInfo == nil {
 		cfg.TargetInfo = &TargetInfo{
 			Enabled: true,
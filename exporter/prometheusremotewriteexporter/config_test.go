@@ -55,6 +55,7 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 				},
 				AddMetricSuffixes: false,
+				SendMetadata:      true,
 				Namespace:         "test-space",
 				ExternalLabels:    map[string]string{"key1": "value1", "key2": "value2"},
 				HTTPClientSettings: confighttp.HTTPClientSettings{
@@ -87,6 +88,14 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "negative_num_consumers"),
 			errorMessage: "remote write consumer number can't be negative",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_missing_resource_attribute"),
+			errorMessage: "tenant resource_attribute must be set",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "tenant_header_conflict"),
+			errorMessage: `tenant header "X-Scope-OrgID" can't also be set in headers`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -133,3 +142,19 @@ func TestDisabledTargetInfo(t *testing.T) {
 
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
+
+func TestTenant(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "tenant").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	tenant := cfg.(*Config).Tenant
+	assert.Equal(t, &TenantConfig{ResourceAttribute: "tenant.id", Default: "anonymous"}, tenant)
+	assert.Equal(t, "X-Scope-OrgID", tenant.header())
+}
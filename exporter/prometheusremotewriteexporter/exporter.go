@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
 	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
@@ -42,8 +44,15 @@ type prwExporter struct {
 	clientSettings  *confighttp.HTTPClientSettings
 	settings        component.TelemetrySettings
 
-	wal              *prweWAL
 	exporterSettings prometheusremotewrite.Settings
+	sendMetadata     bool
+	tenant           *TenantConfig
+
+	walConfig *WALConfig
+	walMu     sync.Mutex // walMu protects the fields below.
+	wals      map[string]*prweWAL
+	walUsed   map[string]time.Time // walUsed is when metrics of each tenant with a WAL were last persisted.
+	walCtx    context.Context
 }
 
 // newPRWExporter initializes a new prwExporter instance and sets fields accordingly.
@@ -75,15 +84,22 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 			ExportCreatedMetric: cfg.CreatedMetric.Enabled,
 			AddMetricSuffixes:   cfg.AddMetricSuffixes,
 		},
+		sendMetadata: cfg.SendMetadata,
+		tenant:       cfg.Tenant,
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
 	}
 
-	prwe.wal, err = newWAL(cfg.WAL, prwe.export)
+	// The WAL of metrics without tenant always exists, the WALs of tenants are created
+	// when their metrics are first exported or recovered when the exporter starts.
+	defaultWAL, err := newWAL(cfg.WAL, "", prwe.exportSink(""))
 	if err != nil {
 		return nil, err
 	}
+	prwe.walConfig = cfg.WAL
+	prwe.wals = map[string]*prweWAL{"": defaultWAL}
+	prwe.walUsed = map[string]time.Time{}
 	return prwe, nil
 }
 
@@ -100,7 +116,14 @@ func (prwe *prwExporter) shutdownWALIfEnabled() error {
 	if !prwe.walEnabled() {
 		return nil
 	}
-	return prwe.wal.stop()
+	prwe.walMu.Lock()
+	defer prwe.walMu.Unlock()
+
+	var errs error
+	for _, w := range prwe.wals {
+		errs = multierr.Append(errs, w.stop())
+	}
+	return errs
 }
 
 // Shutdown stops the exporter from accepting incoming calls(and return error), and wait for current export operations
@@ -127,15 +150,27 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-		tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
-		if err != nil {
-			err = consumererror.NewPermanent(err)
+		if prwe.tenant == nil {
+			return prwe.pushTenantMetrics(ctx, "", md)
 		}
-		// Call export even if a conversion error, since there may be points that were successfully converted.
-		return multierr.Combine(err, prwe.handleExport(ctx, tsMap))
+		return prwe.pushMetricsPerTenant(ctx, md)
 	}
 }
 
+// pushTenantMetrics converts and exports the metrics of a single tenant.
+func (prwe *prwExporter) pushTenantMetrics(ctx context.Context, tenant string, md pmetric.Metrics) error {
+	tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
+	if err != nil {
+		err = consumererror.NewPermanent(err)
+	}
+	var m []prompb.MetricMetadata
+	if prwe.sendMetadata {
+		m = prometheusremotewrite.MetadataFromMetrics(md, prwe.exporterSettings)
+	}
+	// Call export even if a conversion error, since there may be points that were successfully converted.
+	return multierr.Combine(err, prwe.handleExport(ctx, tenant, tsMap, m))
+}
+
 func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	sanitizedLabels := make(map[string]string)
 	for key, value := range cfg.ExternalLabels {
@@ -148,32 +183,51 @@ func validateAndSanitizeExternalLabels(cfg *Config) (map[string]string, error) {
 	return sanitizedLabels, nil
 }
 
-func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*prompb.TimeSeries) error {
+func (prwe *prwExporter) handleExport(ctx context.Context, tenant string, tsMap map[string]*prompb.TimeSeries, m []prompb.MetricMetadata) error {
 	// There are no metrics to export, so return.
 	if len(tsMap) == 0 {
 		return nil
 	}
 
 	// Calls the helper function to convert and batch the TsMap to the desired format
-	requests, err := batchTimeSeries(tsMap, maxBatchByteSize)
+	requests, err := batchTimeSeries(tsMap, maxBatchByteSize, m)
 	if err != nil {
 		return err
 	}
 	if !prwe.walEnabled() {
 		// Perform a direct export otherwise.
-		return prwe.export(ctx, requests)
+		return prwe.export(ctx, tenant, requests)
 	}
 
-	// Otherwise the WAL is enabled, and just persist the requests to the WAL
+	// Otherwise the WAL is enabled, and just persist the requests to the WAL of the tenant
 	// and they'll be exported in another goroutine to the RemoteWrite endpoint.
-	if err = prwe.wal.persistToWAL(requests); err != nil {
-		return consumererror.NewPermanent(err)
+	for {
+		w, err := prwe.tenantWAL(tenant)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		err = w.persistToWAL(requests)
+		if errors.Is(err, errAlreadyClosed) {
+			// The WAL of the tenant was closed meanwhile, persist the requests to a new one.
+			continue
+		}
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		return nil
+	}
+}
+
+// exportSink returns the function exporting the requests read from the WAL of a tenant.
+func (prwe *prwExporter) exportSink(tenant string) func(context.Context, []*prompb.WriteRequest) error {
+	return func(ctx context.Context, requests []*prompb.WriteRequest) error {
+		return prwe.export(ctx, tenant, requests)
 	}
-	return nil
 }
 
-// export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
-func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
+// export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order.
+// A non-empty tenant is sent in the tenant header of each request.
+func (prwe *prwExporter) export(ctx context.Context, tenant string, requests []*prompb.WriteRequest) error {
 	input := make(chan *prompb.WriteRequest, len(requests))
 	for _, request := range requests {
 		input <- request
@@ -201,7 +255,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 					if !ok {
 						return
 					}
-					if errExecute := prwe.execute(ctx, request); errExecute != nil {
+					if errExecute := prwe.execute(ctx, tenant, request); errExecute != nil {
 						mu.Lock()
 						errs = multierr.Append(errs, consumererror.NewPermanent(errExecute))
 						mu.Unlock()
@@ -215,7 +269,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 	return errs
 }
 
-func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequest) error {
+func (prwe *prwExporter) execute(ctx context.Context, tenant string, writeReq *prompb.WriteRequest) error {
 	// Uses proto.Marshal to convert the WriteRequest into bytes array
 	data, err := proto.Marshal(writeReq)
 	if err != nil {
@@ -236,6 +290,9 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	req.Header.Set("Content-Type", "application/x-protobuf")
 	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
 	req.Header.Set("User-Agent", prwe.userAgentHeader)
+	if tenant != "" {
+		req.Header.Set(prwe.tenant.header(), tenant)
+	}
 
 	resp, err := prwe.client.Do(req)
 	if err != nil {
@@ -258,7 +315,7 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	return consumererror.NewPermanent(rerr)
 }
 
-func (prwe *prwExporter) walEnabled() bool { return prwe.wal != nil }
+func (prwe *prwExporter) walEnabled() bool { return prwe.walConfig != nil }
 
 func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
 	if !prwe.walEnabled() {
@@ -269,5 +326,114 @@ func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
 		<-prwe.closeChan
 		cancel()
 	}()
-	return prwe.wal.run(cancelCtx)
+
+	// Recover the WALs of the tenants seen before a restart, so their pending requests are exported.
+	tenants, err := prwe.walConfig.tenants()
+	if err != nil {
+		return err
+	}
+
+	prwe.walMu.Lock()
+	defer prwe.walMu.Unlock()
+	prwe.walCtx = cancelCtx
+	now := time.Now()
+	for _, tenant := range tenants {
+		if len(prwe.walUsed) >= prwe.walConfig.maxTenantWALs() {
+			// The WALs of the other tenants are recovered once metrics of these tenants are exported again.
+			break
+		}
+		if _, ok := prwe.wals[tenant]; ok {
+			continue
+		}
+		w, werr := newWAL(prwe.walConfig, tenant, prwe.exportSink(tenant))
+		if werr != nil {
+			return werr
+		}
+		prwe.wals[tenant] = w
+		prwe.walUsed[tenant] = now
+	}
+	for _, w := range prwe.wals {
+		if err = w.run(cancelCtx); err != nil {
+			return err
+		}
+	}
+	go prwe.closeIdleWALs(cancelCtx)
+	return nil
+}
+
+// tenantWAL returns the WAL of the tenant, creating and running it if this is the first
+// time metrics of the tenant are exported, or if it was closed since.
+func (prwe *prwExporter) tenantWAL(tenant string) (*prweWAL, error) {
+	prwe.walMu.Lock()
+	defer prwe.walMu.Unlock()
+
+	if w, ok := prwe.wals[tenant]; ok {
+		if tenant != "" {
+			prwe.walUsed[tenant] = time.Now()
+		}
+		return w, nil
+	}
+	if prwe.walCtx == nil {
+		return nil, errors.New("the WAL of the exporter has not been started")
+	}
+	if len(prwe.walUsed) >= prwe.walConfig.maxTenantWALs() {
+		prwe.closeTenantWAL(prwe.leastRecentlyUsedTenant())
+	}
+	w, err := newWAL(prwe.walConfig, tenant, prwe.exportSink(tenant))
+	if err != nil {
+		return nil, err
+	}
+	if err = w.run(prwe.walCtx); err != nil {
+		return nil, err
+	}
+	prwe.wals[tenant] = w
+	prwe.walUsed[tenant] = time.Now()
+	return w, nil
+}
+
+// closeIdleWALs periodically closes the WALs of the tenants without metrics for the idle timeout, until ctx is done.
+func (prwe *prwExporter) closeIdleWALs(ctx context.Context) {
+	idleTimeout := prwe.walConfig.tenantWALIdleTimeout()
+	ticker := time.NewTicker(idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			prwe.walMu.Lock()
+			for tenant, used := range prwe.walUsed {
+				if now.Sub(used) >= idleTimeout {
+					prwe.closeTenantWAL(tenant)
+				}
+			}
+			prwe.walMu.Unlock()
+		}
+	}
+}
+
+// leastRecentlyUsedTenant returns the tenant whose WAL was used the least recently. walMu must be held.
+func (prwe *prwExporter) leastRecentlyUsedTenant() string {
+	var lru string
+	var lruUsed time.Time
+	for tenant, used := range prwe.walUsed {
+		if lru == "" || used.Before(lruUsed) {
+			lru, lruUsed = tenant, used
+		}
+	}
+	return lru
+}
+
+// closeTenantWAL closes the WAL of a tenant. The requests it still holds are exported once it is opened again,
+// when metrics of the tenant are exported or when the exporter restarts. walMu must be held.
+func (prwe *prwExporter) closeTenantWAL(tenant string) {
+	w, ok := prwe.wals[tenant]
+	if !ok || tenant == "" {
+		return
+	}
+	if err := w.stop(); err != nil && !errors.Is(err, errAlreadyClosed) {
+		prwe.settings.Logger.Warn("failed to close the WAL of a tenant", zap.String("tenant", tenant), zap.Error(err))
+	}
+	delete(prwe.wals, tenant)
+	delete(prwe.walUsed, tenant)
 }
@@ -5,6 +5,7 @@ package prometheusremotewriteexporter
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -359,7 +360,7 @@ func runExportPipeline(ts *prompb.TimeSeries, endpoint *url.URL) error {
 		return err
 	}
 
-	return prwe.handleExport(context.Background(), testmap)
+	return prwe.handleExport(context.Background(), "", testmap, nil)
 }
 
 // Test_PushMetrics checks the number of TimeSeries received by server and the number of metrics dropped is the same as
@@ -717,6 +718,78 @@ func Test_PushMetrics(t *testing.T) {
 	}
 }
 
+// TestPushMetricsSendsMetadata checks the metric metadata and exemplars of the pushed metrics reach the remote write endpoint.
+func TestPushMetricsSendsMetadata(t *testing.T) {
+	for _, sendMetadata := range []bool{true, false} {
+		t.Run(fmt.Sprintf("send_metadata=%v", sendMetadata), func(t *testing.T) {
+			var mu sync.Mutex
+			var received []prompb.WriteRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				data, err := snappy.Decode(nil, body)
+				require.NoError(t, err)
+				writeReq := prompb.WriteRequest{}
+				require.NoError(t, proto.Unmarshal(data, &writeReq))
+				mu.Lock()
+				received = append(received, writeReq)
+				mu.Unlock()
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.HTTPClientSettings.Endpoint = server.URL
+			cfg.TargetInfo.Enabled = false
+			cfg.SendMetadata = sendMetadata
+
+			md := pmetric.NewMetrics()
+			metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			metric.SetName("requests")
+			metric.SetDescription("Number of requests.")
+			metric.SetEmptySum().SetIsMonotonic(true)
+			metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			dp := metric.Sum().DataPoints().AppendEmpty()
+			dp.SetIntValue(10)
+			exemplar := dp.Exemplars().AppendEmpty()
+			exemplar.SetIntValue(1)
+			exemplar.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+			exemplar.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+			prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+			require.NoError(t, err)
+			ctx := context.Background()
+			require.NoError(t, prwe.Start(ctx, componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, prwe.Shutdown(ctx))
+			}()
+			require.NoError(t, prwe.PushMetrics(ctx, md))
+
+			var series []prompb.TimeSeries
+			var metadata []prompb.MetricMetadata
+			for _, req := range received {
+				series = append(series, req.Timeseries...)
+				metadata = append(metadata, req.Metadata...)
+			}
+			require.Len(t, series, 1)
+			require.Len(t, series[0].Exemplars, 1)
+			assert.Equal(t, 1.0, series[0].Exemplars[0].Value)
+			assert.Equal(t, []prompb.Label{
+				{Name: "trace_id", Value: "0102030405060708090a0b0c0d0e0f10"},
+				{Name: "span_id", Value: "0102030405060708"},
+			}, series[0].Exemplars[0].Labels)
+
+			if !sendMetadata {
+				assert.Empty(t, metadata)
+				return
+			}
+			assert.Equal(t, []prompb.MetricMetadata{
+				{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests", Help: "Number of requests."},
+			}, metadata)
+		})
+	}
+}
+
 func Test_validateAndSanitizeExternalLabels(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -887,7 +960,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 		assert.Error(t, prwe.Shutdown(ctx))
 		close(exiting)
 	})
-	require.NotNil(t, prwe.wal)
+	require.NotNil(t, prwe.wals[""])
 
 	ts1 := &prompb.TimeSeries{
 		Labels:  []prompb.Label{{Name: "ts1l1", Value: "ts1k1"}},
@@ -901,7 +974,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 		"timeseries1": ts1,
 		"timeseries2": ts2,
 	}
-	errs := prwe.handleExport(ctx, tsMap)
+	errs := prwe.handleExport(ctx, "", tsMap, nil)
 	assert.NoError(t, errs)
 	// Shutdown after we've written to the WAL. This ensures that our
 	// exported data in-flight will flushed flushed to the WAL before exiting.
@@ -909,7 +982,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 
 	// 3. Let's now read back all of the WAL records and ensure
 	// that all the prompb.WriteRequest values exist as we sent them.
-	wal, _, werr := cfg.WAL.createWAL()
+	wal, _, werr := cfg.WAL.createWAL("")
 	assert.NoError(t, werr)
 	assert.NotNil(t, wal)
 	t.Cleanup(func() {
@@ -959,7 +1032,7 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 	t.Cleanup(func() {
 		assert.NoError(t, prwe2.Shutdown(ctx))
 	})
-	require.NotNil(t, prwe2.wal)
+	require.NotNil(t, prwe2.wals[""])
 
 	snappyEncodedBytes := <-uploadedBytesCh
 	decodeBuffer := make([]byte, len(snappyEncodedBytes))
@@ -10,8 +10,9 @@ import (
 	"github.com/prometheus/prometheus/prompb"
 )
 
-// batchTimeSeries splits series into multiple batch write requests.
-func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int) ([]*prompb.WriteRequest, error) {
+// batchTimeSeries splits series into multiple batch write requests. Metric metadata is sent in
+// additional requests following the series, so the series requests keep their size.
+func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int, m []prompb.MetricMetadata) ([]*prompb.WriteRequest, error) {
 	if len(tsMap) == 0 {
 		return nil, errors.New("invalid tsMap: cannot be empty map")
 	}
@@ -42,12 +43,31 @@ func batchTimeSeries(tsMap map[string]*prompb.TimeSeries, maxBatchByteSize int)
 		requests = append(requests, wrapped)
 	}
 
+	mArray := make([]prompb.MetricMetadata, 0, len(m))
+	sizeOfCurrentBatch = 0
+	for i := range m {
+		sizeOfM := m[i].Size()
+
+		if sizeOfCurrentBatch+sizeOfM >= maxBatchByteSize && len(mArray) != 0 {
+			requests = append(requests, &prompb.WriteRequest{Metadata: mArray})
+
+			mArray = make([]prompb.MetricMetadata, 0, len(m)-i)
+			sizeOfCurrentBatch = 0
+		}
+
+		mArray = append(mArray, m[i])
+		sizeOfCurrentBatch += sizeOfM
+	}
+
+	if len(mArray) != 0 {
+		requests = append(requests, &prompb.WriteRequest{Metadata: mArray})
+	}
+
 	return requests, nil
 }
 
 func convertTimeseriesToRequest(tsArray []prompb.TimeSeries) *prompb.WriteRequest {
-	// the remote_write endpoint only requires the timeseries.
-	// otlp defines it's own way to handle metric metadata
+	// metric metadata is batched separately, see batchTimeSeries.
 	return &prompb.WriteRequest{
 		// Prometheus requires time series to be sorted by Timestamp to avoid out of order problems.
 		// See:
@@ -57,7 +57,7 @@ func Test_batchTimeSeries(t *testing.T) {
 	// run tests
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			requests, err := batchTimeSeries(tt.tsMap, tt.maxBatchByteSize)
+			requests, err := batchTimeSeries(tt.tsMap, tt.maxBatchByteSize, nil)
 			if tt.returnErr {
 				assert.Error(t, err)
 				return
@@ -68,6 +68,36 @@ func Test_batchTimeSeries(t *testing.T) {
 	}
 }
 
+// Test_batchTimeSeriesWithMetadata checks metric metadata is sent in requests following the series,
+// split by byte size.
+func Test_batchTimeSeriesWithMetadata(t *testing.T) {
+	labels := getPromLabels(label11, value11)
+	ts := getTimeSeries(labels, getSample(floatVal1, msTime1))
+	tsMap := getTimeseriesMap([]*prompb.TimeSeries{ts})
+
+	m := []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests_total", Help: "Number of requests."},
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "memory_usage_bytes", Help: "Memory in use.", Unit: "bytes"},
+	}
+
+	requests, err := batchTimeSeries(tsMap, 1000, m)
+	assert.NoError(t, err)
+	if assert.Len(t, requests, 2) {
+		assert.Len(t, requests[0].Timeseries, 1)
+		assert.Empty(t, requests[0].Metadata)
+		assert.Empty(t, requests[1].Timeseries)
+		assert.Equal(t, m, requests[1].Metadata)
+	}
+
+	// each metadata request is capped to the batch size, so the two entries are sent separately
+	requests, err = batchTimeSeries(tsMap, m[0].Size()+1, m)
+	assert.NoError(t, err)
+	if assert.GreaterOrEqual(t, len(requests), 3) {
+		assert.Equal(t, m[:1], requests[len(requests)-2].Metadata)
+		assert.Equal(t, m[1:], requests[len(requests)-1].Metadata)
+	}
+}
+
 // Ensure that before a prompb.WriteRequest is created, that the points per TimeSeries
 // are sorted by Timestamp value, to prevent Prometheus from barfing when it gets poorly
 // sorted values. See issues:
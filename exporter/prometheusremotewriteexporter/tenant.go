@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// pushMetricsPerTenant splits the metrics by tenant and exports the tenants concurrently, so a
+// tenant failing to export doesn't hold back the others. Only the metrics of the tenants failing
+// with a retryable error are returned for retry, the tenants failing with a permanent error are
+// dropped so that they don't prevent the others from being retried.
+func (prwe *prwExporter) pushMetricsPerTenant(ctx context.Context, md pmetric.Metrics) error {
+	tenants := splitMetricsByTenant(md, prwe.tenant)
+	failed, retryableErr, permanentErr := pushTenants(ctx, tenants, prwe.concurrency, prwe.pushTenantMetrics)
+	if retryableErr == nil {
+		return permanentErr
+	}
+	if permanentErr != nil {
+		prwe.settings.Logger.Error("Dropping the metrics of tenants failing with a permanent error", zap.Error(permanentErr))
+	}
+	return consumererror.NewMetrics(retryableErr, failed)
+}
+
+// pushTenants pushes the metrics of the tenants, at most limit tenants at a time. It returns the
+// metrics of the tenants failing with a retryable error, and the errors of the tenants by kind.
+func pushTenants(
+	ctx context.Context,
+	tenants map[string]pmetric.Metrics,
+	limit int,
+	push func(context.Context, string, pmetric.Metrics) error,
+) (failed pmetric.Metrics, retryableErr error, permanentErr error) {
+	failed = pmetric.NewMetrics()
+	sem := make(chan struct{}, int(math.Max(1, float64(limit))))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for tenant, tenantMd := range tenants {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(tenant string, tenantMd pmetric.Metrics) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			err := push(ctx, tenant, tenantMd)
+			if err == nil {
+				return
+			}
+			err = fmt.Errorf("tenant %q: %w", tenant, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if consumererror.IsPermanent(err) {
+				permanentErr = multierr.Append(permanentErr, err)
+				return
+			}
+			retryableErr = multierr.Append(retryableErr, err)
+			tenantMd.ResourceMetrics().MoveAndAppendTo(failed.ResourceMetrics())
+		}(tenant, tenantMd)
+	}
+	wg.Wait()
+	return failed, retryableErr, permanentErr
+}
+
+// splitMetricsByTenant groups the resource metrics by the value of the tenant resource attribute.
+func splitMetricsByTenant(md pmetric.Metrics, cfg *TenantConfig) map[string]pmetric.Metrics {
+	tenants := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		tenant := cfg.Default
+		if v, ok := rm.Resource().Attributes().Get(cfg.ResourceAttribute); ok && v.AsString() != "" {
+			tenant = v.AsString()
+		}
+
+		tenantMd, ok := tenants[tenant]
+		if !ok {
+			tenantMd = pmetric.NewMetrics()
+			tenants[tenant] = tenantMd
+		}
+		rm.CopyTo(tenantMd.ResourceMetrics().AppendEmpty())
+	}
+	return tenants
+}
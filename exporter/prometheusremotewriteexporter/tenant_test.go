@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func tenantMetrics(tenants ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, tenant := range tenants {
+		rm := md.ResourceMetrics().AppendEmpty()
+		if tenant != "" {
+			rm.Resource().Attributes().PutStr("tenant.id", tenant)
+		}
+		metric := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("requests")
+		dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetIntValue(1)
+		dp.Attributes().PutStr("tenant", tenant)
+	}
+	return md
+}
+
+func TestSplitMetricsByTenant(t *testing.T) {
+	md := tenantMetrics("team-a", "team-b", "", "team-a")
+
+	tenants := splitMetricsByTenant(md, &TenantConfig{ResourceAttribute: "tenant.id", Default: "anonymous"})
+	require.Len(t, tenants, 3)
+	assert.Equal(t, 2, tenants["team-a"].ResourceMetrics().Len())
+	assert.Equal(t, 1, tenants["team-b"].ResourceMetrics().Len())
+	assert.Equal(t, 1, tenants["anonymous"].ResourceMetrics().Len())
+
+	tenants = splitMetricsByTenant(md, &TenantConfig{ResourceAttribute: "tenant.id"})
+	require.Len(t, tenants, 3)
+	assert.Equal(t, 1, tenants[""].ResourceMetrics().Len())
+}
+
+// tenantServer records the series received per value of the tenant header.
+type tenantServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	series map[string][]prompb.TimeSeries
+	status map[string]int
+}
+
+func newTenantServer(t *testing.T, header string) *tenantServer {
+	s := &tenantServer{series: map[string][]prompb.TimeSeries{}, status: map[string]int{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		data, err := snappy.Decode(nil, body)
+		require.NoError(t, err)
+		writeReq := prompb.WriteRequest{}
+		require.NoError(t, proto.Unmarshal(data, &writeReq))
+
+		tenant := r.Header.Get(header)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if status, ok := s.status[tenant]; ok {
+			w.WriteHeader(status)
+			return
+		}
+		s.series[tenant] = append(s.series[tenant], writeReq.Timeseries...)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *tenantServer) received() map[string][]prompb.TimeSeries {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	received := make(map[string][]prompb.TimeSeries, len(s.series))
+	for tenant, series := range s.series {
+		received[tenant] = series
+	}
+	return received
+}
+
+func tenantLabel(ts prompb.TimeSeries) string {
+	for _, l := range ts.Labels {
+		if l.Name == "tenant" {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func TestPushMetricsPerTenant(t *testing.T) {
+	server := newTenantServer(t, "X-Tenant")
+	server.status["team-c"] = http.StatusBadRequest
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.TargetInfo.Enabled = false
+	cfg.SendMetadata = false
+	cfg.Tenant = &TenantConfig{ResourceAttribute: "tenant.id", Header: "X-Tenant"}
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, prwe.Start(ctx, componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, prwe.Shutdown(ctx))
+	}()
+
+	err = prwe.PushMetrics(ctx, tenantMetrics("team-a", "team-b", "", "team-c"))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), `tenant "team-c"`)
+
+	received := server.received()
+	require.Len(t, received, 3)
+	for _, tenant := range []string{"team-a", "team-b", ""} {
+		require.Len(t, received[tenant], 1, tenant)
+		assert.Equal(t, tenant, tenantLabel(received[tenant][0]))
+	}
+}
+
+func TestPushTenants(t *testing.T) {
+	tenants := splitMetricsByTenant(tenantMetrics("team-a", "team-b", "team-c", "team-d"), &TenantConfig{ResourceAttribute: "tenant.id"})
+
+	var mu sync.Mutex
+	var running, maxRunning int
+	pushed := map[string]bool{}
+	push := func(_ context.Context, tenant string, _ pmetric.Metrics) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		pushed[tenant] = true
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		switch tenant {
+		case "team-b":
+			return errors.New("unavailable")
+		case "team-c":
+			return consumererror.NewPermanent(errors.New("bad request"))
+		}
+		return nil
+	}
+
+	failed, retryableErr, permanentErr := pushTenants(context.Background(), tenants, 2, push)
+	assert.Len(t, pushed, 4)
+	assert.LessOrEqual(t, maxRunning, 2)
+
+	require.Error(t, retryableErr)
+	assert.False(t, consumererror.IsPermanent(retryableErr))
+	assert.Contains(t, retryableErr.Error(), `tenant "team-b"`)
+	require.Error(t, permanentErr)
+	assert.Contains(t, permanentErr.Error(), `tenant "team-c"`)
+
+	// Only the metrics of the tenant failing with a retryable error are retried
+	require.Equal(t, 1, failed.ResourceMetrics().Len())
+	tenant, _ := failed.ResourceMetrics().At(0).Resource().Attributes().Get("tenant.id")
+	assert.Equal(t, "team-b", tenant.Str())
+}
+
+func TestTenantWAL(t *testing.T) {
+	// The endpoint is unavailable, so the requests are kept in the WALs.
+	server := newTenantServer(t, defaultTenantHeader)
+	server.status["team-a"] = http.StatusServiceUnavailable
+	server.status["team-b"] = http.StatusServiceUnavailable
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.TargetInfo.Enabled = false
+	cfg.SendMetadata = false
+	cfg.Tenant = &TenantConfig{ResourceAttribute: "tenant.id"}
+	cfg.WAL = &WALConfig{Directory: t.TempDir()}
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, prwe.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, prwe.PushMetrics(ctx, tenantMetrics("team-a", "team-b")))
+	require.NoError(t, prwe.Shutdown(ctx))
+
+	for _, tenant := range []string{"team-a", "team-b"} {
+		assert.DirExists(t, filepath.Join(cfg.WAL.Directory, tenantWALDirectory, hex.EncodeToString([]byte(tenant))))
+
+		log, _, werr := cfg.WAL.createWAL(tenant)
+		require.NoError(t, werr)
+		protoBlob, werr := log.Read(1)
+		require.NoError(t, werr)
+		req := new(prompb.WriteRequest)
+		require.NoError(t, proto.Unmarshal(protoBlob, req))
+		require.Len(t, req.Timeseries, 1)
+		assert.Equal(t, tenant, tenantLabel(req.Timeseries[0]))
+		require.NoError(t, log.Close())
+	}
+
+	// A restarted exporter recovers the WALs of the tenants to export their pending requests.
+	tenants, err := cfg.WAL.tenants()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"team-a", "team-b"}, tenants)
+
+	prwe, err = newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(ctx, componenttest.NewNopHost()))
+	assert.Len(t, prwe.wals, 3)
+	assert.Contains(t, prwe.wals, "team-a")
+	assert.Contains(t, prwe.wals, "team-b")
+	require.NoError(t, prwe.Shutdown(ctx))
+}
+
+func TestTenantWALPath(t *testing.T) {
+	cfg := &WALConfig{Directory: t.TempDir()}
+	paths := map[string]bool{cfg.walPath(""): true}
+	for _, tenant := range []string{".", "..", "../prom_remotewrite", "a/b", "a%2Fb"} {
+		path := cfg.walPath(tenant)
+		assert.False(t, paths[path], "tenant %q shares the WAL path %s", tenant, path)
+		paths[path] = true
+		assert.Equal(t, filepath.Join(cfg.Directory, tenantWALDirectory), filepath.Dir(path))
+	}
+}
+
+func TestTenantWALLimits(t *testing.T) {
+	server := newTenantServer(t, defaultTenantHeader)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = server.URL
+	cfg.TargetInfo.Enabled = false
+	cfg.SendMetadata = false
+	cfg.Tenant = &TenantConfig{ResourceAttribute: "tenant.id"}
+	cfg.WAL = &WALConfig{Directory: t.TempDir(), MaxTenantWALs: 2, TenantWALIdleTimeout: 100 * time.Millisecond}
+
+	prwe, err := newPRWExporter(cfg, exportertest.NewNopCreateSettings())
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, prwe.Start(ctx, componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, prwe.Shutdown(ctx)) })
+
+	openTenants := func() []string {
+		prwe.walMu.Lock()
+		defer prwe.walMu.Unlock()
+		var tenants []string
+		for tenant := range prwe.wals {
+			tenants = append(tenants, tenant)
+		}
+		return tenants
+	}
+
+	// the WAL of the least recently used tenant is closed to open the WAL of a new tenant
+	require.NoError(t, prwe.PushMetrics(ctx, tenantMetrics("team-a")))
+	require.NoError(t, prwe.PushMetrics(ctx, tenantMetrics("team-b")))
+	require.NoError(t, prwe.PushMetrics(ctx, tenantMetrics("team-a")))
+	require.NoError(t, prwe.PushMetrics(ctx, tenantMetrics("team-c")))
+	assert.ElementsMatch(t, []string{"", "team-a", "team-c"}, openTenants())
+
+	// the WALs of idle tenants are closed, the WAL of metrics without tenant is kept
+	assert.Eventually(t, func() bool {
+		return len(openTenants()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{""}, openTenants())
+
+	// closed WALs are opened again when metrics of their tenant are exported
+	require.NoError(t, prwe.PushMetrics(ctx, tenantMetrics("team-b")))
+	assert.Contains(t, openTenants(), "team-b")
+}
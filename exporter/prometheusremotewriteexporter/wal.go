@@ -5,8 +5,10 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"sync"
 	"sync/atomic"
@@ -25,6 +27,7 @@ type prweWAL struct {
 	wal       *wal.Log
 	walConfig *WALConfig
 	walPath   string
+	tenant    string
 
 	exportSink func(ctx context.Context, reqL []*prompb.WriteRequest) error
 
@@ -37,12 +40,24 @@ type prweWAL struct {
 const (
 	defaultWALBufferSize        = 300
 	defaultWALTruncateFrequency = 1 * time.Minute
+	defaultMaxTenantWALs        = 100
+	defaultTenantWALIdleTimeout = 1 * time.Hour
+
+	// tenantWALDirectory holds a WAL per tenant, named after the hex encoded tenant, so that
+	// any tenant is a single directory that can't collide with another one.
+	tenantWALDirectory = "prom_remotewrite_tenants"
 )
 
 type WALConfig struct {
 	Directory         string        `mapstructure:"directory"`
 	BufferSize        int           `mapstructure:"buffer_size"`
 	TruncateFrequency time.Duration `mapstructure:"truncate_frequency"`
+	// MaxTenantWALs is the maximum number of WALs of tenants kept open. The WAL of the least
+	// recently used tenant is closed to open the WAL of another tenant. Default is 100.
+	MaxTenantWALs int `mapstructure:"max_tenant_wals"`
+	// TenantWALIdleTimeout is the duration after which the WAL of a tenant without metrics is closed.
+	// Default is 1h.
+	TenantWALIdleTimeout time.Duration `mapstructure:"tenant_wal_idle_timeout"`
 }
 
 func (wc *WALConfig) bufferSize() int {
@@ -59,7 +74,21 @@ func (wc *WALConfig) truncateFrequency() time.Duration {
 	return defaultWALTruncateFrequency
 }
 
-func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) (*prweWAL, error) {
+func (wc *WALConfig) maxTenantWALs() int {
+	if wc.MaxTenantWALs > 0 {
+		return wc.MaxTenantWALs
+	}
+	return defaultMaxTenantWALs
+}
+
+func (wc *WALConfig) tenantWALIdleTimeout() time.Duration {
+	if wc.TenantWALIdleTimeout > 0 {
+		return wc.TenantWALIdleTimeout
+	}
+	return defaultTenantWALIdleTimeout
+}
+
+func newWAL(walConfig *WALConfig, tenant string, exportSink func(context.Context, []*prompb.WriteRequest) error) (*prweWAL, error) {
 	if walConfig == nil {
 		// There are cases for which the WAL can be disabled.
 		// TODO: Perhaps log that the WAL wasn't enabled.
@@ -69,14 +98,47 @@ func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.Wri
 	return &prweWAL{
 		exportSink: exportSink,
 		walConfig:  walConfig,
+		tenant:     tenant,
 		stopChan:   make(chan struct{}),
 		rWALIndex:  &atomic.Uint64{},
 		wWALIndex:  &atomic.Uint64{},
 	}, nil
 }
 
-func (wc *WALConfig) createWAL() (*wal.Log, string, error) {
-	walPath := filepath.Join(wc.Directory, "prom_remotewrite")
+// walPath returns the location of the WAL of a tenant. The WAL of metrics without tenant keeps
+// the location used before tenants were supported.
+func (wc *WALConfig) walPath(tenant string) string {
+	if tenant == "" {
+		return filepath.Join(wc.Directory, "prom_remotewrite")
+	}
+	return filepath.Join(wc.Directory, tenantWALDirectory, hex.EncodeToString([]byte(tenant)))
+}
+
+// tenants lists the tenants having a WAL in the directory.
+func (wc *WALConfig) tenants() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(wc.Directory, tenantWALDirectory))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("prometheusremotewriteexporter: failed to list tenant WALs: %w", err)
+	}
+	var tenants []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		tenant, derr := hex.DecodeString(entry.Name())
+		if derr != nil || len(tenant) == 0 {
+			continue
+		}
+		tenants = append(tenants, string(tenant))
+	}
+	return tenants, nil
+}
+
+func (wc *WALConfig) createWAL(tenant string) (*wal.Log, string, error) {
+	walPath := wc.walPath(tenant)
 	log, err := wal.Open(walPath, &wal.Options{
 		SegmentCacheSize: wc.bufferSize(),
 		NoCopy:           true,
@@ -98,12 +160,19 @@ func (prwe *prweWAL) retrieveWALIndices() (err error) {
 	prwe.mu.Lock()
 	defer prwe.mu.Unlock()
 
+	// Don't reopen a WAL that was stopped meanwhile.
+	select {
+	case <-prwe.stopChan:
+		return errAlreadyClosed
+	default:
+	}
+
 	err = prwe.closeWAL()
 	if err != nil {
 		return err
 	}
 
-	log, walPath, err := prwe.walConfig.createWAL()
+	log, walPath, err := prwe.walConfig.createWAL(prwe.tenant)
 	if err != nil {
 		return err
 	}
@@ -128,10 +197,12 @@ func (prwe *prweWAL) retrieveWALIndices() (err error) {
 func (prwe *prweWAL) stop() error {
 	err := errAlreadyClosed
 	prwe.stopOnce.Do(func() {
+		// Signal the reader first, so that it releases the lock when it is waiting for writes.
+		close(prwe.stopChan)
+
 		prwe.mu.Lock()
 		defer prwe.mu.Unlock()
 
-		close(prwe.stopChan)
 		err = prwe.closeWAL()
 	})
 	return err
@@ -167,10 +238,19 @@ func (prwe *prweWAL) run(ctx context.Context) (err error) {
 				err := prwe.continuallyPopWALThenExport(runCtx, signalStart)
 				signalStart = func() {}
 				if err != nil {
+					select {
+					case <-prwe.stopChan:
+						// The reader was interrupted by stop.
+						return
+					default:
+					}
 					// log err
 					logger.Error("error processing WAL entries", zap.Error(err))
 					// Restart WAL
 					if errS := prwe.retrieveWALIndices(); errS != nil {
+						if errors.Is(errS, errAlreadyClosed) {
+							return
+						}
 						logger.Error("unable to re-start write-ahead log after error", zap.Error(errS))
 						return
 					}
@@ -305,6 +385,13 @@ func (prwe *prweWAL) persistToWAL(requests []*prompb.WriteRequest) error {
 	prwe.mu.Lock()
 	defer prwe.mu.Unlock()
 
+	// The WAL of a tenant may be closed while its requests are being persisted.
+	select {
+	case <-prwe.stopChan:
+		return errAlreadyClosed
+	default:
+	}
+
 	// Write all the requests to the WAL in a batch.
 	batch := new(wal.Batch)
 	for _, req := range requests {
@@ -361,7 +448,10 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 
 		if index <= 1 {
 			// This could be the very first attempted read, so try again, after a small sleep.
+			// The lock is released meanwhile so that writes and stop aren't blocked.
+			prwe.mu.Unlock()
 			time.Sleep(time.Duration(1<<i) * time.Millisecond)
+			prwe.mu.Lock()
 			continue
 		}
 
@@ -391,6 +481,10 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 				wErr = ctx.Err()
 				return
 
+			case <-prwe.stopChan:
+				wErr = errAlreadyClosed
+				return
+
 			case event, ok := <-walWatcher.Events:
 				if !ok {
 					return
@@ -413,13 +507,19 @@ func (prwe *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq
 			}
 		}()
 
-		if gerr := <-watchCh; gerr != nil {
+		// Don't hold the lock while waiting, otherwise there would be no write to wait for.
+		prwe.mu.Unlock()
+		gerr := <-watchCh
+		prwe.mu.Lock()
+		if gerr != nil {
 			return nil, gerr
 		}
 
 		// Otherwise a write occurred might have occurred,
 		// and we can sleep for a little bit then try again.
+		prwe.mu.Unlock()
 		time.Sleep(time.Duration(1<<i) * time.Millisecond)
+		prwe.mu.Lock()
 	}
 	return nil, err
 }
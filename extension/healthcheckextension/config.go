@@ -37,6 +37,19 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// Readiness contains the settings of the endpoint reporting whether all receivers have started.
+	Readiness endpointSettings `mapstructure:"readiness"`
+
+	// ComponentStatus contains the settings of the endpoint reporting the status of each pipeline and its components as JSON.
+	ComponentStatus endpointSettings `mapstructure:"component_status"`
+}
+
+type endpointSettings struct {
+	// Enabled indicates whether to serve the endpoint.
+	Enabled bool `mapstructure:"enabled"`
+	// Path represents the path the endpoint is served on.
+	Path string `mapstructure:"path"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -44,6 +57,9 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errInvalidPipelineFailureThresholdProvided = errors.New("bad config: pipeline_failure_thresholds expects positive numbers")
+	errInvalidPipelineProvided                 = errors.New("bad config: pipeline_failure_thresholds keys must be traces, metrics or logs pipelines")
+	errDuplicatePath                           = errors.New("bad config: readiness and component_status paths must differ from path and from each other")
 )
 
 // Validate checks if the extension configuration is valid
@@ -61,6 +77,29 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	for pipelineID, threshold := range cfg.CheckCollectorPipeline.PipelineFailureThresholds {
+		switch pipelineID.Type() {
+		case component.DataTypeTraces, component.DataTypeMetrics, component.DataTypeLogs:
+		default:
+			return errInvalidPipelineProvided
+		}
+		if threshold <= 0 {
+			return errInvalidPipelineFailureThresholdProvided
+		}
+	}
+	paths := map[string]bool{cfg.Path: true}
+	for _, endpoint := range []endpointSettings{cfg.Readiness, cfg.ComponentStatus} {
+		if !endpoint.Enabled {
+			continue
+		}
+		if !strings.HasPrefix(endpoint.Path, "/") {
+			return errInvalidPath
+		}
+		if paths[endpoint.Path] {
+			return errDuplicatePath
+		}
+		paths[endpoint.Path] = true
+	}
 	return nil
 }
 
@@ -71,4 +110,7 @@ type checkCollectorPipelineSettings struct {
 	Interval string `mapstructure:"interval"`
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
+	// PipelineFailureThresholds are the thresholds of the numbers of failures of the exporters of each pipeline
+	// during the Interval, checked in addition to ExporterFailureThreshold
+	PipelineFailureThresholds map[component.ID]int `mapstructure:"pipeline_failure_thresholds"`
 }
@@ -45,6 +45,28 @@ func TestLoadConfig(t *testing.T) {
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 				Path:                   "/",
 				ResponseBody:           nil,
+				Readiness:              endpointSettings{Path: "/ready"},
+				ComponentStatus:        endpointSettings{Path: "/status"},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "componentstatus"),
+			expected: &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: checkCollectorPipelineSettings{
+					Enabled:                  true,
+					Interval:                 "5m",
+					ExporterFailureThreshold: 5,
+					PipelineFailureThresholds: map[component.ID]int{
+						component.NewID(component.DataTypeTraces):              2,
+						component.NewIDWithName(component.DataTypeLogs, "app"): 10,
+					},
+				},
+				Path:            "/",
+				Readiness:       endpointSettings{Enabled: true, Path: "/readyz"},
+				ComponentStatus: endpointSettings{Enabled: true, Path: "/statusz"},
 			},
 		},
 		{
@@ -59,6 +81,18 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidpipeline"),
+			expectedErr: errInvalidPipelineProvided,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidpipelinethreshold"),
+			expectedErr: errInvalidPipelineFailureThresholdProvided,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicatepath"),
+			expectedErr: errDuplicatePath,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -8,16 +8,40 @@ import (
 	"time"
 
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
 )
 
 const (
 	exporterFailureView = "exporter/send_failed_requests"
+	// exporterTagKey is the tag identifying the exporter in the exporter views
+	exporterTagKey = "exporter"
 )
 
+// dataTypeFailureViews are the views of the cumulative numbers of items the exporters failed to send, by data type
+var dataTypeFailureViews = map[string]component.DataType{
+	"exporter/send_failed_spans":         component.DataTypeTraces,
+	"exporter/send_failed_metric_points": component.DataTypeMetrics,
+	"exporter/send_failed_log_records":   component.DataTypeLogs,
+}
+
+type failureKey struct {
+	dataType component.DataType
+	exporter string
+}
+
+// failureSample is the cumulative number of items an exporter failed to send at a point in time
+type failureSample struct {
+	end   time.Time
+	value float64
+}
+
 // healthCheckExporter is a struct implement the exporter interface in open census that could export metrics
 type healthCheckExporter struct {
 	mu                   sync.Mutex
 	exporterFailureQueue []*view.Data
+	// failureSamples holds the samples of the failure views during the interval, preceded by the last
+	// sample before the interval, to compute the number of failures during the interval
+	failureSamples map[failureKey][]failureSample
 }
 
 func newHealthCheckExporter() *healthCheckExporter {
@@ -32,6 +56,38 @@ func (e *healthCheckExporter) ExportView(vd *view.Data) {
 	if vd.View.Name == exporterFailureView {
 		e.exporterFailureQueue = append(e.exporterFailureQueue, vd)
 	}
+	if dataType, ok := dataTypeFailureViews[vd.View.Name]; ok {
+		e.addFailureSamples(dataType, vd)
+	}
+}
+
+func (e *healthCheckExporter) addFailureSamples(dataType component.DataType, vd *view.Data) {
+	if e.failureSamples == nil {
+		e.failureSamples = map[failureKey][]failureSample{}
+	}
+	for _, row := range vd.Rows {
+		key := failureKey{dataType: dataType}
+		for _, t := range row.Tags {
+			if t.Key.Name() == exporterTagKey {
+				key.exporter = t.Value
+			}
+		}
+		var value float64
+		switch data := row.Data.(type) {
+		case *view.SumData:
+			value = data.Value
+		case *view.CountData:
+			value = float64(data.Value)
+		default:
+			continue
+		}
+		samples, ok := e.failureSamples[key]
+		if !ok {
+			// The row of an exporter is reported once it failed, it had no failure at the start of the view
+			samples = []failureSample{{end: vd.Start, value: 0}}
+		}
+		e.failureSamples[key] = append(samples, failureSample{end: vd.End, value: value})
+	}
 }
 
 func (e *healthCheckExporter) checkHealthStatus(exporterFailureThreshold int) bool {
@@ -41,6 +97,29 @@ func (e *healthCheckExporter) checkHealthStatus(exporterFailureThreshold int) bo
 	return exporterFailureThreshold >= len(e.exporterFailureQueue)
 }
 
+// dataTypeFailures returns, for each data type and exporter ID, the number of failures of the exporter during the
+// interval. Like for exporterFailureThreshold, a failure is a report of the failure views in which the exporter
+// failed to send data, regardless of the number of items it failed to send.
+func (e *healthCheckExporter) dataTypeFailures() map[component.DataType]map[string]int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	failures := map[component.DataType]map[string]int{}
+	for key, samples := range e.failureSamples {
+		if failures[key.dataType] == nil {
+			failures[key.dataType] = map[string]int{}
+		}
+		count := 0
+		for i := 1; i < len(samples); i++ {
+			if samples[i].value > samples[i-1].value {
+				count++
+			}
+		}
+		failures[key.dataType][key.exporter] = count
+	}
+	return failures
+}
+
 // rotate function could rotate the error logs that expired the time interval
 func (e *healthCheckExporter) rotate(interval time.Duration) {
 	e.mu.Lock()
@@ -48,6 +127,14 @@ func (e *healthCheckExporter) rotate(interval time.Duration) {
 
 	viewNum := len(e.exporterFailureQueue)
 	currentTime := time.Now()
+	cutoff := currentTime.Add(-interval)
+	for key, samples := range e.failureSamples {
+		// Keep the last sample before the interval as the reference of the failures during the interval
+		for len(samples) > 1 && !samples[1].end.After(cutoff) {
+			samples = samples[1:]
+		}
+		e.failureSamples[key] = samples
+	}
 	for i := 0; i < viewNum; i++ {
 		vd := e.exporterFailureQueue[0]
 		if vd.Start.Add(interval).After(currentTime) {
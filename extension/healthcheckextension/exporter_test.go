@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
 )
 
 func TestHealthCheckExporter_ExportView(t *testing.T) {
@@ -48,3 +49,33 @@ func TestHealthCheckExporter_rotate(t *testing.T) {
 	exporter.rotate(5 * time.Minute)
 	assert.Equal(t, 1, len(exporter.exporterFailureQueue))
 }
+
+func TestHealthCheckExporter_dataTypeFailures(t *testing.T) {
+	exporter := &healthCheckExporter{}
+	currentTime := time.Now()
+	sample := func(end time.Time, failures map[string]float64) *view.Data {
+		vd := dataTypeFailureViewData("exporter/send_failed_spans", failures)
+		vd.Start = currentTime.Add(-time.Hour)
+		vd.End = end
+		return vd
+	}
+	exporter.ExportView(sample(currentTime.Add(-10*time.Minute), map[string]float64{"otlp": 4}))
+	exporter.ExportView(sample(currentTime.Add(-3*time.Minute), map[string]float64{"otlp": 6}))
+	exporter.ExportView(sample(currentTime, map[string]float64{"otlp": 7, "otlp/2": 1}))
+	exporter.ExportView(dataTypeFailureViewData("exporter/send_failed_log_records", map[string]float64{"otlp": 2}))
+
+	// failures are counted by report, regardless of the number of items that failed
+	assert.Equal(t, map[component.DataType]map[string]int{
+		component.DataTypeTraces: {"otlp": 3, "otlp/2": 1},
+		component.DataTypeLogs:   {"otlp": 1},
+	}, exporter.dataTypeFailures())
+
+	// reports without new failures are not counted
+	exporter.ExportView(sample(currentTime, map[string]float64{"otlp": 7, "otlp/2": 1}))
+	assert.Equal(t, 3, exporter.dataTypeFailures()[component.DataTypeTraces]["otlp"])
+
+	// only the failures after the last sample before the interval are counted
+	exporter.rotate(5 * time.Minute)
+	assert.Equal(t, 2, exporter.dataTypeFailures()[component.DataTypeTraces]["otlp"])
+	assert.Equal(t, 1, exporter.dataTypeFailures()[component.DataTypeTraces]["otlp/2"])
+}
@@ -36,6 +36,12 @@ func createDefaultConfig() component.Config {
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 		Path:                   "/",
+		Readiness: endpointSettings{
+			Path: "/ready",
+		},
+		ComponentStatus: endpointSettings{
+			Path: "/status",
+		},
 	}
 }
 
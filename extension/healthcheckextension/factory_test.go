@@ -24,6 +24,8 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 		Path:                   "/",
+		Readiness:              endpointSettings{Path: "/ready"},
+		ComponentStatus:        endpointSettings{Path: "/status"},
 	}, cfg)
 
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
@@ -5,14 +5,17 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
 )
@@ -25,9 +28,13 @@ type healthCheckExtension struct {
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
 	settings component.TelemetrySettings
+
+	pipelinesMu sync.RWMutex
+	pipelines   map[component.ID]*pipelineComponents
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
+var _ extension.ConfigWatcher = (*healthCheckExtension)(nil)
 
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
@@ -42,11 +49,21 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		return err
 	}
 
-	if !hc.config.CheckCollectorPipeline.Enabled {
-		// Mount HC handler
-		mux := http.NewServeMux()
+	mux := http.NewServeMux()
+	if hc.config.CheckCollectorPipeline.Enabled {
+		mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
+	} else {
 		mux.Handle(hc.config.Path, hc.baseHandler())
-		hc.server.Handler = mux
+	}
+	if hc.config.Readiness.Enabled {
+		mux.Handle(hc.config.Readiness.Path, hc.readinessHandler())
+	}
+	if hc.config.ComponentStatus.Enabled {
+		mux.Handle(hc.config.ComponentStatus.Path, hc.componentStatusHandler())
+	}
+	hc.server.Handler = mux
+
+	if !hc.config.CheckCollectorPipeline.Enabled && !hc.config.ComponentStatus.Enabled {
 		hc.stopCh = make(chan struct{})
 		go func() {
 			defer close(hc.stopCh)
@@ -69,9 +86,6 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		// ticker used by collector pipeline health check for rotation
 		ticker := time.NewTicker(time.Second)
 
-		mux := http.NewServeMux()
-		mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
-		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
 			defer close(hc.stopCh)
@@ -132,7 +146,45 @@ func (hc *healthCheckExtension) checkCollectorPipelineHandler() http.Handler {
 }
 
 func (hc *healthCheckExtension) check() bool {
-	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
+	if !hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold) {
+		return false
+	}
+	if len(hc.config.CheckCollectorPipeline.PipelineFailureThresholds) == 0 {
+		return true
+	}
+	for _, pipeline := range hc.componentStatus().Pipelines {
+		if !pipeline.Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+// readiness handler function, reporting ready once all receivers have started
+func (hc *healthCheckExtension) readinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if hc.state.Get() == healthcheck.Ready {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+}
+
+// component status handler function, reporting the status of each pipeline and its components as JSON
+func (hc *healthCheckExtension) componentStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status := hc.componentStatus()
+		w.Header().Set("Content-Type", "application/json")
+		if status.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			hc.logger.Warn("failed to write component status", zap.Error(err))
+		}
+	})
 }
 
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
@@ -156,6 +208,22 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// NotifyConfig records the pipelines of the collector configuration, reported by the component status endpoint
+func (hc *healthCheckExtension) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	pipelinesConf, err := conf.Sub("service::pipelines")
+	if err != nil {
+		return err
+	}
+	pipelines := map[component.ID]*pipelineComponents{}
+	if err = pipelinesConf.Unmarshal(&pipelines); err != nil {
+		return fmt.Errorf("failed to read the pipelines of the collector configuration: %w", err)
+	}
+	hc.pipelinesMu.Lock()
+	defer hc.pipelinesMu.Unlock()
+	hc.pipelines = pipelines
+	return nil
+}
+
 func newServer(config Config, settings component.TelemetrySettings) *healthCheckExtension {
 	hc := &healthCheckExtension{
 		config:   config,
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"github.com/jaegertracing/jaeger/pkg/healthcheck"
+	"go.opentelemetry.io/collector/component"
+)
+
+// pipelineComponents are the components of a pipeline, as configured in the service section of the collector configuration
+type pipelineComponents struct {
+	Receivers  []component.ID `mapstructure:"receivers"`
+	Processors []component.ID `mapstructure:"processors"`
+	Exporters  []component.ID `mapstructure:"exporters"`
+}
+
+// collectorStatus is the body of the component status endpoint
+type collectorStatus struct {
+	// Healthy is true when the collector is ready and all pipelines are healthy
+	Healthy bool `json:"healthy"`
+	// Ready is true once all receivers have started
+	Ready     bool                       `json:"ready"`
+	Pipelines map[string]*pipelineStatus `json:"pipelines"`
+}
+
+// pipelineStatus is the status of a pipeline and of its components, by component ID
+type pipelineStatus struct {
+	Healthy          bool                        `json:"healthy"`
+	Failures         int                         `json:"failures"`
+	FailureThreshold int                         `json:"failure_threshold"`
+	Receivers        map[string]*componentStatus `json:"receivers"`
+	Processors       map[string]*componentStatus `json:"processors"`
+	Exporters        map[string]*componentStatus `json:"exporters"`
+}
+
+// componentStatus is the status of a single component
+type componentStatus struct {
+	Healthy  bool `json:"healthy"`
+	Failures int  `json:"failures"`
+}
+
+// componentStatus returns the status of each pipeline. Receivers are healthy once the collector is ready, and
+// exporters as long as they didn't fail more than exporter_failure_threshold times during the
+// check_collector_pipeline interval. Failures are not tracked for processors.
+func (hc *healthCheckExtension) componentStatus() collectorStatus {
+	status := collectorStatus{
+		Ready:     hc.state.Get() == healthcheck.Ready,
+		Pipelines: map[string]*pipelineStatus{},
+	}
+	status.Healthy = status.Ready
+
+	failures := hc.exporter.dataTypeFailures()
+	exporterThreshold := hc.config.CheckCollectorPipeline.ExporterFailureThreshold
+	hc.pipelinesMu.RLock()
+	defer hc.pipelinesMu.RUnlock()
+	for pipelineID, components := range hc.pipelines {
+		pipeline := &pipelineStatus{
+			Healthy:          true,
+			FailureThreshold: exporterThreshold,
+			Receivers:        map[string]*componentStatus{},
+			Processors:       map[string]*componentStatus{},
+			Exporters:        map[string]*componentStatus{},
+		}
+		if threshold, ok := hc.config.CheckCollectorPipeline.PipelineFailureThresholds[pipelineID]; ok {
+			pipeline.FailureThreshold = threshold
+		}
+		for _, id := range components.Receivers {
+			pipeline.Receivers[id.String()] = &componentStatus{Healthy: status.Ready}
+		}
+		for _, id := range components.Processors {
+			pipeline.Processors[id.String()] = &componentStatus{Healthy: true}
+		}
+		for _, id := range components.Exporters {
+			exporter := &componentStatus{
+				Failures: failures[pipelineID.Type()][id.String()],
+			}
+			exporter.Healthy = exporter.Failures <= exporterThreshold
+			pipeline.Exporters[id.String()] = exporter
+			pipeline.Failures += exporter.Failures
+			pipeline.Healthy = pipeline.Healthy && exporter.Healthy
+		}
+		pipeline.Healthy = pipeline.Healthy && pipeline.Failures <= pipeline.FailureThreshold
+		status.Healthy = status.Healthy && pipeline.Healthy
+		status.Pipelines[pipelineID.String()] = pipeline
+	}
+	return status
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func TestComponentStatusAndReadiness(t *testing.T) {
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: checkCollectorPipelineSettings{
+			Enabled:                  true,
+			Interval:                 "5m",
+			ExporterFailureThreshold: 5,
+			PipelineFailureThresholds: map[component.ID]int{
+				component.NewID(component.DataTypeTraces): 1,
+			},
+		},
+		Path:            "/",
+		Readiness:       endpointSettings{Enabled: true, Path: "/ready"},
+		ComponentStatus: endpointSettings{Enabled: true, Path: "/status"},
+	}
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.NoError(t, hcExt.NotifyConfig(context.Background(), confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers":  []any{"otlp"},
+					"processors": []any{"batch"},
+					"exporters":  []any{"otlp", "otlp/2"},
+				},
+				"metrics": map[string]any{
+					"receivers": []any{"otlp"},
+					"exporters": []any{"otlp"},
+				},
+			},
+		},
+	})))
+	require.Eventuallyf(t, ensureServerRunning(config.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	baseURL := "http://" + config.Endpoint
+
+	assertStatusCode(t, baseURL+"/ready", http.StatusServiceUnavailable)
+	status := getComponentStatus(t, baseURL+"/status", http.StatusServiceUnavailable)
+	assert.False(t, status.Ready)
+	assert.False(t, status.Pipelines["traces"].Receivers["otlp"].Healthy)
+
+	require.NoError(t, hcExt.Ready())
+	assertStatusCode(t, baseURL+"/ready", http.StatusOK)
+	assertStatusCode(t, baseURL+"/", http.StatusOK)
+	status = getComponentStatus(t, baseURL+"/status", http.StatusOK)
+	assert.True(t, status.Ready)
+	assert.True(t, status.Healthy)
+	require.Len(t, status.Pipelines, 2)
+	assert.Equal(t, 1, status.Pipelines["traces"].FailureThreshold)
+	assert.Equal(t, 5, status.Pipelines["metrics"].FailureThreshold)
+	assert.True(t, status.Pipelines["traces"].Receivers["otlp"].Healthy)
+	assert.True(t, status.Pipelines["traces"].Processors["batch"].Healthy)
+	assert.Len(t, status.Pipelines["traces"].Exporters, 2)
+	assert.Empty(t, status.Pipelines["metrics"].Processors)
+
+	// otlp and otlp/2 failed once each, which exceeds the threshold of the traces pipeline only,
+	// the failures of otlp are not charged to the metrics pipeline it is also part of
+	hcExt.exporter.ExportView(dataTypeFailureViewData("exporter/send_failed_spans", map[string]float64{"otlp": 10, "otlp/2": 20}))
+	hcExt.exporter.ExportView(dataTypeFailureViewData("exporter/send_failed_metric_points", map[string]float64{}))
+	assertStatusCode(t, baseURL+"/", http.StatusInternalServerError)
+	assertStatusCode(t, baseURL+"/ready", http.StatusOK)
+	status = getComponentStatus(t, baseURL+"/status", http.StatusServiceUnavailable)
+	assert.False(t, status.Healthy)
+	assert.False(t, status.Pipelines["traces"].Healthy)
+	assert.Equal(t, 2, status.Pipelines["traces"].Failures)
+	assert.True(t, status.Pipelines["traces"].Exporters["otlp/2"].Healthy)
+	assert.Equal(t, 1, status.Pipelines["traces"].Exporters["otlp/2"].Failures)
+	assert.Equal(t, 1, status.Pipelines["traces"].Exporters["otlp"].Failures)
+	assert.True(t, status.Pipelines["metrics"].Healthy)
+	assert.Equal(t, 0, status.Pipelines["metrics"].Exporters["otlp"].Failures)
+}
+
+func assertStatusCode(t *testing.T, url string, expected int) {
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	assert.Equal(t, expected, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+}
+
+func getComponentStatus(t *testing.T, url string, expectedStatusCode int) collectorStatus {
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	assert.Equal(t, expectedStatusCode, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var status collectorStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	return status
+}
+
+// dataTypeFailureViewData returns the data of a failure view with the cumulative failures of the exporters
+func dataTypeFailureViewData(name string, failures map[string]float64) *view.Data {
+	vd := &view.Data{
+		View:  &view.View{Name: name},
+		Start: time.Now().Add(-time.Minute),
+		End:   time.Now(),
+	}
+	for exporter, value := range failures {
+		vd.Rows = append(vd.Rows, &view.Row{
+			Tags: []tag.Tag{{Key: tag.MustNewKey(exporterTagKey), Value: exporter}},
+			Data: &view.SumData{Value: value},
+		})
+	}
+	return vd
+}
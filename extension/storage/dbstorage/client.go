@@ -8,58 +8,150 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	// Postgres driver
 	_ "github.com/jackc/pgx/v4/stdlib"
 	// SQLite driver
 	_ "github.com/mattn/go-sqlite3"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
 )
 
 const (
-	createTable     = "create table if not exists %s (key text primary key, value blob)"
-	getQueryText    = "select value from %s where key=?"
-	setQueryText    = "insert into %s(key, value) values(?,?) on conflict(key) do update set value=?"
-	deleteQueryText = "delete from %s where key=?"
+	createTable              = "create table if not exists %s (key text primary key, value %s)"
+	createSchemaVersionTable = "create table if not exists " + schemaVersionTable + " (table_name text primary key, version integer)"
+	getSchemaVersionText     = "select version from " + schemaVersionTable + " where table_name=?"
+	setSchemaVersionText     = "insert into " + schemaVersionTable + "(table_name, version) values(?,?) on conflict(table_name) do update set version=?"
+	getQueryText             = "select value from %s where key=? and (expires_at is null or expires_at > ?)"
+	setQueryText             = "insert into %s(key, value, expires_at) values(?,?,?) on conflict(key) do update set value=?, expires_at=?"
+	deleteQueryText          = "delete from %s where key=?"
+	deleteExpiredQueryText   = "delete from %s where expires_at is not null and expires_at <= ?"
+
+	schemaVersionTable = "dbstorage_schema_version"
 )
 
+// migrations upgrade the table of a client from one schema version to the next:
+// migrations[i] upgrades a table from version i+1 to version i+2.
+// Tables created before schema versioning was introduced are at version 1.
+var migrations = []string{
+	"alter table %s add column expires_at bigint",
+}
+
+// schemaVersion is the version of the table schema expected by the client.
+var schemaVersion = len(migrations) + 1
+
 type dbStorageClient struct {
-	db          *sql.DB
-	getQuery    *sql.Stmt
-	setQuery    *sql.Stmt
-	deleteQuery *sql.Stmt
+	db                 *sql.DB
+	getQuery           *sql.Stmt
+	setQuery           *sql.Stmt
+	deleteQuery        *sql.Stmt
+	deleteExpiredQuery *sql.Stmt
+	ttl                time.Duration
+	logger             *zap.Logger
+
+	stopCleanup chan struct{}
+	stopOnce    sync.Once
+	cleanupDone sync.WaitGroup
 }
 
-func newClient(ctx context.Context, db *sql.DB, tableName string) (*dbStorageClient, error) {
-	var err error
-	_, err = db.ExecContext(ctx, fmt.Sprintf(createTable, tableName))
+func newClient(ctx context.Context, logger *zap.Logger, db *sql.DB, driverName string, tableName string, ttl time.Duration) (*dbStorageClient, error) {
+	d := dialectFor(driverName)
+	if err := migrate(ctx, db, d, tableName); err != nil {
+		return nil, fmt.Errorf("failed to migrate table %s: %w", tableName, err)
+	}
+
+	selectQuery, err := db.PrepareContext(ctx, d.rebind(fmt.Sprintf(getQueryText, tableName)))
 	if err != nil {
 		return nil, err
 	}
-
-	selectQuery, err := db.PrepareContext(ctx, fmt.Sprintf(getQueryText, tableName))
+	setQuery, err := db.PrepareContext(ctx, d.rebind(fmt.Sprintf(setQueryText, tableName)))
 	if err != nil {
 		return nil, err
 	}
-	setQuery, err := db.PrepareContext(ctx, fmt.Sprintf(setQueryText, tableName))
+	deleteQuery, err := db.PrepareContext(ctx, d.rebind(fmt.Sprintf(deleteQueryText, tableName)))
 	if err != nil {
 		return nil, err
 	}
-	deleteQuery, err := db.PrepareContext(ctx, fmt.Sprintf(deleteQueryText, tableName))
+	deleteExpiredQuery, err := db.PrepareContext(ctx, d.rebind(fmt.Sprintf(deleteExpiredQueryText, tableName)))
 	if err != nil {
 		return nil, err
 	}
-	return &dbStorageClient{db, selectQuery, setQuery, deleteQuery}, nil
+	client := &dbStorageClient{
+		db:                 db,
+		getQuery:           selectQuery,
+		setQuery:           setQuery,
+		deleteQuery:        deleteQuery,
+		deleteExpiredQuery: deleteExpiredQuery,
+		ttl:                ttl,
+		logger:             logger,
+		stopCleanup:        make(chan struct{}),
+	}
+	if ttl > 0 {
+		if err = client.deleteExpired(ctx); err != nil {
+			return nil, err
+		}
+		client.startCleanupLoop()
+	}
+	return client, nil
+}
+
+// migrate creates the table of a client if it doesn't exist,
+// and upgrades its schema to the current version in a single transaction.
+func migrate(ctx context.Context, db *sql.DB, d dialect, tableName string) error {
+	if _, err := db.ExecContext(ctx, createSchemaVersionTable); err != nil {
+		return err
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var version int
+	err = tx.QueryRowContext(ctx, d.rebind(getSchemaVersionText), tableName).Scan(&version)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		version = 1
+	case err != nil:
+		return err
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("table schema version %d is newer than the supported version %d", version, schemaVersion)
+	}
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(createTable, tableName, d.blobType)); err != nil {
+		return err
+	}
+	for ; version < schemaVersion; version++ {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(migrations[version-1], tableName)); err != nil {
+			return fmt.Errorf("failed to upgrade schema to version %d: %w", version+1, err)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, d.rebind(setSchemaVersionText), tableName, schemaVersion, schemaVersion); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // Get will retrieve data from storage that corresponds to the specified key
 func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
-	rows, err := c.getQuery.QueryContext(ctx, key)
+	return c.get(ctx, c.getQuery, key)
+}
+
+func (c *dbStorageClient) get(ctx context.Context, stmt *sql.Stmt, key string) ([]byte, error) {
+	rows, err := stmt.QueryContext(ctx, key, time.Now().UnixNano())
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	if !rows.Next() {
-		return nil, nil
+		return nil, rows.Err()
 	}
 	var result []byte
 	err = rows.Scan(&result)
@@ -72,7 +164,15 @@ func (c *dbStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
 
 // Set will store data. The data can be retrieved using the same key
 func (c *dbStorageClient) Set(ctx context.Context, key string, value []byte) error {
-	_, err := c.setQuery.ExecContext(ctx, key, value, value)
+	return c.set(ctx, c.setQuery, key, value)
+}
+
+func (c *dbStorageClient) set(ctx context.Context, stmt *sql.Stmt, key string, value []byte) error {
+	var expiresAt sql.NullInt64
+	if c.ttl > 0 {
+		expiresAt = sql.NullInt64{Int64: time.Now().Add(c.ttl).UnixNano(), Valid: true}
+	}
+	_, err := stmt.ExecContext(ctx, key, value, expiresAt, value, expiresAt)
 	return err
 }
 
@@ -82,17 +182,28 @@ func (c *dbStorageClient) Delete(ctx context.Context, key string) error {
 	return err
 }
 
-// Batch executes the specified operations in order. Get operation results are updated in place
+// Batch executes the specified operations in order, in a single transaction.
+// Get operation results are updated in place
 func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
-	var err error
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	getQuery := tx.StmtContext(ctx, c.getQuery)
+	setQuery := tx.StmtContext(ctx, c.setQuery)
+	deleteQuery := tx.StmtContext(ctx, c.deleteQuery)
 	for _, op := range ops {
 		switch op.Type {
 		case storage.Get:
-			op.Value, err = c.Get(ctx, op.Key)
+			op.Value, err = c.get(ctx, getQuery, op.Key)
 		case storage.Set:
-			err = c.Set(ctx, op.Key, op.Value)
+			err = c.set(ctx, setQuery, op.Key, op.Value)
 		case storage.Delete:
-			err = c.Delete(ctx, op.Key)
+			_, err = deleteQuery.ExecContext(ctx, op.Key)
 		default:
 			return errors.New("wrong operation type")
 		}
@@ -101,16 +212,81 @@ func (c *dbStorageClient) Batch(ctx context.Context, ops ...storage.Operation) e
 			return err
 		}
 	}
-	return err
+	return tx.Commit()
 }
 
 // Close will close the database
 func (c *dbStorageClient) Close(_ context.Context) error {
+	c.stopOnce.Do(func() { close(c.stopCleanup) })
+	c.cleanupDone.Wait()
 	if err := c.setQuery.Close(); err != nil {
 		return err
 	}
 	if err := c.deleteQuery.Close(); err != nil {
 		return err
 	}
+	if err := c.deleteExpiredQuery.Close(); err != nil {
+		return err
+	}
 	return c.getQuery.Close()
 }
+
+func (c *dbStorageClient) deleteExpired(ctx context.Context) error {
+	_, err := c.deleteExpiredQuery.ExecContext(ctx, time.Now().UnixNano())
+	return err
+}
+
+// startCleanupLoop periodically deletes expired keys, so that keys which are never read again
+// don't accumulate in the database. Expired keys are never returned by Get in between.
+func (c *dbStorageClient) startCleanupLoop() {
+	c.cleanupDone.Add(1)
+	go func() {
+		defer c.cleanupDone.Done()
+		ticker := time.NewTicker(c.ttl)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.deleteExpired(context.Background()); err != nil {
+					c.logger.Error("failed to delete expired keys", zap.Error(err))
+				}
+			case <-c.stopCleanup:
+				return
+			}
+		}
+	}()
+}
+
+// dialect holds the differences between the supported SQL databases.
+type dialect struct {
+	blobType string
+	// numberedPlaceholders is set for databases using $1, $2... instead of ? as placeholders.
+	numberedPlaceholders bool
+}
+
+func dialectFor(driverName string) dialect {
+	switch driverName {
+	case "pgx", "postgres":
+		return dialect{blobType: "bytea", numberedPlaceholders: true}
+	default:
+		return dialect{blobType: "blob"}
+	}
+}
+
+// rebind replaces the ? placeholders of query with the placeholders of the dialect.
+func (d dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Skip tests on Windows temporarily, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/11451
+//go:build !windows
+// +build !windows
+
+package dbstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestDB(t), 0)
+
+	require.NoError(t, client.Set(ctx, "existing", []byte("value")))
+
+	getExisting := storage.GetOperation("existing")
+	getNew := storage.GetOperation("new")
+	require.NoError(t, client.Batch(ctx,
+		getExisting,
+		storage.SetOperation("new", []byte("new value")),
+		getNew,
+		storage.DeleteOperation("existing"),
+	))
+	assert.Equal(t, []byte("value"), getExisting.Value)
+	assert.Equal(t, []byte("new value"), getNew.Value)
+
+	value, err := client.Get(ctx, "existing")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientBatchRollback(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newTestDB(t), 0)
+
+	invalid := storage.GetOperation("key")
+	invalid.Type = 100
+	err := client.Batch(ctx, storage.SetOperation("key", []byte("value")), invalid)
+	require.Error(t, err)
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value, "operations of a failed batch must not be applied")
+}
+
+func TestClientTTL(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	client := newTestClient(t, db, 50*time.Millisecond)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.Eventually(t, func() bool {
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, "select count(*) from test").Scan(&count))
+		return count == 0
+	}, 5*time.Second, 10*time.Millisecond, "expired key must be deleted")
+
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestClientMigration(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	// A table created before schema versioning was introduced.
+	_, err := db.ExecContext(ctx, "create table test (key text primary key, value blob)")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "insert into test(key, value) values('key', 'value')")
+	require.NoError(t, err)
+
+	client := newTestClient(t, db, 0)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	var version int
+	require.NoError(t, db.QueryRowContext(ctx, "select version from dbstorage_schema_version where table_name='test'").Scan(&version))
+	assert.Equal(t, schemaVersion, version)
+
+	// Creating another client for the same table must not run migrations again.
+	newTestClient(t, db, 0)
+}
+
+func TestClientMigrationNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	_, err := db.ExecContext(ctx, createSchemaVersionTable)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, "insert into dbstorage_schema_version(table_name, version) values('test', ?)", schemaVersion+1)
+	require.NoError(t, err)
+
+	_, err = newClient(ctx, zap.NewNop(), db, "sqlite3", "test", 0)
+	require.ErrorContains(t, err, "newer than the supported version")
+}
+
+func TestClientDoubleClose(t *testing.T) {
+	client, err := newClient(context.Background(), zap.NewNop(), newTestDB(t), "sqlite3", "test", time.Minute)
+	require.NoError(t, err)
+	assert.NoError(t, client.Close(context.Background()))
+	assert.NoError(t, client.Close(context.Background()))
+}
+
+func TestDialectRebind(t *testing.T) {
+	assert.Equal(t, "select value from t where key=? and x > ?", dialectFor("sqlite3").rebind("select value from t where key=? and x > ?"))
+	assert.Equal(t, "select value from t where key=$1 and x > $2", dialectFor("pgx").rebind("select value from t where key=? and x > ?"))
+}
+
+func newTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s/foo.db?_busy_timeout=10000&_journal=WAL&_sync=NORMAL", t.TempDir()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, db.Close())
+	})
+	return db
+}
+
+func newTestClient(t *testing.T, db *sql.DB, ttl time.Duration) storage.Client {
+	client, err := newClient(context.Background(), zap.NewNop(), db, "sqlite3", "test", ttl)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, client.Close(context.Background()))
+	})
+	return client
+}
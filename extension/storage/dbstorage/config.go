@@ -5,12 +5,16 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 
 import (
 	"errors"
+	"time"
 )
 
 // Config defines configuration for dbstorage extension.
 type Config struct {
 	DriverName string `mapstructure:"driver,omitempty"`
 	DataSource string `mapstructure:"datasource,omitempty"`
+	// TTL is the time after which a key expires once it has been set.
+	// Expired keys are not returned and are periodically deleted. Keys never expire if unset.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
 }
 
 func (cfg *Config) Validate() error {
@@ -20,6 +24,9 @@ func (cfg *Config) Validate() error {
 	if cfg.DriverName == "" {
 		return errors.New("missing driver name")
 	}
+	if cfg.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
 
 	return nil
 }
@@ -10,6 +10,7 @@ package dbstorage // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,6 +31,11 @@ func TestConfigValidate(t *testing.T) {
 			Config{DriverName: "foo"},
 			errors.New("missing datasource"),
 		},
+		{
+			"Negative ttl",
+			Config{DriverName: "foo", DataSource: "bar", TTL: -time.Second},
+			errors.New("ttl must not be negative"),
+		},
 		{
 			"valid",
 			Config{DriverName: "foo", DataSource: "bar"},
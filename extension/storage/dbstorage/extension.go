@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -18,6 +19,7 @@ import (
 type databaseStorage struct {
 	driverName     string
 	datasourceName string
+	ttl            time.Duration
 	logger         *zap.Logger
 	db             *sql.DB
 }
@@ -29,6 +31,7 @@ func newDBStorage(logger *zap.Logger, config *Config) (extension.Extension, erro
 	return &databaseStorage{
 		driverName:     config.DriverName,
 		datasourceName: config.DataSource,
+		ttl:            config.TTL,
 		logger:         logger,
 	}, nil
 }
@@ -64,7 +67,7 @@ func (ds *databaseStorage) GetClient(ctx context.Context, kind component.Kind, e
 		fullName = fmt.Sprintf("%s_%s_%s_%s", kindString(kind), ent.Type(), ent.Name(), name)
 	}
 	fullName = strings.ReplaceAll(fullName, " ", "")
-	return newClient(ctx, ds.db, fullName)
+	return newClient(ctx, ds.logger, ds.db, ds.driverName, fullName, ds.ttl)
 }
 
 func kindString(k component.Kind) string {
@@ -19,6 +19,13 @@ import (
 
 var defaultBucket = []byte(`default`)
 
+// encryptionBucket holds encryptionEnabledKey once the values of the default bucket are encrypted,
+// so that plain text values are never mistaken for encrypted ones, nor the other way around.
+var (
+	encryptionBucket     = []byte(`encryption`)
+	encryptionEnabledKey = []byte(`enabled`)
+)
+
 const (
 	elapsedKey       = "elapsed"
 	directoryKey     = "directory"
@@ -35,6 +42,8 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	// cipher encrypts values at rest, it is nil when encryption is not enabled
+	cipher *valueCipher
 }
 
 func bboltOptions(timeout time.Duration) *bbolt.Options {
@@ -46,7 +55,7 @@ func bboltOptions(timeout time.Duration) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, cipher *valueCipher) (*fileStorageClient, error) {
 	options := bboltOptions(timeout)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -62,7 +71,11 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout, cipher: cipher}
+	if err := db.Update(client.initEncryption); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -104,16 +117,26 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case c.cipher != nil:
+					// decryption makes a copy of the value
+					op.Value, err = c.cipher.decrypt(value)
+				default:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.cipher != nil {
+					if value, err = c.cipher.encrypt(value); err != nil {
+						return err
+					}
+				}
+				err = bucket.Put([]byte(op.Key), value)
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -133,6 +156,73 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 	return c.db.Update(batch)
 }
 
+// initEncryption checks that the encryption configuration matches the values in the file, and
+// brings all values to the current encryption key: values stored before encryption was enabled
+// are encrypted, and values encrypted with a previous key are re-encrypted, so that previous keys
+// are no longer needed once the client has been opened.
+func (c *fileStorageClient) initEncryption(tx *bbolt.Tx) error {
+	encrypted := false
+	if bucket := tx.Bucket(encryptionBucket); bucket != nil {
+		encrypted = bucket.Get(encryptionEnabledKey) != nil
+	}
+	if c.cipher == nil {
+		if encrypted {
+			return errors.New("storage file is encrypted, but encryption is not configured")
+		}
+		return nil
+	}
+	if err := c.rotateKeys(tx, encrypted); err != nil {
+		return err
+	}
+	if encrypted {
+		return nil
+	}
+	bucket, err := tx.CreateBucketIfNotExists(encryptionBucket)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(encryptionEnabledKey, []byte{encryptedValueVersion})
+}
+
+// rotateKeys encrypts with the current key all values of the default bucket that are not
+// encrypted with it yet. encrypted tells whether the values are encrypted, or in plain text
+// because encryption was just enabled.
+func (c *fileStorageClient) rotateKeys(tx *bbolt.Tx, encrypted bool) error {
+	bucket := tx.Bucket(defaultBucket)
+	var rotated [][2][]byte
+	err := bucket.ForEach(func(k, v []byte) error {
+		value := v
+		if encrypted {
+			if c.cipher.isCurrent(v) {
+				return nil
+			}
+			var err error
+			if value, err = c.cipher.decrypt(v); err != nil {
+				return fmt.Errorf("failed to decrypt value of key %q: %w", k, err)
+			}
+		}
+		value, err := c.cipher.encrypt(value)
+		if err != nil {
+			return err
+		}
+		// the bucket must not be modified while iterating over it
+		rotated = append(rotated, [2][]byte{append([]byte(nil), k...), value})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, kv := range rotated {
+		if err = bucket.Put(kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	if len(rotated) > 0 {
+		c.logger.Info("encrypted values with the current encryption key", zap.Int("count", len(rotated)))
+	}
+	return nil
+}
+
 // Close will close the database
 func (c *fileStorageClient) Close(_ context.Context) error {
 	c.compactionMutex.Lock()
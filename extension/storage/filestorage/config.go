@@ -17,6 +17,8 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -44,6 +46,20 @@ type CompactionConfig struct {
 	CheckInterval time.Duration `mapstructure:"check_interval,omitempty"`
 }
 
+// EncryptionConfig defines configuration for optional encryption of the stored values.
+type EncryptionConfig struct {
+	// KeyFile is the path of a file containing the base64 encoded AES key used to encrypt values.
+	KeyFile string `mapstructure:"key_file,omitempty"`
+	// KeyEnv is the name of an environment variable containing the base64 encoded AES key used to encrypt values.
+	KeyEnv string `mapstructure:"key_env,omitempty"`
+	// PreviousKeyFiles are the paths of files containing keys previously used to encrypt values.
+	// Values encrypted with these keys are re-encrypted with the current key when a client is opened.
+	PreviousKeyFiles []string `mapstructure:"previous_key_files,omitempty"`
+	// PreviousKeyEnvs are the names of environment variables containing keys previously used to encrypt values.
+	// Values encrypted with these keys are re-encrypted with the current key when a client is opened.
+	PreviousKeyEnvs []string `mapstructure:"previous_key_envs,omitempty"`
+}
+
 func (cfg *Config) Validate() error {
 	var dirs []string
 	if cfg.Compaction.OnStart {
@@ -76,5 +92,9 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.Encryption != nil && (cfg.Encryption.KeyFile == "") == (cfg.Encryption.KeyEnv == "") {
+		return errors.New("exactly one of encryption key_file and key_env must be set")
+	}
+
 	return nil
 }
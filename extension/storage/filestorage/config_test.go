@@ -93,3 +93,18 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestEncryptionKeySourceValidation(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	cfg.Encryption = &EncryptionConfig{}
+	assert.EqualError(t, component.ValidateConfig(cfg), "exactly one of encryption key_file and key_env must be set")
+
+	cfg.Encryption = &EncryptionConfig{KeyFile: "key", KeyEnv: "KEY"}
+	assert.EqualError(t, component.ValidateConfig(cfg), "exactly one of encryption key_file and key_env must be set")
+
+	cfg.Encryption = &EncryptionConfig{KeyEnv: "KEY"}
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// encryptedValueMagic starts every encrypted value. It is followed by the version of the format:
+// magic (4 bytes) | version (1 byte) | key ID (4 bytes) | nonce (12 bytes) | AES-GCM sealed value.
+var encryptedValueMagic = []byte{0xfe, 'O', 'T', 'E'}
+
+const encryptedValueVersion byte = 1
+
+const (
+	keyIDSize        = 4
+	valuePrefixSize  = 5 // magic and version
+	valueKeyIDOffset = valuePrefixSize
+	valueNonceOffset = valuePrefixSize + keyIDSize
+)
+
+var errUnknownKey = errors.New("value was encrypted with an unknown key")
+
+// valueCipher encrypts values with the current key, and decrypts values
+// encrypted with the current key or any of the previous keys.
+type valueCipher struct {
+	currentID [keyIDSize]byte
+	aeads     map[[keyIDSize]byte]cipher.AEAD
+}
+
+func newValueCipher(cfg *EncryptionConfig) (*valueCipher, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	current, err := readKey(cfg.KeyFile, cfg.KeyEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption key: %w", err)
+	}
+	keys := [][]byte{current}
+	for _, keyFile := range cfg.PreviousKeyFiles {
+		key, err := readKey(keyFile, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous encryption key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	for _, keyEnv := range cfg.PreviousKeyEnvs {
+		key, err := readKey("", keyEnv)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous encryption key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	c := &valueCipher{aeads: make(map[[keyIDSize]byte]cipher.AEAD, len(keys))}
+	for i, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		id := keyID(key)
+		if i == 0 {
+			c.currentID = id
+		}
+		if _, ok := c.aeads[id]; !ok {
+			c.aeads[id] = aead
+		}
+	}
+	return c, nil
+}
+
+// readKey reads a base64 encoded key from a file, or from an environment variable.
+func readKey(file string, env string) ([]byte, error) {
+	var encoded string
+	switch {
+	case file != "":
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(content)
+	case env != "":
+		var ok bool
+		if encoded, ok = os.LookupEnv(env); !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", env)
+		}
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key is not base64 encoded: %w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("key must be 16, 24 or 32 bytes long, got %d bytes", len(key))
+	}
+}
+
+// keyID identifies a key in encrypted values without revealing it.
+func keyID(key []byte) [keyIDSize]byte {
+	var id [keyIDSize]byte
+	sum := sha256.Sum256(key)
+	copy(id[:], sum[:keyIDSize])
+	return id
+}
+
+func (c *valueCipher) encrypt(value []byte) ([]byte, error) {
+	aead := c.aeads[c.currentID]
+	header := make([]byte, valueNonceOffset+aead.NonceSize(), valueNonceOffset+aead.NonceSize()+len(value)+aead.Overhead())
+	copy(header, encryptedValueMagic)
+	header[len(encryptedValueMagic)] = encryptedValueVersion
+	copy(header[valueKeyIDOffset:], c.currentID[:])
+	nonce := header[valueNonceOffset:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	// the header is authenticated along with the value, so it cannot be tampered with
+	return aead.Seal(header, nonce, value, header), nil
+}
+
+func (c *valueCipher) decrypt(value []byte) ([]byte, error) {
+	if !c.isEncrypted(value) {
+		return nil, errors.New("value is not encrypted")
+	}
+	if value[len(encryptedValueMagic)] != encryptedValueVersion {
+		return nil, fmt.Errorf("unsupported encrypted value version %d", value[len(encryptedValueMagic)])
+	}
+	var id [keyIDSize]byte
+	copy(id[:], value[valueKeyIDOffset:])
+	aead, ok := c.aeads[id]
+	if !ok {
+		return nil, errUnknownKey
+	}
+	headerSize := valueNonceOffset + aead.NonceSize()
+	if len(value) < headerSize+aead.Overhead() {
+		return nil, errors.New("encrypted value is truncated")
+	}
+	header := value[:headerSize]
+	return aead.Open(nil, header[valueNonceOffset:], value[headerSize:], header)
+}
+
+// isEncrypted reports whether value starts with the header of an encrypted value.
+func (c *valueCipher) isEncrypted(value []byte) bool {
+	return len(value) >= valueNonceOffset && bytes.HasPrefix(value, encryptedValueMagic)
+}
+
+// isCurrent reports whether value was encrypted in the current format with the current key.
+func (c *valueCipher) isCurrent(value []byte) bool {
+	return c.isEncrypted(value) &&
+		value[len(encryptedValueMagic)] == encryptedValueVersion &&
+		bytes.Equal(value[valueKeyIDOffset:valueNonceOffset], c.currentID[:])
+}
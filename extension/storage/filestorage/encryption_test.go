@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+func TestEncryptedClientRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	cipher, err := newValueCipher(&EncryptionConfig{KeyFile: writeTestKey(t, tempDir, "key", 32)})
+	require.NoError(t, err)
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, cipher)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	myBytes := []byte("sensitive telemetry")
+	require.NoError(t, client.Set(ctx, "key", myBytes))
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, myBytes, value)
+
+	missing, err := client.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	require.NoError(t, client.Close(ctx))
+
+	raw := readRawValue(t, dbFile, "key")
+	assert.False(t, bytes.Contains(raw, myBytes), "value must not be stored in plain text")
+}
+
+func TestEncryptedClientKeyFromEnv(t *testing.T) {
+	key := make([]byte, 16)
+	t.Setenv("FILE_STORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(key))
+
+	cipher, err := newValueCipher(&EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_KEY"})
+	require.NoError(t, err)
+
+	encrypted, err := cipher.encrypt([]byte("value"))
+	require.NoError(t, err)
+	decrypted, err := cipher.decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), decrypted)
+}
+
+func TestEncryptedClientKeyRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	oldKeyFile := writeTestKey(t, tempDir, "old", 32)
+	newKeyFile := writeTestKey(t, tempDir, "new", 32)
+	ctx := context.Background()
+
+	oldCipher, err := newValueCipher(&EncryptionConfig{KeyFile: oldKeyFile})
+	require.NoError(t, err)
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, oldCipher)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	// a client with only the new key cannot read values encrypted with the old key
+	newOnlyCipher, err := newValueCipher(&EncryptionConfig{KeyFile: newKeyFile})
+	require.NoError(t, err)
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newOnlyCipher)
+	require.ErrorIs(t, err, errUnknownKey)
+
+	rotatingCipher, err := newValueCipher(&EncryptionConfig{KeyFile: newKeyFile, PreviousKeyFiles: []string{oldKeyFile}})
+	require.NoError(t, err)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, rotatingCipher)
+	require.NoError(t, err)
+	require.NoError(t, client.Close(ctx))
+
+	// values have been re-encrypted, so the old key is no longer needed
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newOnlyCipher)
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	require.NoError(t, client.Close(ctx))
+}
+
+func TestEncryptedValueTampering(t *testing.T) {
+	cipher, err := newValueCipher(&EncryptionConfig{KeyFile: writeTestKey(t, t.TempDir(), "key", 24)})
+	require.NoError(t, err)
+
+	encrypted, err := cipher.encrypt([]byte("value"))
+	require.NoError(t, err)
+	encrypted[len(encrypted)-1] ^= 0xff
+	_, err = cipher.decrypt(encrypted)
+	assert.Error(t, err)
+
+	_, err = cipher.decrypt([]byte("plain"))
+	assert.Error(t, err)
+}
+
+func TestNewValueCipherErrors(t *testing.T) {
+	tempDir := t.TempDir()
+
+	_, err := newValueCipher(&EncryptionConfig{KeyFile: filepath.Join(tempDir, "missing")})
+	assert.Error(t, err)
+
+	_, err = newValueCipher(&EncryptionConfig{KeyFile: writeTestKey(t, tempDir, "short", 10)})
+	assert.ErrorContains(t, err, "key must be 16, 24 or 32 bytes long, got 10 bytes")
+
+	_, err = newValueCipher(&EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_UNSET_KEY"})
+	assert.ErrorContains(t, err, "environment variable FILE_STORAGE_TEST_UNSET_KEY is not set")
+
+	cipher, err := newValueCipher(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, cipher)
+}
+
+func writeTestKey(t *testing.T, dir string, name string, size int) string {
+	key := make([]byte, size)
+	for i := range key {
+		key[i] = name[i%len(name)] + byte(i)
+	}
+	keyFile := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+	return keyFile
+}
+
+func readRawValue(t *testing.T, dbFile string, key string) []byte {
+	db, err := bbolt.Open(dbFile, 0600, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	var value []byte
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		value = append(value, tx.Bucket(defaultBucket).Get([]byte(key))...)
+		return nil
+	}))
+	return value
+}
+
+func TestEncryptedClientPlaintextMigration(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := context.Background()
+
+	// values as stored by a persistent queue: little endian indices, and marshaled requests
+	index := make([]byte, 8)
+	binary.LittleEndian.PutUint64(index, 1)
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("plain telemetry")
+	item, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+	plain := map[string][]byte{
+		"ri": index,
+		"wi": append([]byte(nil), index...),
+		"0":  item,
+	}
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	require.NoError(t, err)
+	for key, value := range plain {
+		require.NoError(t, client.Set(ctx, key, value))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	cipher, err := newValueCipher(&EncryptionConfig{KeyFile: writeTestKey(t, tempDir, "key", 32)})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		// values are encrypted once, when the file is first opened with encryption enabled
+		client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, cipher)
+		require.NoError(t, err)
+		for key, expected := range plain {
+			value, err := client.Get(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, expected, value)
+		}
+		require.NoError(t, client.Close(ctx))
+	}
+
+	for key := range plain {
+		raw := readRawValue(t, dbFile, key)
+		assert.True(t, cipher.isCurrent(raw), "value of %q must be encrypted in place", key)
+	}
+	assert.False(t, bytes.Contains(readRawValue(t, dbFile, "0"), []byte("plain telemetry")))
+
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil)
+	assert.ErrorContains(t, err, "storage file is encrypted, but encryption is not configured")
+}
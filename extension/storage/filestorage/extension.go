@@ -17,6 +17,7 @@ import (
 type localFileStorage struct {
 	cfg    *Config
 	logger *zap.Logger
+	cipher *valueCipher
 }
 
 // Ensure this storage extension implements the appropriate interface
@@ -29,9 +30,11 @@ func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extensio
 	}, nil
 }
 
-// Start does nothing
+// Start reads the encryption keys, if encryption is enabled
 func (lfs *localFileStorage) Start(context.Context, component.Host) error {
-	return nil
+	var err error
+	lfs.cipher, err = newValueCipher(lfs.cfg.Encryption)
+	return err
 }
 
 // Shutdown will close any open databases
@@ -51,7 +54,7 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 	}
 	// TODO sanitize rawName
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.cipher)
 
 	if err != nil {
 		return nil, err
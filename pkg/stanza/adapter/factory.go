@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
 	rcvr "go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/consumerretry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
@@ -54,8 +55,10 @@ func createLogsReceiver(logReceiverType LogReceiverType) rcvr.CreateLogsFunc {
 		}
 		emitter := NewLogEmitter(params.Logger.Sugar(), emitterOpts...)
 		pipe, err := pipeline.Config{
-			Operators:     operators,
-			DefaultOutput: emitter,
+			Operators:        operators,
+			DefaultOutput:    emitter,
+			MeterProvider:    params.MeterProvider,
+			MetricAttributes: []attribute.KeyValue{attribute.String("receiver", params.ID.String())},
 		}.Build(params.Logger.Sugar())
 		if err != nil {
 			return nil, err
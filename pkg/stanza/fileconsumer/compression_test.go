@@ -0,0 +1,324 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func gzipContent(t *testing.T, content string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdContent(t *testing.T, content string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, path string, content []byte) {
+	require.NoError(t, os.WriteFile(path, content, 0600))
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	cases := []struct {
+		name        string
+		compression string
+		files       map[string][]byte
+		expected    [][]byte
+	}{
+		{
+			name:        "gzip",
+			compression: compression.Gzip,
+			files: map[string][]byte{
+				"a.log.gz": gzipContent(t, "testlog1\ntestlog2\n"),
+			},
+			expected: [][]byte{[]byte("testlog1"), []byte("testlog2")},
+		},
+		{
+			name:        "zstd",
+			compression: compression.Zstd,
+			files: map[string][]byte{
+				"a.log.zst": zstdContent(t, "testlog1\ntestlog2\n"),
+			},
+			expected: [][]byte{[]byte("testlog1"), []byte("testlog2")},
+		},
+		{
+			name:        "auto",
+			compression: compression.Auto,
+			files: map[string][]byte{
+				"a.log":     []byte("testlog1\n"),
+				"a.log.gz":  gzipContent(t, "testlog2\n"),
+				"a.log.zst": zstdContent(t, "testlog3\n"),
+			},
+			expected: [][]byte{[]byte("testlog1"), []byte("testlog2"), []byte("testlog3")},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.compression
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			for name, content := range tc.files {
+				writeFile(t, filepath.Join(tempDir, name), content)
+			}
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForTokens(t, emitCalls, tc.expected)
+		})
+	}
+}
+
+func TestCompressedFileStartAtEnd(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.Compression = compression.Gzip
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "a.log.gz")
+	writeFile(t, path, gzipContent(t, "testlog1\n"))
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	expectNoTokens(t, emitCalls)
+
+	// A new gzip member is appended, only its content is read
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(gzipContent(t, "testlog2\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	expectNoTokens(t, emitCalls)
+}
+
+// A file compressed after its rotation, as done by logrotate, has the fingerprint of the original
+// file: only the lines written after the last read of the original file are read from it.
+func TestRotatedFileCompressed(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Moving files while open is unsupported on Windows")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compression.Auto
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "a.log")
+	writeFile(t, path, []byte("testlog1\ntestlog2\n"))
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+	// The file is rotated, then compressed, with lines written in between
+	writeFile(t, path+".1.gz", gzipContent(t, "testlog1\ntestlog2\ntestlog3\n"))
+	require.NoError(t, os.Remove(path))
+	writeFile(t, path, []byte("testlog4\n"))
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog3"), []byte("testlog4")})
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+// A compressed file still being written is read up to the end of the data flushed so far.
+func TestCompressedFileWrittenProgressively(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compression.Gzip
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	f, err := os.Create(filepath.Join(tempDir, "a.log.gz"))
+	require.NoError(t, err)
+	defer f.Close()
+	w := gzip.NewWriter(f)
+
+	_, err = w.Write([]byte("testlog1\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForToken(t, emitCalls, []byte("testlog1"))
+
+	_, err = w.Write([]byte("testlog2\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog2"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestCompressedFileRestartOffsets(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compression.Zstd
+	persister := testutil.NewMockPersister("test")
+
+	path := filepath.Join(tempDir, "a.log.zst")
+	writeFile(t, path, zstdContent(t, "testlog1\n"))
+
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	require.NoError(t, operatorOne.Start(persister))
+	waitForToken(t, emitCallsOne, []byte("testlog1"))
+	require.NoError(t, operatorOne.Stop())
+
+	writeFile(t, path, zstdContent(t, "testlog1\ntestlog2\n"))
+
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	defer func() {
+		require.NoError(t, operatorTwo.Stop())
+	}()
+	waitForToken(t, emitCallsTwo, []byte("testlog2"))
+	expectNoTokens(t, emitCallsTwo)
+}
+
+// A compressed file that didn't change since it was read to the end is not decompressed again
+func TestFinishedCompressedFileNotDecompressedAgain(t *testing.T) {
+	f, emitChan := testReaderFactory(t)
+	f.compression = compression.Gzip
+	core, logs := observer.New(zap.DebugLevel)
+	f.SugaredLogger = zap.New(core).Sugar()
+
+	content := gzipContent(t, "testlog1\ntestlog2\n")
+	temp := openTemp(t, t.TempDir())
+	_, err := temp.Write(content)
+	require.NoError(t, err)
+
+	fp, err := f.newFingerprint(temp)
+	require.NoError(t, err)
+	r, err := f.newReader(temp, fp)
+	require.NoError(t, err)
+
+	r.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog1"), readToken(t, emitChan))
+	require.Equal(t, []byte("testlog2"), readToken(t, emitChan))
+	require.True(t, r.eof)
+	require.Equal(t, int64(len(content)), r.CompressedSize)
+
+	// The next poll cycle copies the reader. Content of the same size that can't be
+	// decompressed would fail to be read if the file was decompressed again.
+	next, err := f.copy(r, temp)
+	require.NoError(t, err)
+	_, err = temp.WriteAt(bytes.Repeat([]byte{0}, len(content)), 0)
+	require.NoError(t, err)
+
+	next.ReadToEnd(context.Background())
+	require.True(t, next.eof)
+	require.Equal(t, r.Offset, next.Offset)
+	require.Zero(t, logs.FilterLevelExact(zap.ErrorLevel).Len())
+	expectNoTokens(t, emitChan)
+}
+
+// The decompression stream of a growing compressed file is kept across poll cycles
+func TestCompressedFileStreamHandedOver(t *testing.T) {
+	f, emitChan := testReaderFactory(t)
+	f.compression = compression.Gzip
+
+	path := filepath.Join(t.TempDir(), "a.log.gz")
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	defer out.Close()
+	w := gzip.NewWriter(out)
+	_, err = w.Write([]byte("testlog1\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	fp, err := f.newFingerprint(file)
+	require.NoError(t, err)
+	r, err := f.newReader(file, fp)
+	require.NoError(t, err)
+	r.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog1"), readToken(t, emitChan))
+	stream := r.stream
+	require.NotNil(t, stream)
+
+	// The next poll cycle opens the file again
+	reopened, err := os.Open(path)
+	require.NoError(t, err)
+	next, err := f.copy(r, reopened)
+	require.NoError(t, err)
+	defer next.Close()
+	require.Same(t, stream, next.stream)
+	require.Nil(t, r.stream)
+	r.Close()
+
+	_, err = w.Write([]byte("testlog2\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	next.ReadToEnd(context.Background())
+	require.Equal(t, []byte("testlog2"), readToken(t, emitChan))
+	expectNoTokens(t, emitChan)
+
+	// A different file gets a stream of its own
+	other := openTemp(t, t.TempDir())
+	_, err = other.Write(gzipContent(t, "testlog1\ntestlog2\n"))
+	require.NoError(t, err)
+	copied, err := f.copy(next, other)
+	require.NoError(t, err)
+	defer copied.Close()
+	require.Nil(t, copied.stream)
+	require.Same(t, stream, next.stream)
+}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/splitter"
@@ -70,9 +71,11 @@ type Config struct {
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
 	MaxBatches              int                   `mapstructure:"max_batches,omitempty"`
+	MaxBytesPerPoll         helper.ByteSize       `mapstructure:"max_bytes_per_poll,omitempty"`
 	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 	Header                  *HeaderConfig         `mapstructure:"header,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
 }
 
 type HeaderConfig struct {
@@ -159,6 +162,7 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit emit.Callback, fact
 			readerConfig: &readerConfig{
 				fingerprintSize:         int(c.FingerprintSize),
 				maxLogSize:              int(c.MaxLogSize),
+				maxBytesPerPoll:         int64(c.MaxBytesPerPoll),
 				emit:                    emit,
 				includeFileName:         c.IncludeFileName,
 				includeFilePath:         c.IncludeFilePath,
@@ -169,6 +173,7 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit emit.Callback, fact
 			splitterFactory: factory,
 			encoding:        enc,
 			headerConfig:    hCfg,
+			compression:     c.Compression,
 		},
 		fileMatcher:     fileMatcher,
 		roller:          newRoller(),
@@ -214,10 +219,18 @@ func (c Config) validate() error {
 		return fmt.Errorf("`header` cannot be specified with `start_at: end`")
 	}
 
+	if err := compression.Validate(c.Compression); err != nil {
+		return fmt.Errorf("`compression`: %w", err)
+	}
+
 	if c.MaxBatches < 0 {
 		return errors.New("`max_batches` must not be negative")
 	}
 
+	if c.MaxBytesPerPoll < 0 {
+		return errors.New("`max_bytes_per_poll` must not be negative")
+	}
+
 	enc, err := helper.LookupEncoding(c.Splitter.Encoding)
 	if err != nil {
 		return err
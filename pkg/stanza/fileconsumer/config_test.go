@@ -387,6 +387,31 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "exclude_older_than",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Include = append(cfg.Include, "*.log")
+					cfg.ExcludeOlderThan = 24 * time.Hour
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "max_bytes_per_poll",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.MaxBytesPerPoll = 1024 * 1024
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "compression_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "auto"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "header_config",
 				Expect: func() *mockOperatorConfig {
@@ -560,6 +585,50 @@ func TestBuild(t *testing.T) {
 				require.Equal(t, 6, m.maxBatches)
 			},
 		},
+		{
+			"InvalidExcludeOlderThan",
+			func(f *Config) {
+				f.ExcludeOlderThan = -time.Hour
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidMaxBytesPerPoll",
+			func(f *Config) {
+				f.MaxBytesPerPoll = -1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidMaxBytesPerPoll",
+			func(f *Config) {
+				f.MaxBytesPerPoll = 1024
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, int64(1024), m.readerFactory.readerConfig.maxBytesPerPoll)
+			},
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"ValidCompression",
+			func(f *Config) {
+				f.Compression = "gzip"
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, "gzip", m.readerFactory.compression)
+			},
+		},
 		{
 			"HeaderConfigNoFlag",
 			func(f *Config) {
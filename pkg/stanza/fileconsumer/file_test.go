@@ -1664,3 +1664,97 @@ func TestStalePartialFingerprintDiscarded(t *testing.T) {
 	waitForTokens(t, emitCalls, [][]byte{[]byte(content), []byte(newContent1), []byte(newContent)})
 	operator.wg.Wait()
 }
+
+func TestExcludeOlderThan(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.ExcludeOlderThan = time.Hour
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	oldFile := openTemp(t, tempDir)
+	writeString(t, oldFile, "old log\n")
+	oldTime := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(oldFile.Name(), oldTime, oldTime))
+
+	newFile := openTemp(t, tempDir)
+	writeString(t, newFile, "new log\n")
+
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForToken(t, emitCalls, []byte("new log"))
+	expectNoTokens(t, emitCalls)
+
+	// The old file is read once it is modified again
+	writeString(t, oldFile, "old log updated\n")
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("old log"), []byte("old log updated")})
+}
+
+func TestMaxBytesPerPoll(t *testing.T) {
+	t.Parallel()
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.MaxBytesPerPoll = 20
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	// Lines of 10 bytes, newline included
+	largeFile := openTemp(t, tempDir)
+	for i := 0; i < 5; i++ {
+		writeString(t, largeFile, fmt.Sprintf("large-%03d\n", i))
+	}
+	smallFile := openTemp(t, tempDir)
+	writeString(t, smallFile, "small-000\n")
+
+	// The large file is read up to its budget, without preventing the small file from being read
+	operator.poll(context.Background())
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForTokens(t, emitCalls, [][]byte{[]byte("large-000"), []byte("large-001"), []byte("small-000")})
+
+	operator.poll(context.Background())
+	waitForTokens(t, emitCalls, [][]byte{[]byte("large-002"), []byte("large-003")})
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("large-004"))
+
+	operator.poll(context.Background())
+	expectNoTokens(t, emitCalls)
+}
+
+func TestMaxBytesPerPollDeleteAfterRead(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(allowFileDeletion.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(allowFileDeletion.ID(), false))
+	}()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	cfg.MaxBytesPerPoll = 10
+	operator, emitCalls := buildTestManager(t, cfg)
+	operator.persister = testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "testlog-1\ntestlog-2\n")
+	require.NoError(t, temp.Close())
+
+	// The file isn't deleted until it is read entirely
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog-1"))
+	_, err := os.Stat(temp.Name())
+	require.NoError(t, err)
+
+	operator.poll(context.Background())
+	waitForToken(t, emitCalls, []byte("testlog-2"))
+	_, err = os.Stat(temp.Name())
+	require.True(t, os.IsNotExist(err))
+}
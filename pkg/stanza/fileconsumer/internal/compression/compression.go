@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compression // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// None means that the files are read as is
+	None = ""
+	// Gzip means that the files are decompressed with gzip
+	Gzip = "gzip"
+	// Zstd means that the files are decompressed with zstd
+	Zstd = "zstd"
+	// Auto means that the compression of each file is detected from its magic number
+	Auto = "auto"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Validate returns an error if the compression isn't supported
+func Validate(compression string) error {
+	switch compression {
+	case None, Gzip, Zstd, Auto:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s'", compression)
+	}
+}
+
+// Detect returns the compression of a file from its magic number, or None if it isn't compressed
+func Detect(file io.ReaderAt) (string, error) {
+	buf := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return None, fmt.Errorf("reading magic number: %w", err)
+	}
+	switch {
+	case bytes.HasPrefix(buf[:n], gzipMagic):
+		return Gzip, nil
+	case bytes.HasPrefix(buf[:n], zstdMagic):
+		return Zstd, nil
+	default:
+		return None, nil
+	}
+}
+
+// NewReader returns a reader decompressing the content of r.
+// A truncated stream, as read from a file still being written, ends with io.EOF rather than
+// io.ErrUnexpectedEOF so that its content is consumed the same way as a growing plain file.
+func NewReader(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case Gzip:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		return &truncatedReader{ReadCloser: gr}, nil
+	case Zstd:
+		zr, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return &truncatedReader{ReadCloser: zr.IOReadCloser()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression '%s'", compression)
+	}
+}
+
+type truncatedReader struct {
+	io.ReadCloser
+}
+
+func (r *truncatedReader) Read(dst []byte) (int, error) {
+	n, err := r.ReadCloser.Read(dst)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+	return n, err
+}
@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+)
+
+const content = "testlog1\ntestlog2\n"
+
+func gzipBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range []string{None, Gzip, Zstd, Auto} {
+		require.NoError(t, Validate(c))
+	}
+	require.Error(t, Validate("lz4"))
+}
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  []byte
+		expected string
+	}{
+		{"gzip", gzipBytes(t, content), Gzip},
+		{"zstd", zstdBytes(t, content), Zstd},
+		{"plain", []byte(content), None},
+		{"short", []byte{0x1f}, None},
+		{"empty", []byte{}, None},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			detected, err := Detect(bytes.NewReader(tc.content))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, detected)
+		})
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	cases := []struct {
+		name        string
+		compression string
+		content     []byte
+	}{
+		{"gzip", Gzip, gzipBytes(t, content)},
+		{"zstd", Zstd, zstdBytes(t, content)},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewReader(bytes.NewReader(tc.content), tc.compression)
+			require.NoError(t, err)
+			defer r.Close()
+
+			decompressed, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, content, string(decompressed))
+		})
+	}
+
+	_, err := NewReader(bytes.NewReader([]byte(content)), Gzip)
+	require.Error(t, err)
+
+	_, err = NewReader(bytes.NewReader([]byte(content)), None)
+	require.Error(t, err)
+}
+
+func TestNewReaderTruncated(t *testing.T) {
+	cases := []struct {
+		name        string
+		compression string
+		content     []byte
+	}{
+		{"gzip", Gzip, gzipBytes(t, content)},
+		{"zstd", Zstd, zstdBytes(t, content)},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// Drop the end of the stream, as if it was still being written
+			r, err := NewReader(bytes.NewReader(tc.content[:len(tc.content)-4]), tc.compression)
+			require.NoError(t, err)
+			defer r.Close()
+
+			decompressed, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix([]byte(content), decompressed))
+		})
+	}
+}
+
+// growingFile is a file whose content is written progressively
+type growingFile struct {
+	content []byte
+	size    int
+	// read is the count of bytes read from the file
+	read int
+}
+
+func (f *growingFile) ReadAt(dst []byte, off int64) (int, error) {
+	if off >= int64(f.size) {
+		return 0, io.EOF
+	}
+	n := copy(dst, f.content[off:f.size])
+	f.read += n
+	if n < len(dst) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readAvailable reads the content of the stream written so far, as a reader does in a poll cycle
+func readAvailable(t *testing.T, s *Stream, offset int64) []byte {
+	require.NoError(t, s.Seek(offset))
+	var read []byte
+	buf := make([]byte, 7)
+	for {
+		n, err := s.Read(buf)
+		read = append(read, buf[:n]...)
+		s.Release(offset + int64(len(read)))
+		if err == io.EOF {
+			return read
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestStream(t *testing.T) {
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("testlog%d\n", i)
+	}
+
+	// The files are written member by member, or frame by frame, by appending compressed lines
+	var gzipMembers, zstdFrames []byte
+	for _, line := range lines {
+		gzipMembers = append(gzipMembers, gzipBytes(t, line)...)
+		zstdFrames = append(zstdFrames, zstdBytes(t, line)...)
+	}
+	// The files are written as a single stream flushed after each line
+	var gzipFlushed, zstdFlushed bytes.Buffer
+	gw := gzip.NewWriter(&gzipFlushed)
+	zw, err := zstd.NewWriter(&zstdFlushed)
+	require.NoError(t, err)
+	for _, line := range lines {
+		_, err = gw.Write([]byte(line))
+		require.NoError(t, err)
+		require.NoError(t, gw.Flush())
+		_, err = zw.Write([]byte(line))
+		require.NoError(t, err)
+		require.NoError(t, zw.Flush())
+	}
+	require.NoError(t, gw.Close())
+	require.NoError(t, zw.Close())
+
+	expected := strings.Join(lines, "")
+	cases := []struct {
+		name        string
+		compression string
+		content     []byte
+	}{
+		{"gzip_members", Gzip, gzipMembers},
+		{"zstd_frames", Zstd, zstdFrames},
+		{"gzip_flushed", Gzip, gzipFlushed.Bytes()},
+		{"zstd_flushed", Zstd, zstdFlushed.Bytes()},
+	}
+	for _, tc := range cases {
+		tc := tc
+		for _, step := range []int{1, 5, 13, 64} {
+			step := step
+			t.Run(fmt.Sprintf("%s_%d", tc.name, step), func(t *testing.T) {
+				file := &growingFile{content: tc.content}
+				s, err := NewStream(file, tc.compression)
+				require.NoError(t, err)
+				defer s.Close()
+
+				var read []byte
+				for file.size < len(tc.content) {
+					file.size += step
+					if file.size > len(tc.content) {
+						file.size = len(tc.content)
+					}
+					read = append(read, readAvailable(t, s, int64(len(read)))...)
+					require.True(t, strings.HasPrefix(expected, string(read)))
+				}
+				require.Equal(t, expected, string(read))
+				// The content of the file is decompressed once
+				require.Equal(t, len(tc.content), file.read)
+			})
+		}
+	}
+}
+
+func TestStreamSeek(t *testing.T) {
+	file := &growingFile{content: gzipBytes(t, content)}
+	file.size = len(file.content)
+	s, err := NewStream(file, Gzip)
+	require.NoError(t, err)
+	defer s.Close()
+
+	// Seeking to the end returns the size of the decompressed content
+	end, err := s.SeekEnd()
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), end)
+
+	// The released content is decompressed again
+	require.Equal(t, content[9:], string(readAvailable(t, s, 9)))
+
+	// The content read is kept until it is released
+	require.NoError(t, s.Seek(3))
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(s, buf)
+	require.NoError(t, err)
+	require.Equal(t, content[3:7], string(buf))
+	require.Equal(t, content[5:], string(readAvailable(t, s, 5)))
+
+	require.False(t, s.Truncated(int64(len(file.content))))
+	require.True(t, s.Truncated(4))
+}
+
+func TestStreamInvalid(t *testing.T) {
+	file := &growingFile{content: []byte(content)}
+	file.size = len(file.content)
+	s, err := NewStream(file, Gzip)
+	require.NoError(t, err)
+	defer s.Close()
+
+	_, err = s.Read(make([]byte, 8))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, io.EOF)
+
+	_, err = NewStream(file, None)
+	require.Error(t, err)
+}
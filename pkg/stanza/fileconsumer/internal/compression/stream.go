@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package compression // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	// errStarved is reported by the decoding goroutine when the content written so far is decompressed
+	errStarved = errors.New("end of the content written so far")
+	// errStreamClosed stops the decoding goroutine
+	errStreamClosed = errors.New("stream closed")
+)
+
+// decoder is a decompressor which starts decompressing once reset
+type decoder interface {
+	io.Reader
+	Reset(r io.Reader) error
+}
+
+// gzipDecoder decompresses the members of a gzip file one by one, so that the content of a member
+// is returned before the header of the next one is read, which may not be written yet
+type gzipDecoder struct {
+	gzip.Reader
+	r     io.Reader
+	ended bool
+}
+
+func (d *gzipDecoder) Reset(r io.Reader) error {
+	d.r, d.ended = r, false
+	if err := d.Reader.Reset(r); err != nil {
+		return err
+	}
+	d.Reader.Multistream(false)
+	return nil
+}
+
+func (d *gzipDecoder) Read(dst []byte) (int, error) {
+	for {
+		if d.ended {
+			if err := d.Reset(d.r); err != nil {
+				return 0, err
+			}
+		}
+		n, err := d.Reader.Read(dst)
+		if errors.Is(err, io.EOF) {
+			d.ended, err = true, nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+type result struct {
+	data []byte
+	err  error
+}
+
+// source reads a file from a position, regardless of the offset of the file, so that the file can be
+// replaced by another handle of the same file. At the end of the file, it waits for the next request
+// to read further rather than returning io.EOF, which the decoders can't resume from.
+type source struct {
+	file     io.ReaderAt
+	pos      int64
+	requests chan struct{}
+	results  chan result
+}
+
+func (s *source) Read(dst []byte) (int, error) {
+	for {
+		n, err := s.file.ReadAt(dst, s.pos)
+		s.pos += int64(n)
+		if n > 0 {
+			return n, nil
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		s.results <- result{err: errStarved}
+		if _, ok := <-s.requests; !ok {
+			return 0, errStreamClosed
+		}
+	}
+}
+
+// Stream decompresses a file which may still be written. The decompressor is kept between reads, waiting
+// for more content at the end of the file, so that the content written since the previous read is
+// decompressed from where it stopped rather than from the start of the file.
+//
+// The decompressed content read since the last call to Release is kept, so that it can be read again
+// after a Seek. Seeking before it decompresses the file again from its start.
+type Stream struct {
+	compression string
+	src         *source
+	br          *bufio.Reader
+	done        chan struct{}
+	// err is the error which stopped the decompression
+	err error
+	// decoded is the offset in the decompressed content up to which the decompressed content was received
+	decoded int64
+	// buf holds the decompressed content from offset base, and pos is the read position in it.
+	// The content before base is discarded.
+	base int64
+	buf  []byte
+	pos  int
+}
+
+// NewStream returns a stream decompressing a file from its start
+func NewStream(file io.ReaderAt, compression string) (*Stream, error) {
+	s := &Stream{compression: compression}
+	if err := s.start(file); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// start starts the goroutine decompressing the file from its start
+func (s *Stream) start(file io.ReaderAt) error {
+	var dec decoder
+	closeDec := func() {}
+	switch s.compression {
+	case Gzip:
+		dec = &gzipDecoder{}
+	case Zstd:
+		zr, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return fmt.Errorf("zstd: %w", err)
+		}
+		dec, closeDec = zr, zr.Close
+	default:
+		return fmt.Errorf("unsupported compression '%s'", s.compression)
+	}
+
+	s.src = &source{
+		file:     file,
+		requests: make(chan struct{}),
+		// The last result of the goroutine, sent when the stream is closed, isn't received
+		results: make(chan result, 1),
+	}
+	s.br = bufio.NewReader(s.src)
+	s.done = make(chan struct{})
+	s.err = nil
+	s.decoded = 0
+	go decode(dec, closeDec, s.src, s.br, s.done)
+	return nil
+}
+
+// decode decompresses the file into results, each time a request is received
+func decode(dec decoder, closeDec func(), src *source, br *bufio.Reader, done chan struct{}) {
+	defer close(done)
+	defer closeDec()
+	if _, ok := <-src.requests; !ok {
+		return
+	}
+	if err := dec.Reset(br); err != nil {
+		src.results <- result{err: err}
+		return
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := dec.Read(buf)
+		src.results <- result{data: buf[:n], err: err}
+		if err != nil {
+			return
+		}
+		if _, ok := <-src.requests; !ok {
+			return
+		}
+	}
+}
+
+// stop stops the decompression goroutine
+func (s *Stream) stop() {
+	close(s.src.requests)
+	<-s.done
+}
+
+// SetFile replaces the file the stream reads from by another handle of the same file
+func (s *Stream) SetFile(file io.ReaderAt) {
+	s.src.file = file
+}
+
+// Truncated reports whether the file is smaller than the part of it already decompressed
+func (s *Stream) Truncated(size int64) bool {
+	return size < s.src.pos-int64(s.br.Buffered())
+}
+
+// Read reads the decompressed content from the read position. It returns io.EOF when the
+// content written so far is read.
+func (s *Stream) Read(dst []byte) (int, error) {
+	for s.pos >= len(s.buf) {
+		if err := s.fill(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(dst, s.buf[s.pos:])
+	s.pos += n
+	return n, nil
+}
+
+// fill receives the next decompressed content, and returns io.EOF if there is none for now
+func (s *Stream) fill() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.src.requests <- struct{}{}
+	res := <-s.src.results
+	if errors.Is(res.err, errStarved) {
+		return io.EOF
+	}
+	// Discard the content before base
+	if skip := s.base - s.decoded; skip < int64(len(res.data)) {
+		s.buf = append(s.buf, res.data[max64(skip, 0):]...)
+	}
+	s.decoded += int64(len(res.data))
+	if res.err != nil {
+		s.err = res.err
+		if len(res.data) == 0 {
+			return s.err
+		}
+	}
+	return nil
+}
+
+// Release discards the decompressed content before offset, it won't be read again
+func (s *Stream) Release(offset int64) {
+	n := offset - s.base
+	if n <= 0 || n > int64(s.pos) {
+		return
+	}
+	s.buf = s.buf[:copy(s.buf, s.buf[n:])]
+	s.pos -= int(n)
+	s.base = offset
+}
+
+// Seek moves the read position to an offset of the decompressed content and releases the content before it
+func (s *Stream) Seek(offset int64) error {
+	switch {
+	case offset < s.base:
+		// The content was released, decompress the file again from its start
+		s.stop()
+		if err := s.start(s.src.file); err != nil {
+			return err
+		}
+	case offset <= s.base+int64(len(s.buf)):
+		s.pos = int(offset - s.base)
+		s.Release(offset)
+		return nil
+	}
+	s.base, s.buf, s.pos = offset, s.buf[:0], 0
+	return nil
+}
+
+// SeekEnd moves the read position to the end of the content written so far, and returns its offset
+func (s *Stream) SeekEnd() (int64, error) {
+	for {
+		s.base, s.buf, s.pos = s.decoded, s.buf[:0], 0
+		if err := s.fill(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return s.base, nil
+			}
+			return s.base, err
+		}
+	}
+}
+
+// Close stops the decompression
+func (s *Stream) Close() {
+	s.stop()
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
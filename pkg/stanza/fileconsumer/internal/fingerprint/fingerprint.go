@@ -37,6 +37,23 @@ func New(file *os.File, size int) (*Fingerprint, error) {
 	return fp, nil
 }
 
+// NewFromReader creates a new fingerprint from the first bytes read from a stream,
+// such as the decompressed content of a file
+func NewFromReader(r io.Reader, size int) (*Fingerprint, error) {
+	buf := make([]byte, size)
+
+	n, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	fp := &Fingerprint{
+		FirstBytes: buf[:n],
+	}
+
+	return fp, nil
+}
+
 // Copy creates a new copy of the fingerprint
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.FirstBytes), cap(f.FirstBytes))
@@ -4,6 +4,7 @@
 package fingerprint
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"os"
@@ -133,6 +134,53 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewFromReader(t *testing.T) {
+	cases := []struct {
+		name            string
+		fingerprintSize int
+		contentSize     int
+		expectedLen     int
+	}{
+		{
+			name:            "exactSize",
+			fingerprintSize: MinSize,
+			contentSize:     MinSize,
+			expectedLen:     MinSize,
+		},
+		{
+			name:            "smallerContent",
+			fingerprintSize: MinSize,
+			contentSize:     MinSize / 2,
+			expectedLen:     MinSize / 2,
+		},
+		{
+			name:            "largerContent",
+			fingerprintSize: MinSize,
+			contentSize:     DefaultSize,
+			expectedLen:     MinSize,
+		},
+		{
+			name:            "emptyContent",
+			fingerprintSize: MinSize,
+			contentSize:     0,
+			expectedLen:     0,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			content := tokenWithLength(tc.contentSize)
+			fp, err := NewFromReader(bytes.NewReader(content), tc.fingerprintSize)
+			require.NoError(t, err)
+
+			require.Equal(t, content[:tc.expectedLen], fp.FirstBytes)
+		})
+	}
+}
+
 func TestFingerprintCopy(t *testing.T) {
 	t.Parallel()
 	cases := []string{
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filter // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher/internal/filter"
+
+import (
+	"os"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+type excludeOlderThanOption struct {
+	age time.Duration
+}
+
+// ExcludeOlderThan excludes the files which were last modified longer ago than the given age.
+func ExcludeOlderThan(age time.Duration) Option {
+	return excludeOlderThanOption{age: age}
+}
+
+func (o excludeOlderThanOption) apply(items []*item) ([]*item, error) {
+	var errs error
+	filtered := make([]*item, 0, len(items))
+	for _, it := range items {
+		info, err := os.Stat(it.value)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		if time.Since(info.ModTime()) <= o.age {
+			filtered = append(filtered, it)
+		}
+	}
+	return filtered, errs
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcludeOlderThan(t *testing.T) {
+	tempDir := t.TempDir()
+	now := time.Now()
+
+	ages := map[string]time.Duration{
+		"new.log":    0,
+		"recent.log": 30 * time.Minute,
+		"old.log":    2 * time.Hour,
+	}
+	items := make([]*item, 0, len(ages)+1)
+	for name, age := range ages {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(name), 0600))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+		items = append(items, &item{value: path})
+	}
+	items = append(items, &item{value: filepath.Join(tempDir, "missing.log")})
+
+	filtered, err := ExcludeOlderThan(time.Hour).apply(items)
+	assert.Error(t, err)
+
+	values := make([]string, 0, len(filtered))
+	for _, it := range filtered {
+		values = append(values, filepath.Base(it.value))
+	}
+	assert.ElementsMatch(t, []string{"new.log", "recent.log"}, values)
+}
@@ -49,6 +49,10 @@ type item struct {
 }
 
 func newItem(value string, regex *regexp.Regexp) (*item, error) {
+	if regex == nil {
+		return &item{value: value}, nil
+	}
+
 	match := regex.FindStringSubmatch(value)
 	if match == nil {
 		return nil, fmt.Errorf("'%s' does not match regex", value)
@@ -6,6 +6,7 @@ package matcher // import "github.com/open-telemetry/opentelemetry-collector-con
 import (
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher/internal/filter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/matcher/internal/finder"
@@ -20,6 +21,7 @@ const (
 type Criteria struct {
 	Include          []string         `mapstructure:"include,omitempty"`
 	Exclude          []string         `mapstructure:"exclude,omitempty"`
+	ExcludeOlderThan time.Duration    `mapstructure:"exclude_older_than,omitempty"`
 	OrderingCriteria OrderingCriteria `mapstructure:"ordering_criteria,omitempty"`
 }
 
@@ -50,10 +52,20 @@ func New(c Criteria) (*Matcher, error) {
 		return nil, fmt.Errorf("exclude: %w", err)
 	}
 
+	if c.ExcludeOlderThan < 0 {
+		return nil, fmt.Errorf("'exclude_older_than' must not be negative")
+	}
+
+	var filterOpts []filter.Option
+	if c.ExcludeOlderThan != 0 {
+		filterOpts = append(filterOpts, filter.ExcludeOlderThan(c.ExcludeOlderThan))
+	}
+
 	if len(c.OrderingCriteria.SortBy) == 0 {
 		return &Matcher{
-			include: c.Include,
-			exclude: c.Exclude,
+			include:    c.Include,
+			exclude:    c.Exclude,
+			filterOpts: filterOpts,
 		}, nil
 	}
 
@@ -66,7 +78,6 @@ func New(c Criteria) (*Matcher, error) {
 		return nil, fmt.Errorf("compile regex: %w", err)
 	}
 
-	var filterOpts []filter.Option
 	for _, sc := range c.OrderingCriteria.SortBy {
 		switch sc.SortType {
 		case sortTypeNumeric:
@@ -97,6 +108,7 @@ func New(c Criteria) (*Matcher, error) {
 		exclude:    c.Exclude,
 		regex:      regex,
 		filterOpts: filterOpts,
+		sorted:     true,
 	}, nil
 }
 
@@ -105,6 +117,7 @@ type Matcher struct {
 	exclude    []string
 	regex      *regexp.Regexp
 	filterOpts []filter.Option
+	sorted     bool
 }
 
 // MatchFiles gets a list of paths given an array of glob patterns to include and exclude
@@ -118,7 +131,7 @@ func (m Matcher) MatchFiles() ([]string, error) {
 	}
 
 	result, err := filter.Filter(files, m.regex, m.filterOpts...)
-	if len(result) == 0 {
+	if len(result) == 0 || !m.sorted {
 		return result, err
 	}
 
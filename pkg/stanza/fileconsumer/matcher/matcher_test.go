@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,6 +38,21 @@ func TestNew(t *testing.T) {
 				Include: []string{"*.log", "*.txt"},
 			},
 		},
+		{
+			name: "ExcludeOlderThan",
+			criteria: Criteria{
+				Include:          []string{"*.log"},
+				ExcludeOlderThan: time.Hour,
+			},
+		},
+		{
+			name: "ExcludeOlderThanNegative",
+			criteria: Criteria{
+				Include:          []string{"*.log"},
+				ExcludeOlderThan: -time.Hour,
+			},
+			expectedErr: "'exclude_older_than' must not be negative",
+		},
 		{
 			name: "IncludeInvalidGlob",
 			criteria: Criteria{
@@ -600,3 +616,49 @@ func TestMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestMatcherExcludeOlderThan(t *testing.T) {
+	tempDir := t.TempDir()
+	now := time.Now()
+
+	ages := map[string]time.Duration{
+		"err.1.log": 0,
+		"err.2.log": 2 * time.Hour,
+		"err.3.log": 30 * time.Minute,
+		"err.4.log": 3 * time.Hour,
+	}
+	for name, age := range ages {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(name), 0600))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+
+	matcher, err := New(Criteria{
+		Include:          []string{filepath.Join(tempDir, "*.log")},
+		ExcludeOlderThan: time.Hour,
+	})
+	require.NoError(t, err)
+	files, err := matcher.MatchFiles()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(tempDir, "err.1.log"), filepath.Join(tempDir, "err.3.log")}, files)
+
+	// The files are excluded before being sorted
+	matcher, err = New(Criteria{
+		Include:          []string{filepath.Join(tempDir, "*.log")},
+		ExcludeOlderThan: time.Hour,
+		OrderingCriteria: OrderingCriteria{
+			Regex: `err\.(?P<value>\d+)\.log`,
+			SortBy: []Sort{
+				{
+					SortType:  sortTypeNumeric,
+					RegexKey:  "value",
+					Ascending: false,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	files, err = matcher.MatchFiles()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(tempDir, "err.3.log")}, files)
+}
@@ -8,11 +8,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/emit"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/scanner"
@@ -22,6 +24,7 @@ import (
 type readerConfig struct {
 	fingerprintSize         int
 	maxLogSize              int
+	maxBytesPerPoll         int64
 	emit                    emit.Callback
 	includeFileName         bool
 	includeFilePath         bool
@@ -34,6 +37,8 @@ type readerMetadata struct {
 	Offset          int64
 	FileAttributes  map[string]any
 	HeaderFinalized bool
+	// CompressedSize is the size of a compressed file when its content was last read to the end
+	CompressedSize int64
 }
 
 // reader manages a single file
@@ -42,6 +47,8 @@ type reader struct {
 	*readerConfig
 	*readerMetadata
 	file          *os.File
+	compression   string
+	stream        *compression.Stream
 	lineSplitFunc bufio.SplitFunc
 	splitFunc     bufio.SplitFunc
 	decoder       *helper.Decoder
@@ -53,6 +60,25 @@ type reader struct {
 
 // offsetToEnd sets the starting offset
 func (r *reader) offsetToEnd() error {
+	if r.compression != compression.None {
+		// The offset of a compressed file is the size of its decompressed content
+		size, err := r.compressedSize()
+		if err != nil {
+			return err
+		}
+		if r.stream == nil {
+			if r.stream, err = compression.NewStream(r.file, r.compression); err != nil {
+				return err
+			}
+		}
+		r.Offset, err = r.stream.SeekEnd()
+		if err != nil {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.CompressedSize = size
+		return nil
+	}
+
 	info, err := r.file.Stat()
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
@@ -61,9 +87,77 @@ func (r *reader) offsetToEnd() error {
 	return nil
 }
 
+// seek positions the file at the current offset. The offsets of compressed files are tracked
+// on their decompressed content, which can't be seeked: the stream of the reader keeps
+// decompressing the file from where the previous read stopped. The file is decompressed from
+// its start only when the reader has no stream yet.
+func (r *reader) seek() error {
+	if r.compression == compression.None {
+		_, err := r.file.Seek(r.Offset, 0)
+		return err
+	}
+
+	if r.stream == nil {
+		var err error
+		if r.stream, err = compression.NewStream(r.file, r.compression); err != nil {
+			return err
+		}
+	}
+	return r.stream.Seek(r.Offset)
+}
+
+// compressedSize returns the size of a compressed file, or 0 if the file is not compressed
+func (r *reader) compressedSize() (int64, error) {
+	if r.compression == compression.None {
+		return 0, nil
+	}
+	info, err := r.file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("stat: %w", err)
+	}
+	return info.Size(), nil
+}
+
+func (r *reader) closeStream() {
+	if r.stream == nil {
+		return
+	}
+	r.stream.Close()
+	r.stream = nil
+}
+
 // ReadToEnd will read until the end of the file
 func (r *reader) ReadToEnd(ctx context.Context) {
-	if _, err := r.file.Seek(r.Offset, 0); err != nil {
+	r.read(ctx, 0)
+}
+
+// ReadPoll will read until the end of the file, or until the read budget of a poll cycle
+// is spent. The rest of the file is left to the next poll cycles, so that a large file
+// doesn't delay the reading of the other files.
+func (r *reader) ReadPoll(ctx context.Context) {
+	r.read(ctx, r.maxBytesPerPoll)
+}
+
+// read reads until the end of the file, or until at least budget bytes are read if budget is positive
+func (r *reader) read(ctx context.Context, budget int64) {
+	start := r.Offset
+
+	// A compressed file that didn't change since it was read to the end is not read again
+	size, err := r.compressedSize()
+	if err != nil {
+		r.Errorw("Failed to get compressed size", zap.Error(err))
+		return
+	}
+	if size > 0 && size == r.CompressedSize {
+		r.eof = true
+		return
+	}
+	if r.stream != nil && r.stream.Truncated(size) {
+		// The decompressed content no longer matches the file, decompress it again from its start
+		r.closeStream()
+	}
+
+	if err := r.seek(); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
@@ -85,10 +179,18 @@ func (r *reader) ReadToEnd(ctx context.Context) {
 				// If Scan returned an error then we are not guaranteed to be at the end of the file
 				r.eof = false
 				r.Errorw("Failed during scan", zap.Error(err))
+			} else {
+				r.CompressedSize = size
 			}
 			break
 		}
 
+		if budget > 0 && r.Offset-start >= budget {
+			// The budget is spent, the token is left to the next poll cycle
+			r.eof = false
+			return
+		}
+
 		token, err := r.decoder.Decode(s.Bytes())
 		if err != nil {
 			r.Errorw("decode: %w", zap.Error(err))
@@ -102,7 +204,7 @@ func (r *reader) ReadToEnd(ctx context.Context) {
 				// could be split differently with the new splitter.
 				r.splitFunc = r.lineSplitFunc
 				r.processFunc = r.emit
-				if _, err = r.file.Seek(r.Offset, 0); err != nil {
+				if err = r.seek(); err != nil {
 					r.Errorw("Failed to seek post-header", zap.Error(err))
 					return
 				}
@@ -126,6 +228,7 @@ func (r *reader) finalizeHeader() {
 
 // Close will close the file
 func (r *reader) Close() {
+	r.closeStream()
 	if r.file != nil {
 		if err := r.file.Close(); err != nil {
 			r.Debugw("Problem closing reader", zap.Error(err))
@@ -141,12 +244,19 @@ func (r *reader) Close() {
 
 // Read from the file and update the fingerprint if necessary
 func (r *reader) Read(dst []byte) (int, error) {
+	var src io.Reader = r.file
+	if r.stream != nil {
+		// The content before the offset is emitted, the stream doesn't need to keep it
+		r.stream.Release(r.Offset)
+		src = r.stream
+	}
+
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return src.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := src.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -5,6 +5,8 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"bufio"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -12,6 +14,7 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/text/encoding"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/compression"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/header"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/splitter"
@@ -26,6 +29,7 @@ type readerFactory struct {
 	splitterFactory splitter.Factory
 	encoding        encoding.Encoding
 	headerConfig    *header.Config
+	compression     string
 }
 
 func (f *readerFactory) newReader(file *os.File, fp *fingerprint.Fingerprint) (*reader, error) {
@@ -46,9 +50,12 @@ func (f *readerFactory) newFromMetadata(m *readerMetadata) (*reader, error) {
 	}.build()
 }
 
-// copy creates a deep copy of a reader
+// copy creates a deep copy of a reader. The decompression stream of a compressed file is
+// handed over to the copy when the new file is the same file, so that the content written
+// since the previous poll is decompressed from where the old reader stopped. Where readers
+// are closed at the end of each poll, as on Windows, the copy decompresses the file again.
 func (f *readerFactory) copy(old *reader, newFile *os.File) (*reader, error) {
-	return readerBuilder{
+	r, err := readerBuilder{
 		readerFactory: f,
 		file:          newFile,
 		splitFunc:     old.lineSplitFunc,
@@ -57,12 +64,63 @@ func (f *readerFactory) copy(old *reader, newFile *os.File) (*reader, error) {
 			Offset:          old.Offset,
 			FileAttributes:  util.MapCopy(old.FileAttributes),
 			HeaderFinalized: old.HeaderFinalized,
+			CompressedSize:  old.CompressedSize,
 		},
 	}.build()
+	if err != nil {
+		return nil, err
+	}
+
+	if old.stream != nil && old.compression == r.compression && sameFile(old.file, newFile) {
+		old.stream.SetFile(newFile)
+		r.stream, old.stream = old.stream, nil
+	}
+	return r, nil
 }
 
+// sameFile reports whether two handles refer to the same file
+func sameFile(a, b *os.File) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	aInfo, err := a.Stat()
+	if err != nil {
+		return false
+	}
+	bInfo, err := b.Stat()
+	if err != nil {
+		return false
+	}
+	return os.SameFile(aInfo, bInfo)
+}
+
+// newFingerprint creates the fingerprint of a file. The fingerprint of a compressed file is
+// made of the first bytes of its decompressed content, so that it matches the fingerprint
+// of the file it was compressed from.
 func (f *readerFactory) newFingerprint(file *os.File) (*fingerprint.Fingerprint, error) {
-	return fingerprint.New(file, f.readerConfig.fingerprintSize)
+	fileCompression, err := f.fileCompression(file)
+	if err != nil {
+		return nil, err
+	}
+	if fileCompression == compression.None {
+		return fingerprint.New(file, f.readerConfig.fingerprintSize)
+	}
+
+	// Read through a section reader to leave the offset of the file untouched
+	dec, err := compression.NewReader(io.NewSectionReader(file, 0, math.MaxInt64), fileCompression)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	return fingerprint.NewFromReader(dec, f.readerConfig.fingerprintSize)
+}
+
+// fileCompression returns the compression of a file, detecting it if configured to do so
+func (f *readerFactory) fileCompression(file *os.File) (string, error) {
+	if f.compression != compression.Auto {
+		return f.compression, nil
+	}
+	return compression.Detect(file)
 }
 
 type readerBuilder struct {
@@ -107,6 +165,9 @@ func (b readerBuilder) build() (r *reader, err error) {
 
 	r.file = b.file
 	r.SugaredLogger = b.SugaredLogger.With("path", b.file.Name())
+	if r.compression, err = b.fileCompression(b.file); err != nil {
+		return nil, err
+	}
 
 	// Resolve file name and path attributes
 	resolved := b.file.Name()
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import "context"
+
+type droppedRecorderKey struct{}
+
+// ContextWithDroppedRecorder returns a context in which the entries dropped by the operator
+// processing them are reported to record. It is used by the pipeline to count the dropped entries.
+func ContextWithDroppedRecorder(ctx context.Context, record func(context.Context)) context.Context {
+	return context.WithValue(ctx, droppedRecorderKey{}, record)
+}
+
+// RecordDropped reports that the entry being processed with ctx was dropped on purpose,
+// e.g. by a filter or because of an error with the drop on_error mode.
+func RecordDropped(ctx context.Context) {
+	if record, ok := ctx.Value(droppedRecorderKey{}).(func(context.Context)); ok {
+		record(ctx)
+	}
+}
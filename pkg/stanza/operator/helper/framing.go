@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// FramingNone means that the messages are split according to the multiline configuration
+	FramingNone = ""
+	// FramingOctetCounting means that each message is prefixed by its length in decimal
+	// followed by a space, as described in RFC 6587
+	FramingOctetCounting = "octet_counting"
+	// FramingLengthPrefix means that each message is prefixed by its length as a 4-byte big-endian integer
+	FramingLengthPrefix = "length_prefix"
+	// FramingNUL means that the messages are delimited by NUL bytes
+	FramingNUL = "nul"
+
+	lengthPrefixSize = 4
+	// maxOctetCountDigits is the number of digits of the largest octet count accepted
+	maxOctetCountDigits = 10
+)
+
+var (
+	errInvalidFrame   = errors.New("invalid frame header")
+	errFrameTooLarge  = errors.New("frame exceeds max_log_size")
+	errTruncatedFrame = errors.New("truncated frame")
+)
+
+// ValidateFraming returns an error if the framing isn't supported
+func ValidateFraming(framing string) error {
+	switch framing {
+	case FramingNone, FramingOctetCounting, FramingLengthPrefix, FramingNUL:
+		return nil
+	default:
+		return fmt.Errorf("invalid value '%s' for parameter 'framing'", framing)
+	}
+}
+
+// NewFramingSplitFunc returns a split func extracting the messages of the frames of a stream.
+// The returned tokens don't include the frame headers nor the delimiters.
+// A frame larger than maxLogSize, headers included, cannot be read and fails the split.
+func NewFramingSplitFunc(framing string, maxLogSize int) (bufio.SplitFunc, error) {
+	switch framing {
+	case FramingOctetCounting:
+		return splitOctetCounting(maxLogSize), nil
+	case FramingLengthPrefix:
+		return splitLengthPrefix(maxLogSize), nil
+	case FramingNUL:
+		return splitNUL(maxLogSize), nil
+	default:
+		return nil, fmt.Errorf("unsupported framing '%s'", framing)
+	}
+}
+
+func splitOctetCounting(maxLogSize int) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+
+		space := bytes.IndexByte(data, ' ')
+		if space < 0 {
+			if len(data) > maxOctetCountDigits {
+				return 0, nil, errInvalidFrame
+			}
+			if atEOF {
+				return 0, nil, errTruncatedFrame
+			}
+			return 0, nil, nil
+		}
+		if space == 0 || space > maxOctetCountDigits || data[0] == '0' {
+			return 0, nil, errInvalidFrame
+		}
+		length, err := strconv.Atoi(string(data[:space]))
+		if err != nil {
+			return 0, nil, errInvalidFrame
+		}
+
+		return splitFrame(data, atEOF, space+1, length, maxLogSize)
+	}
+}
+
+func splitLengthPrefix(maxLogSize int) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if len(data) == 0 {
+			return 0, nil, nil
+		}
+		if len(data) < lengthPrefixSize {
+			if atEOF {
+				return 0, nil, errTruncatedFrame
+			}
+			return 0, nil, nil
+		}
+
+		length := binary.BigEndian.Uint32(data[:lengthPrefixSize])
+		if uint64(length) > uint64(maxLogSize) {
+			return 0, nil, errFrameTooLarge
+		}
+		return splitFrame(data, atEOF, lengthPrefixSize, int(length), maxLogSize)
+	}
+}
+
+// splitFrame returns the message of a frame once it has been fully read.
+// Empty messages are skipped.
+func splitFrame(data []byte, atEOF bool, headerSize int, length int, maxLogSize int) (advance int, token []byte, err error) {
+	frameSize := headerSize + length
+	if frameSize > maxLogSize {
+		return 0, nil, errFrameTooLarge
+	}
+	if len(data) < frameSize {
+		if atEOF {
+			return 0, nil, errTruncatedFrame
+		}
+		return 0, nil, nil
+	}
+	if length == 0 {
+		return frameSize, nil, nil
+	}
+	return frameSize, data[headerSize:frameSize], nil
+}
+
+func splitNUL(maxLogSize int) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if i := bytes.IndexByte(data, 0); i >= 0 && i <= maxLogSize {
+			if i == 0 {
+				// Skip the empty messages
+				return 1, nil, nil
+			}
+			return i + 1, data[:i], nil
+		}
+
+		if len(data) >= maxLogSize {
+			return maxLogSize, data[:maxLogSize], nil
+		}
+
+		// Flush the last message, which may not be followed by a delimiter
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/internal"
+)
+
+func lengthPrefixed(messages ...string) []byte {
+	var raw []byte
+	for _, message := range messages {
+		raw = binary.BigEndian.AppendUint32(raw, uint32(len(message)))
+		raw = append(raw, message...)
+	}
+	return raw
+}
+
+func TestValidateFraming(t *testing.T) {
+	for _, framing := range []string{FramingNone, FramingOctetCounting, FramingLengthPrefix, FramingNUL} {
+		require.NoError(t, ValidateFraming(framing))
+	}
+	require.EqualError(t, ValidateFraming("newline"), "invalid value 'newline' for parameter 'framing'")
+
+	_, err := NewFramingSplitFunc(FramingNone, 1024)
+	require.EqualError(t, err, "unsupported framing ''")
+}
+
+func TestFramingSplitFunc(t *testing.T) {
+	cases := []struct {
+		framing string
+		tests   []internal.TokenizerTestCase
+	}{
+		{
+			framing: FramingOctetCounting,
+			tests: []internal.TokenizerTestCase{
+				{
+					Name:              "OneMessage",
+					Raw:               []byte("10 my message"),
+					ExpectedTokenized: []string{"my message"},
+				},
+				{
+					Name:              "MultipleMessages",
+					Raw:               []byte("4 abcd2 ef13 line1\nline2\n\x00"),
+					ExpectedTokenized: []string{"abcd", "ef", "line1\nline2\n\x00"},
+				},
+				{
+					Name:              "EmptyMessage",
+					Raw:               []byte("0 3 abc"),
+					ExpectedError:     errInvalidFrame,
+					ExpectedTokenized: nil,
+				},
+				{
+					Name:              "InvalidLength",
+					Raw:               []byte("3 abcx2 ab"),
+					ExpectedTokenized: []string{"abc"},
+					ExpectedError:     errInvalidFrame,
+				},
+				{
+					Name:          "NoHeader",
+					Raw:           []byte("this is not a frame"),
+					ExpectedError: errInvalidFrame,
+				},
+				{
+					Name:              "Truncated",
+					Raw:               []byte("3 abc10 def"),
+					ExpectedTokenized: []string{"abc"},
+					ExpectedError:     errTruncatedFrame,
+				},
+				{
+					Name:          "TooLarge",
+					Raw:           []byte("100 abc"),
+					ExpectedError: errFrameTooLarge,
+				},
+			},
+		},
+		{
+			framing: FramingLengthPrefix,
+			tests: []internal.TokenizerTestCase{
+				{
+					Name:              "OneMessage",
+					Raw:               lengthPrefixed("my message"),
+					ExpectedTokenized: []string{"my message"},
+				},
+				{
+					Name:              "MultipleMessages",
+					Raw:               lengthPrefixed("abcd", "", "binary\x00\n\x01"),
+					ExpectedTokenized: []string{"abcd", "binary\x00\n\x01"},
+				},
+				{
+					Name:              "TruncatedHeader",
+					Raw:               append(lengthPrefixed("abc"), 0x00, 0x00),
+					ExpectedTokenized: []string{"abc"},
+					ExpectedError:     errTruncatedFrame,
+				},
+				{
+					Name:          "TruncatedMessage",
+					Raw:           lengthPrefixed("abc")[:5],
+					ExpectedError: errTruncatedFrame,
+				},
+				{
+					Name:          "TooLarge",
+					Raw:           []byte{0xff, 0xff, 0xff, 0xff, 'a'},
+					ExpectedError: errFrameTooLarge,
+				},
+			},
+		},
+		{
+			framing: FramingNUL,
+			tests: []internal.TokenizerTestCase{
+				{
+					Name:              "OneMessage",
+					Raw:               []byte("my message\x00"),
+					ExpectedTokenized: []string{"my message"},
+				},
+				{
+					Name:              "MultipleMessages",
+					Raw:               []byte("line1\nline2\x00\x00abc\x00last"),
+					ExpectedTokenized: []string{"line1\nline2", "abc", "last"},
+				},
+				{
+					Name:              "TooLarge",
+					Raw:               append(internal.GeneratedByteSliceOfLength(70), 0x00),
+					ExpectedTokenized: []string{string(internal.GeneratedByteSliceOfLength(64)), "mnopqr"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.framing, func(t *testing.T) {
+			for _, test := range tc.tests {
+				splitFunc, err := NewFramingSplitFunc(tc.framing, 64)
+				require.NoError(t, err)
+				t.Run(test.Name, test.RunFunc(splitFunc))
+			}
+		})
+	}
+}
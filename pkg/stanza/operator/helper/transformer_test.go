@@ -75,7 +75,8 @@ func TestTransformerDropOnError(t *testing.T) {
 			OutputIDs:       []string{"test-output"},
 		},
 	}
-	ctx := context.Background()
+	dropped := 0
+	ctx := ContextWithDroppedRecorder(context.Background(), func(context.Context) { dropped++ })
 	testEntry := entry.New()
 	transform := func(e *entry.Entry) error {
 		return fmt.Errorf("Failure")
@@ -84,6 +85,7 @@ func TestTransformerDropOnError(t *testing.T) {
 	err := transformer.ProcessWith(ctx, testEntry, transform)
 	require.Error(t, err)
 	output.AssertNotCalled(t, "Process", mock.Anything, mock.Anything)
+	require.Equal(t, 1, dropped)
 }
 
 func TestTransformerSendOnError(t *testing.T) {
@@ -75,6 +75,7 @@ type BaseConfig struct {
 	Multiline                   helper.MultilineConfig      `mapstructure:"multiline,omitempty"`
 	PreserveLeadingWhitespaces  bool                        `mapstructure:"preserve_leading_whitespaces,omitempty"`
 	PreserveTrailingWhitespaces bool                        `mapstructure:"preserve_trailing_whitespaces,omitempty"`
+	Framing                     string                      `mapstructure:"framing,omitempty"`
 	MultiLineBuilder            MultiLineBuilderFunc
 }
 
@@ -118,12 +119,25 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	if err = helper.ValidateFraming(c.Framing); err != nil {
+		return nil, err
+	}
+
+	if c.Framing != helper.FramingNone && c.OneLogPerPacket {
+		return nil, fmt.Errorf("only one of 'framing' and 'one_log_per_packet' can be set")
+	}
+
 	if c.MultiLineBuilder == nil {
 		c.MultiLineBuilder = c.defaultMultilineBuilder
 	}
 
-	// Build multiline
-	splitFunc, err := c.MultiLineBuilder(enc)
+	var splitFunc bufio.SplitFunc
+	if c.Framing != helper.FramingNone {
+		splitFunc, err = helper.NewFramingSplitFunc(c.Framing, int(c.MaxLogSize))
+	} else {
+		// Build multiline
+		splitFunc, err = c.MultiLineBuilder(enc)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -74,9 +75,14 @@ zv9WEy+9p05Aet+12x3dzRu93+yRIEYbSZ35NOUWfQ+gspF5rGgpxA==
 -----END CERTIFICATE-----`
 
 func tcpInputTest(input []byte, expected []string) func(t *testing.T) {
+	return tcpInputConfigTest(func(*Config) {}, input, expected)
+}
+
+func tcpInputConfigTest(configure func(*Config), input []byte, expected []string) func(t *testing.T) {
 	return func(t *testing.T) {
 		cfg := NewConfigWithID("test_id")
 		cfg.ListenAddress = ":0"
+		configure(cfg)
 
 		op, err := cfg.Build(testutil.Logger(t))
 		require.NoError(t, err)
@@ -319,6 +325,27 @@ func TestBuild(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"framing-invalid",
+			Config{
+				BaseConfig: BaseConfig{
+					ListenAddress: "10.0.0.1:9000",
+					Framing:       "newline",
+				},
+			},
+			true,
+		},
+		{
+			"framing-with-one-log-per-packet",
+			Config{
+				BaseConfig: BaseConfig{
+					ListenAddress:   "10.0.0.1:9000",
+					Framing:         helper.FramingNUL,
+					OneLogPerPacket: true,
+				},
+			},
+			true,
+		},
 		{
 			"tls-enabled-with-no-such-file-error",
 			Config{
@@ -343,6 +370,8 @@ func TestBuild(t *testing.T) {
 			cfg.ListenAddress = tc.inputBody.ListenAddress
 			cfg.MaxLogSize = tc.inputBody.MaxLogSize
 			cfg.TLS = tc.inputBody.TLS
+			cfg.Framing = tc.inputBody.Framing
+			cfg.OneLogPerPacket = tc.inputBody.OneLogPerPacket
 			_, err := cfg.Build(testutil.Logger(t))
 			if tc.expectErr {
 				require.Error(t, err)
@@ -358,6 +387,21 @@ func TestTCPInput(t *testing.T) {
 	t.Run("CarriageReturn", tcpInputTest([]byte("message\r\n"), []string{"message"}))
 }
 
+func TestTCPInputFraming(t *testing.T) {
+	framing := func(framing string) func(*Config) {
+		return func(cfg *Config) {
+			cfg.Framing = framing
+		}
+	}
+
+	t.Run("OctetCounting", tcpInputConfigTest(framing(helper.FramingOctetCounting),
+		[]byte("11 message\none10 messagetwo"), []string{"message\none", "messagetwo"}))
+	t.Run("LengthPrefix", tcpInputConfigTest(framing(helper.FramingLengthPrefix),
+		[]byte("\x00\x00\x00\x0bmessage\none\x00\x00\x00\x0amessagetwo"), []string{"message\none", "messagetwo"}))
+	t.Run("NUL", tcpInputConfigTest(framing(helper.FramingNUL),
+		[]byte("message\none\x00messagetwo\x00"), []string{"message\none", "messagetwo"}))
+}
+
 func TestTCPInputAattributes(t *testing.T) {
 	t.Run("Simple", tcpInputAttributesTest([]byte("message\n"), []string{"message"}))
 	t.Run("CarriageReturn", tcpInputAttributesTest([]byte("message\r\n"), []string{"message"}))
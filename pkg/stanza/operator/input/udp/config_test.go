@@ -34,6 +34,16 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "framing",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.ListenAddress = "10.0.0.1:9000"
+					cfg.Framing = helper.FramingLengthPrefix
+					return cfg
+				}(),
+			},
 		},
 	}.Run(t)
 }
@@ -65,6 +65,7 @@ type BaseConfig struct {
 	Multiline                   helper.MultilineConfig `mapstructure:"multiline,omitempty"`
 	PreserveLeadingWhitespaces  bool                   `mapstructure:"preserve_leading_whitespaces,omitempty"`
 	PreserveTrailingWhitespaces bool                   `mapstructure:"preserve_trailing_whitespaces,omitempty"`
+	Framing                     string                 `mapstructure:"framing,omitempty"`
 }
 
 // Build will build a udp input operator.
@@ -88,8 +89,21 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
-	// Build multiline
-	splitFunc, err := c.Multiline.Build(enc, true, c.PreserveLeadingWhitespaces, c.PreserveTrailingWhitespaces, nil, MaxUDPSize)
+	if err = helper.ValidateFraming(c.Framing); err != nil {
+		return nil, err
+	}
+
+	if c.Framing != helper.FramingNone && c.OneLogPerPacket {
+		return nil, fmt.Errorf("only one of 'framing' and 'one_log_per_packet' can be set")
+	}
+
+	var splitFunc bufio.SplitFunc
+	if c.Framing != helper.FramingNone {
+		splitFunc, err = helper.NewFramingSplitFunc(c.Framing, MaxUDPSize)
+	} else {
+		// Build multiline
+		splitFunc, err = c.Multiline.Build(enc, true, c.PreserveLeadingWhitespaces, c.PreserveTrailingWhitespaces, nil, MaxUDPSize)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +122,7 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		splitFunc:       splitFunc,
 		resolver:        resolver,
 		OneLogPerPacket: c.OneLogPerPacket,
+		framed:          c.Framing != helper.FramingNone,
 	}
 	return udpInput, nil
 }
@@ -119,6 +134,7 @@ type Input struct {
 	address         *net.UDPAddr
 	addAttributes   bool
 	OneLogPerPacket bool
+	framed          bool
 
 	connection net.PacketConn
 	cancel     context.CancelFunc
@@ -238,8 +254,10 @@ func (u *Input) readMessage() ([]byte, net.Addr, error) {
 		return nil, nil, err
 	}
 
-	// Remove trailing characters and NULs
-	for ; (n > 0) && (u.buffer[n-1] < 32); n-- { // nolint
+	// Remove trailing characters and NULs, unless they belong to framed messages
+	if !u.framed {
+		for ; (n > 0) && (u.buffer[n-1] < 32); n-- { // nolint
+		}
 	}
 
 	return u.buffer[:n], addr, nil
@@ -15,13 +15,19 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
 func udpInputTest(input []byte, expected []string) func(t *testing.T) {
+	return udpInputConfigTest(func(*Config) {}, input, expected)
+}
+
+func udpInputConfigTest(configure func(*Config), input []byte, expected []string) func(t *testing.T) {
 	return func(t *testing.T) {
 		cfg := NewConfigWithID("test_input")
 		cfg.ListenAddress = ":0"
+		configure(cfg)
 
 		op, err := cfg.Build(testutil.Logger(t))
 		require.NoError(t, err)
@@ -144,6 +150,34 @@ func TestInput(t *testing.T) {
 	t.Run("NewlineInMessage", udpInputTest([]byte("message1\nmessage2\n"), []string{"message1\nmessage2"}))
 }
 
+func TestInputFraming(t *testing.T) {
+	framing := func(framing string) func(*Config) {
+		return func(cfg *Config) {
+			cfg.Framing = framing
+		}
+	}
+
+	t.Run("OctetCounting", udpInputConfigTest(framing(helper.FramingOctetCounting),
+		[]byte("9 message1\n8 message2"), []string{"message1\n", "message2"}))
+	t.Run("LengthPrefix", udpInputConfigTest(framing(helper.FramingLengthPrefix),
+		[]byte("\x00\x00\x00\x09message1\n\x00\x00\x00\x02\x00\x01"), []string{"message1\n", "\x00\x01"}))
+	t.Run("NUL", udpInputConfigTest(framing(helper.FramingNUL),
+		[]byte("message1\n\x00message2\x00"), []string{"message1\n", "message2"}))
+}
+
+func TestBuildFraming(t *testing.T) {
+	cfg := NewConfigWithID("test_input")
+	cfg.ListenAddress = ":0"
+	cfg.Framing = "newline"
+	_, err := cfg.Build(testutil.Logger(t))
+	require.EqualError(t, err, "invalid value 'newline' for parameter 'framing'")
+
+	cfg.Framing = helper.FramingNUL
+	cfg.OneLogPerPacket = true
+	_, err = cfg.Build(testutil.Logger(t))
+	require.EqualError(t, err, "only one of 'framing' and 'one_log_per_packet' can be set")
+}
+
 func TestInputAttributes(t *testing.T) {
 	t.Run("Simple", udpInputAttributesTest([]byte("message1"), []string{"message1"}))
 	t.Run("TrailingNewlines", udpInputAttributesTest([]byte("message1\n"), []string{"message1"}))
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unix
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:      "default",
+				ExpectErr: false,
+				Expect:    NewConfig(),
+			},
+			{
+				Name:      "all",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.SocketPath = "/var/run/app.sock"
+					cfg.SocketType = SocketTypeDatagram
+					cfg.MaxLogSize = 1000000
+					cfg.AddAttributes = true
+					cfg.OneLogPerPacket = true
+					cfg.Encoding = "utf-16le"
+					cfg.Multiline = helper.NewMultilineConfig()
+					cfg.Multiline.LineStartPattern = "ABC"
+					return cfg
+				}(),
+			},
+			{
+				Name:      "framing",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.SocketPath = "/var/run/app.sock"
+					cfg.Framing = helper.FramingNUL
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,387 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unix // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/unix"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+	"go.uber.org/zap"
+	"golang.org/x/text/encoding"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "unix_input"
+
+	// SocketTypeStream accepts connections on a SOCK_STREAM socket
+	SocketTypeStream = "stream"
+	// SocketTypeDatagram reads the datagrams sent to a SOCK_DGRAM socket
+	SocketTypeDatagram = "datagram"
+
+	// minMaxLogSize is the minimal size which can be used for buffering
+	// unix socket input
+	minMaxLogSize = 64 * 1024
+
+	// DefaultMaxLogSize is the max buffer sized used
+	// if MaxLogSize is not set
+	DefaultMaxLogSize = 1024 * 1024
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new unix socket input config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new unix socket input config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		InputConfig: helper.NewInputConfig(operatorID, operatorType),
+		BaseConfig: BaseConfig{
+			SocketType: SocketTypeStream,
+			Multiline:  helper.NewMultilineConfig(),
+			Encoding:   "utf-8",
+		},
+	}
+}
+
+// Config is the configuration of a unix socket input operator.
+type Config struct {
+	helper.InputConfig `mapstructure:",squash"`
+	BaseConfig         `mapstructure:",squash"`
+}
+
+// BaseConfig is the detailed configuration of a unix socket input operator.
+type BaseConfig struct {
+	SocketPath                  string                 `mapstructure:"socket_path,omitempty"`
+	SocketType                  string                 `mapstructure:"socket_type,omitempty"`
+	MaxLogSize                  helper.ByteSize        `mapstructure:"max_log_size,omitempty"`
+	AddAttributes               bool                   `mapstructure:"add_attributes,omitempty"`
+	OneLogPerPacket             bool                   `mapstructure:"one_log_per_packet,omitempty"`
+	Encoding                    string                 `mapstructure:"encoding,omitempty"`
+	Multiline                   helper.MultilineConfig `mapstructure:"multiline,omitempty"`
+	PreserveLeadingWhitespaces  bool                   `mapstructure:"preserve_leading_whitespaces,omitempty"`
+	PreserveTrailingWhitespaces bool                   `mapstructure:"preserve_trailing_whitespaces,omitempty"`
+	Framing                     string                 `mapstructure:"framing,omitempty"`
+}
+
+// Build will build a unix socket input operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	inputOperator, err := c.InputConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// If MaxLogSize not set, set sane default
+	if c.MaxLogSize == 0 {
+		c.MaxLogSize = DefaultMaxLogSize
+	}
+
+	if c.MaxLogSize < minMaxLogSize {
+		return nil, fmt.Errorf("invalid value for parameter 'max_log_size', must be equal to or greater than %d bytes", minMaxLogSize)
+	}
+
+	if c.SocketPath == "" {
+		return nil, fmt.Errorf("missing required parameter 'socket_path'")
+	}
+
+	var network string
+	switch c.SocketType {
+	case SocketTypeStream:
+		network = "unix"
+	case SocketTypeDatagram:
+		network = "unixgram"
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'socket_type'", c.SocketType)
+	}
+
+	enc, err := helper.LookupEncoding(c.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = helper.ValidateFraming(c.Framing); err != nil {
+		return nil, err
+	}
+
+	if c.Framing != helper.FramingNone && c.OneLogPerPacket {
+		return nil, fmt.Errorf("only one of 'framing' and 'one_log_per_packet' can be set")
+	}
+
+	var splitFunc bufio.SplitFunc
+	if c.Framing != helper.FramingNone {
+		splitFunc, err = helper.NewFramingSplitFunc(c.Framing, int(c.MaxLogSize))
+	} else {
+		splitFunc, err = c.Multiline.Build(enc, true, c.PreserveLeadingWhitespaces, c.PreserveTrailingWhitespaces, nil, int(c.MaxLogSize))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Input{
+		InputOperator:   inputOperator,
+		path:            c.SocketPath,
+		network:         network,
+		maxLogSize:      int(c.MaxLogSize),
+		addAttributes:   c.AddAttributes,
+		oneLogPerPacket: c.OneLogPerPacket,
+		encoding:        enc,
+		splitFunc:       splitFunc,
+		backoff: backoff.Backoff{
+			Max: 3 * time.Second,
+		},
+	}, nil
+}
+
+// Input is an operator that listens for log entries on a unix domain socket.
+type Input struct {
+	helper.InputOperator
+	path            string
+	network         string
+	maxLogSize      int
+	addAttributes   bool
+	oneLogPerPacket bool
+
+	listener net.Listener
+	packet   net.PacketConn
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	backoff  backoff.Backoff
+
+	encoding  encoding.Encoding
+	splitFunc bufio.SplitFunc
+}
+
+// Start will start listening for log entries on the socket.
+func (u *Input) Start(_ operator.Persister) error {
+	if err := removeStaleSocket(u.path); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+
+	if u.network == "unixgram" {
+		conn, err := net.ListenPacket(u.network, u.path)
+		if err != nil {
+			return fmt.Errorf("failed to listen on socket: %w", err)
+		}
+		u.packet = conn
+		u.goHandlePackets(ctx)
+		return nil
+	}
+
+	listener, err := net.Listen(u.network, u.path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on socket: %w", err)
+	}
+	u.listener = listener
+	u.goListen(ctx)
+	return nil
+}
+
+// removeStaleSocket removes the socket file left by a previous run, which would prevent listening.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat socket_path: %w", err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("socket_path '%s' exists and is not a socket", path)
+	}
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	return nil
+}
+
+// goListen will listen for connections on a stream socket.
+func (u *Input) goListen(ctx context.Context) {
+	u.wg.Add(1)
+
+	go func() {
+		defer u.wg.Done()
+
+		for {
+			conn, err := u.listener.Accept()
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					u.Debugw("Listener accept error", zap.Error(err))
+					time.Sleep(u.backoff.Duration())
+					continue
+				}
+			}
+			u.backoff.Reset()
+
+			u.Debug("Received connection")
+			subctx, cancel := context.WithCancel(ctx)
+			u.goHandleClose(subctx, conn)
+			u.goHandleMessages(subctx, conn, cancel)
+		}
+	}()
+}
+
+// goHandleClose will wait for the context to finish before closing a connection.
+func (u *Input) goHandleClose(ctx context.Context, conn net.Conn) {
+	u.wg.Add(1)
+
+	go func() {
+		defer u.wg.Done()
+		<-ctx.Done()
+		u.Debug("Closing connection")
+		if err := conn.Close(); err != nil {
+			u.Errorf("Failed to close connection: %s", err)
+		}
+	}()
+}
+
+// goHandleMessages will handle messages from a stream connection.
+func (u *Input) goHandleMessages(ctx context.Context, conn net.Conn, cancel context.CancelFunc) {
+	u.wg.Add(1)
+
+	go func() {
+		defer u.wg.Done()
+		defer cancel()
+
+		decoder := helper.NewDecoder(u.encoding)
+
+		if u.oneLogPerPacket {
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, conn); err != nil {
+				u.Errorw("IO copy net connection buffer error", zap.Error(err))
+			}
+			u.handleMessage(ctx, decoder, truncateMaxLog(buf.Bytes(), u.maxLogSize))
+			return
+		}
+
+		u.scan(ctx, decoder, conn)
+	}()
+}
+
+// goHandlePackets will handle the datagrams received on a datagram socket.
+func (u *Input) goHandlePackets(ctx context.Context) {
+	u.wg.Add(1)
+
+	go func() {
+		defer u.wg.Done()
+
+		decoder := helper.NewDecoder(u.encoding)
+		buf := make([]byte, u.maxLogSize)
+		for {
+			n, _, err := u.packet.ReadFrom(buf)
+			if err != nil {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					u.Errorw("Failed reading messages", zap.Error(err))
+				}
+				break
+			}
+
+			if u.oneLogPerPacket {
+				u.handleMessage(ctx, decoder, truncateMaxLog(buf[:n], u.maxLogSize))
+				continue
+			}
+			u.scan(ctx, decoder, bytes.NewReader(buf[:n]))
+		}
+	}()
+}
+
+// scan splits the data read from r into messages
+func (u *Input) scan(ctx context.Context, decoder *helper.Decoder, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, u.maxLogSize), u.maxLogSize)
+	scanner.Split(u.splitFunc)
+
+	for scanner.Scan() {
+		u.handleMessage(ctx, decoder, scanner.Bytes())
+	}
+
+	if err := scanner.Err(); err != nil {
+		u.Errorw("Scanner error", zap.Error(err))
+	}
+}
+
+func (u *Input) handleMessage(ctx context.Context, decoder *helper.Decoder, log []byte) {
+	if len(log) == 0 {
+		return
+	}
+
+	decoded, err := decoder.Decode(log)
+	if err != nil {
+		u.Errorw("Failed to decode data", zap.Error(err))
+		return
+	}
+
+	entry, err := u.NewEntry(string(decoded))
+	if err != nil {
+		u.Errorw("Failed to create entry", zap.Error(err))
+		return
+	}
+
+	if u.addAttributes {
+		entry.AddAttribute("net.transport", "Unix")
+		entry.AddAttribute("net.sock.host.addr", u.path)
+	}
+
+	u.Write(ctx, entry)
+}
+
+func truncateMaxLog(data []byte, maxLogSize int) (token []byte) {
+	if len(data) >= maxLogSize {
+		return data[:maxLogSize]
+	}
+	return data
+}
+
+// Stop will stop listening for log entries on the socket.
+func (u *Input) Stop() error {
+	if u.cancel == nil {
+		return nil
+	}
+	u.cancel()
+
+	// Closing the listener of a stream socket removes the socket file
+	if u.listener != nil {
+		if err := u.listener.Close(); err != nil {
+			u.Errorf("failed to close unix socket: %s", err)
+		}
+	}
+	if u.packet != nil {
+		if err := u.packet.Close(); err != nil {
+			u.Errorf("failed to close unix socket: %s", err)
+		}
+		if err := os.Remove(u.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			u.Errorf("failed to remove unix socket: %s", err)
+		}
+	}
+
+	u.wg.Wait()
+	u.listener = nil
+	u.packet = nil
+	u.cancel = nil
+	return nil
+}
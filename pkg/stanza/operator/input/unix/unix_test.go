@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unix
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		expectedErr string
+	}{
+		{
+			name:      "default",
+			configure: func(*Config) {},
+		},
+		{
+			name: "missing_socket_path",
+			configure: func(cfg *Config) {
+				cfg.SocketPath = ""
+			},
+			expectedErr: "missing required parameter 'socket_path'",
+		},
+		{
+			name: "invalid_socket_type",
+			configure: func(cfg *Config) {
+				cfg.SocketType = "seqpacket"
+			},
+			expectedErr: "invalid value 'seqpacket' for parameter 'socket_type'",
+		},
+		{
+			name: "max_log_size_too_small",
+			configure: func(cfg *Config) {
+				cfg.MaxLogSize = 1024
+			},
+			expectedErr: "invalid value for parameter 'max_log_size'",
+		},
+		{
+			name: "invalid_framing",
+			configure: func(cfg *Config) {
+				cfg.Framing = "newline"
+			},
+			expectedErr: "invalid value 'newline' for parameter 'framing'",
+		},
+		{
+			name: "framing_with_one_log_per_packet",
+			configure: func(cfg *Config) {
+				cfg.Framing = helper.FramingNUL
+				cfg.OneLogPerPacket = true
+			},
+			expectedErr: "only one of 'framing' and 'one_log_per_packet' can be set",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test_id")
+			cfg.SocketPath = "/tmp/test.sock"
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func socketPath(t *testing.T) string {
+	// The path of a unix socket is limited to about a hundred bytes, which is
+	// easily exceeded by the name of a test inside the default temp directory
+	dir, err := os.MkdirTemp("", "unix")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "test.sock")
+}
+
+func startInput(t *testing.T, configure func(*Config)) (*Input, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test_id")
+	cfg.SocketPath = socketPath(t)
+	configure(cfg)
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	input := op.(*Input)
+	input.InputOperator.OutputOperators = []operator.Operator{fake}
+
+	require.NoError(t, input.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() {
+		require.NoError(t, input.Stop(), "expected to stop unix input operator without error")
+	})
+	return input, fake
+}
+
+func unixInputTest(configure func(*Config), input [][]byte, expected []string) func(t *testing.T) {
+	return func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Unix domain sockets aren't fully supported on Windows")
+		}
+
+		op, fake := startInput(t, configure)
+
+		conn, err := net.Dial(op.network, op.path)
+		require.NoError(t, err)
+		for _, data := range input {
+			_, err = conn.Write(data)
+			require.NoError(t, err)
+		}
+		// Closing the connection ends the messages sent as one log per connection
+		require.NoError(t, conn.Close())
+
+		for _, expectedBody := range expected {
+			fake.ExpectBody(t, expectedBody)
+		}
+		fake.ExpectNoEntry(t, 100*time.Millisecond)
+	}
+}
+
+func TestStreamInput(t *testing.T) {
+	t.Run("Simple", unixInputTest(func(*Config) {},
+		[][]byte{[]byte("message1\nmessage2\n")}, []string{"message1", "message2"}))
+	t.Run("CarriageReturn", unixInputTest(func(*Config) {},
+		[][]byte{[]byte("message1\r\n")}, []string{"message1"}))
+	t.Run("OneLogPerPacket", unixInputTest(func(cfg *Config) {
+		cfg.OneLogPerPacket = true
+	}, [][]byte{[]byte("message1\n"), []byte("message2\n")}, []string{"message1\nmessage2\n"}))
+	t.Run("LengthPrefix", unixInputTest(func(cfg *Config) {
+		cfg.Framing = helper.FramingLengthPrefix
+	}, [][]byte{[]byte("\x00\x00\x00\x09message1\n\x00\x00\x00"), []byte("\x08message2")}, []string{"message1\n", "message2"}))
+}
+
+func TestDatagramInput(t *testing.T) {
+	datagram := func(cfg *Config) {
+		cfg.SocketType = SocketTypeDatagram
+	}
+	t.Run("Simple", unixInputTest(datagram,
+		[][]byte{[]byte("message1\n"), []byte("message2\nmessage3\n")}, []string{"message1", "message2", "message3"}))
+	t.Run("OneLogPerPacket", unixInputTest(func(cfg *Config) {
+		datagram(cfg)
+		cfg.OneLogPerPacket = true
+	}, [][]byte{[]byte("message1\nmessage2"), []byte("message3")}, []string{"message1\nmessage2", "message3"}))
+	t.Run("OctetCounting", unixInputTest(func(cfg *Config) {
+		datagram(cfg)
+		cfg.Framing = helper.FramingOctetCounting
+	}, [][]byte{[]byte("9 message1\n8 message2")}, []string{"message1\n", "message2"}))
+}
+
+func TestInputAttributes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets aren't fully supported on Windows")
+	}
+
+	op, fake := startInput(t, func(cfg *Config) {
+		cfg.AddAttributes = true
+	})
+
+	conn, err := net.Dial("unix", op.path)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("message1\n"))
+	require.NoError(t, err)
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, map[string]interface{}{
+			"net.transport":      "Unix",
+			"net.sock.host.addr": op.path,
+		}, e.Attributes)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+}
+
+func TestSocketFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets aren't fully supported on Windows")
+	}
+
+	for _, socketType := range []string{SocketTypeStream, SocketTypeDatagram} {
+		socketType := socketType
+		t.Run(socketType, func(t *testing.T) {
+			path := socketPath(t)
+
+			// A socket left over by a process which didn't remove it
+			stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+			require.NoError(t, err)
+			stale.SetUnlinkOnClose(false)
+			require.NoError(t, stale.Close())
+			_, err = os.Stat(path)
+			require.NoError(t, err)
+
+			cfg := NewConfigWithID("test_id")
+			cfg.SocketPath = path
+			cfg.SocketType = socketType
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+			require.NoError(t, op.Stop())
+
+			_, err = os.Stat(path)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestNotASocket(t *testing.T) {
+	path := socketPath(t)
+	require.NoError(t, os.WriteFile(path, []byte("not a socket"), 0600))
+
+	cfg := NewConfigWithID("test_id")
+	cfg.SocketPath = path
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	require.ErrorContains(t, op.Start(testutil.NewMockPersister("test")), "exists and is not a socket")
+	require.NoError(t, op.Stop())
+}
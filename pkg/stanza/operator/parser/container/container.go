@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/container"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/errors"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+)
+
+const (
+	operatorType = "container"
+
+	formatAuto       = "auto"
+	formatDocker     = "docker"
+	formatCRIO       = "crio"
+	formatContainerd = "containerd"
+
+	logFilePathAttribute = "log.file.path"
+	iostreamAttribute    = "log.iostream"
+	logtagAttribute      = "logtag"
+
+	podNameAttribute       = "k8s.pod.name"
+	podUIDAttribute        = "k8s.pod.uid"
+	namespaceAttribute     = "k8s.namespace.name"
+	containerNameAttribute = "k8s.container.name"
+	restartCountAttribute  = "k8s.container.restart_count"
+
+	// partialLogtag is the logtag of the lines split by the container runtime. The logtag of
+	// the docker lines is derived from the trailing newline, written only at the end of a line.
+	partialLogtag = "P"
+	fullLogtag    = "F"
+)
+
+var (
+	// criRegexp matches the lines written by the CRI-O and containerd runtimes,
+	// e.g. `2023-06-22T10:00:00.123456789Z stdout F message`
+	criRegexp = regexp.MustCompile(`^(?P<time>[^ ]+) (?P<stream>stdout|stderr) (?P<logtag>[^ ]*) ?(?P<log>.*)$`)
+
+	// podLogPathRegexp matches the paths of the logs of the Kubernetes pods, e.g.
+	// `/var/log/pods/<namespace>_<pod_name>_<pod_uid>/<container_name>/<restart_count>.log`
+	podLogPathRegexp = regexp.MustCompile(`^.*\/(?P<namespace>[^_]+)_(?P<pod_name>[^_]+)_(?P<uid>[a-f0-9\-]+)\/(?P<container_name>[^\._]+)\/(?P<restart_count>\d+)\.log$`)
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new container parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new container parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig:       helper.NewTransformerConfig(operatorID, operatorType),
+		ParseFrom:               entry.NewBodyField(),
+		Format:                  formatAuto,
+		AddMetadataFromFilePath: true,
+	}
+}
+
+// Config is the configuration of a container parser operator.
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+
+	ParseFrom               entry.Field     `mapstructure:"parse_from"`
+	Format                  string          `mapstructure:"format"`
+	AddMetadataFromFilePath bool            `mapstructure:"add_metadata_from_file_path"`
+	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+}
+
+// Build will build a container parser operator.
+func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.Format {
+	case formatAuto, formatDocker, formatCRIO, formatContainerd:
+	default:
+		return nil, fmt.Errorf("invalid format '%s'", c.Format)
+	}
+
+	// The partial lines of all the runtimes are reassembled by a recombine operator,
+	// which writes the reassembled entries to the outputs of the parser.
+	recombineConfig := recombine.NewConfigWithID(c.ID() + "_recombine")
+	recombineConfig.IsLastEntry = fmt.Sprintf("attributes.%s != %q", logtagAttribute, partialLogtag)
+	recombineConfig.CombineField = entry.NewBodyField()
+	recombineConfig.CombineWith = ""
+	recombineConfig.SourceIdentifier = entry.NewAttributeField(logFilePathAttribute)
+	recombineConfig.MaxLogSize = c.MaxLogSize
+	recombineOperator, err := recombineConfig.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("build recombine operator: %w", err)
+	}
+
+	return &Parser{
+		TransformerOperator:     transformerOperator,
+		parseFrom:               c.ParseFrom,
+		format:                  c.Format,
+		addMetadataFromFilePath: c.AddMetadataFromFilePath,
+		json:                    jsoniter.ConfigFastest,
+		recombine:               recombineOperator,
+	}, nil
+}
+
+// Parser is an operator that parses the logs written by the container runtimes.
+type Parser struct {
+	helper.TransformerOperator
+	parseFrom               entry.Field
+	format                  string
+	addMetadataFromFilePath bool
+	json                    jsoniter.API
+	recombine               operator.Operator
+}
+
+// dockerLog is a line of the json-file logging driver of docker
+type dockerLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+// Start will start the recombine operator reassembling the partial lines.
+func (p *Parser) Start(persister operator.Persister) error {
+	return p.recombine.Start(persister)
+}
+
+// Stop will flush the partial lines not reassembled yet.
+func (p *Parser) Stop() error {
+	return p.recombine.Stop()
+}
+
+// SetOutputs will set the outputs of the parser and of its recombine operator.
+func (p *Parser) SetOutputs(operators []operator.Operator) error {
+	if err := p.TransformerOperator.SetOutputs(operators); err != nil {
+		return err
+	}
+	p.recombine.SetOutputIDs(p.GetOutputIDs())
+	return p.recombine.SetOutputs(operators)
+}
+
+// Process will parse a container log line from an entry.
+func (p *Parser) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := p.Skip(ctx, e)
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		p.Write(ctx, e)
+		return nil
+	}
+
+	value, ok := e.Get(p.parseFrom)
+	if !ok {
+		err = errors.NewError(
+			"Entry is missing the expected parse_from field.",
+			"Ensure that all incoming entries contain the parse_from field.",
+			"parse_from", p.parseFrom.String(),
+		)
+		return p.HandleEntryError(ctx, e, err)
+	}
+	raw, ok := value.(string)
+	if !ok {
+		return p.HandleEntryError(ctx, e, fmt.Errorf("type '%T' cannot be parsed as a container log", value))
+	}
+
+	format := p.format
+	if format == formatAuto {
+		format = detectFormat(raw)
+	}
+
+	switch format {
+	case formatDocker:
+		err = p.parseDocker(e, raw)
+	default:
+		err = p.parseCRI(e, raw)
+	}
+	if err != nil {
+		return p.HandleEntryError(ctx, e, err)
+	}
+
+	if p.addMetadataFromFilePath {
+		if err = addMetadataFromFilePath(e); err != nil {
+			return p.HandleEntryError(ctx, e, err)
+		}
+	}
+
+	return p.recombine.Process(ctx, e)
+}
+
+// detectFormat returns the format of a line: docker writes JSON objects, the CRI runtimes plain text.
+func detectFormat(raw string) string {
+	if strings.HasPrefix(raw, "{") {
+		return formatDocker
+	}
+	return formatCRIO
+}
+
+func (p *Parser) parseDocker(e *entry.Entry, raw string) error {
+	var parsed dockerLog
+	if err := p.json.UnmarshalFromString(raw, &parsed); err != nil {
+		return fmt.Errorf("parse docker log: %w", err)
+	}
+	ts, err := time.Parse(time.RFC3339Nano, parsed.Time)
+	if err != nil {
+		return fmt.Errorf("parse docker log time: %w", err)
+	}
+
+	// Docker splits the long lines into several records, only the last one ends with a newline
+	logtag := partialLogtag
+	if strings.HasSuffix(parsed.Log, "\n") {
+		logtag = fullLogtag
+	}
+
+	e.Timestamp = ts
+	e.Body = strings.TrimSuffix(parsed.Log, "\n")
+	if err = e.Set(entry.NewAttributeField(iostreamAttribute), parsed.Stream); err != nil {
+		return err
+	}
+	return e.Set(entry.NewAttributeField(logtagAttribute), logtag)
+}
+
+func (p *Parser) parseCRI(e *entry.Entry, raw string) error {
+	matches := criRegexp.FindStringSubmatch(raw)
+	if matches == nil {
+		return fmt.Errorf("line does not match the CRI log format")
+	}
+	ts, err := time.Parse(time.RFC3339Nano, matches[criRegexp.SubexpIndex("time")])
+	if err != nil {
+		return fmt.Errorf("parse CRI log time: %w", err)
+	}
+
+	e.Timestamp = ts
+	e.Body = matches[criRegexp.SubexpIndex("log")]
+	if err = e.Set(entry.NewAttributeField(iostreamAttribute), matches[criRegexp.SubexpIndex("stream")]); err != nil {
+		return err
+	}
+	return e.Set(entry.NewAttributeField(logtagAttribute), matches[criRegexp.SubexpIndex("logtag")])
+}
+
+// addMetadataFromFilePath adds the metadata of the pod and of the container found in the path
+// of the log file to the resource of the entry.
+func addMetadataFromFilePath(e *entry.Entry) error {
+	var path string
+	if err := e.Read(entry.NewAttributeField(logFilePathAttribute), &path); err != nil {
+		return fmt.Errorf("read %s attribute, `include_file_path` must be enabled: %w", logFilePathAttribute, err)
+	}
+
+	matches := podLogPathRegexp.FindStringSubmatch(path)
+	if matches == nil {
+		return fmt.Errorf("path '%s' does not match the path of the pod logs", path)
+	}
+
+	resource := map[string]string{
+		namespaceAttribute:     matches[podLogPathRegexp.SubexpIndex("namespace")],
+		podNameAttribute:       matches[podLogPathRegexp.SubexpIndex("pod_name")],
+		podUIDAttribute:        matches[podLogPathRegexp.SubexpIndex("uid")],
+		containerNameAttribute: matches[podLogPathRegexp.SubexpIndex("container_name")],
+		restartCountAttribute:  matches[podLogPathRegexp.SubexpIndex("restart_count")],
+	}
+	for key, value := range resource {
+		if err := e.Set(entry.NewResourceField(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const testLogPath = "/var/log/pods/default_my-pod_9c8c1d1b-6a0e-4e7b-9d6a-0f0b5e4b3b5a/my-container/1.log"
+
+func newTestParser(t *testing.T, configure func(*Config)) (*Parser, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	if configure != nil {
+		configure(cfg)
+	}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+	t.Cleanup(func() { require.NoError(t, op.Stop()) })
+	return op.(*Parser), fake
+}
+
+func newTestEntry(body string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.Attributes = map[string]interface{}{
+		logFilePathAttribute: testLogPath,
+	}
+	return e
+}
+
+func expectedResource() map[string]interface{} {
+	return map[string]interface{}{
+		namespaceAttribute:     "default",
+		podNameAttribute:       "my-pod",
+		podUIDAttribute:        "9c8c1d1b-6a0e-4e7b-9d6a-0f0b5e4b3b5a",
+		containerNameAttribute: "my-container",
+		restartCountAttribute:  "1",
+	}
+}
+
+func TestConfigBuild(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.Format = "podman"
+	_, err := cfg.Build(testutil.Logger(t))
+	require.ErrorContains(t, err, "invalid format 'podman'")
+}
+
+func TestParser(t *testing.T) {
+	cases := []struct {
+		name     string
+		format   string
+		input    string
+		expected func() *entry.Entry
+	}{
+		{
+			name:   "docker",
+			format: formatDocker,
+			input:  `{"log":"INFO: log line here\n","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+			expected: func() *entry.Entry {
+				e := entry.New()
+				e.Timestamp = time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC)
+				e.Body = "INFO: log line here"
+				e.Attributes = map[string]interface{}{
+					logFilePathAttribute: testLogPath,
+					iostreamAttribute:    "stdout",
+					logtagAttribute:      "F",
+				}
+				e.Resource = expectedResource()
+				return e
+			},
+		},
+		{
+			name:   "crio",
+			format: formatCRIO,
+			input:  "2024-04-13T07:59:37.505201169-05:00 stderr F standalone crio line",
+			expected: func() *entry.Entry {
+				e := entry.New()
+				e.Timestamp = time.Date(2024, time.April, 13, 7, 59, 37, 505201169, time.FixedZone("", -5*60*60))
+				e.Body = "standalone crio line"
+				e.Attributes = map[string]interface{}{
+					logFilePathAttribute: testLogPath,
+					iostreamAttribute:    "stderr",
+					logtagAttribute:      "F",
+				}
+				e.Resource = expectedResource()
+				return e
+			},
+		},
+		{
+			name:   "containerd",
+			format: formatContainerd,
+			input:  "2023-06-22T10:10:38.108935742Z stdout F standalone containerd line",
+			expected: func() *entry.Entry {
+				e := entry.New()
+				e.Timestamp = time.Date(2023, time.June, 22, 10, 10, 38, 108935742, time.UTC)
+				e.Body = "standalone containerd line"
+				e.Attributes = map[string]interface{}{
+					logFilePathAttribute: testLogPath,
+					iostreamAttribute:    "stdout",
+					logtagAttribute:      "F",
+				}
+				e.Resource = expectedResource()
+				return e
+			},
+		},
+		{
+			name:   "auto_docker",
+			format: formatAuto,
+			input:  `{"log":"auto docker line\n","stream":"stderr","time":"2029-03-30T08:31:20.545192187Z"}`,
+			expected: func() *entry.Entry {
+				e := entry.New()
+				e.Timestamp = time.Date(2029, time.March, 30, 8, 31, 20, 545192187, time.UTC)
+				e.Body = "auto docker line"
+				e.Attributes = map[string]interface{}{
+					logFilePathAttribute: testLogPath,
+					iostreamAttribute:    "stderr",
+					logtagAttribute:      "F",
+				}
+				e.Resource = expectedResource()
+				return e
+			},
+		},
+		{
+			name:   "auto_cri",
+			format: formatAuto,
+			input:  "2023-06-22T10:10:38.108935742Z stdout F auto cri line",
+			expected: func() *entry.Entry {
+				e := entry.New()
+				e.Timestamp = time.Date(2023, time.June, 22, 10, 10, 38, 108935742, time.UTC)
+				e.Body = "auto cri line"
+				e.Attributes = map[string]interface{}{
+					logFilePathAttribute: testLogPath,
+					iostreamAttribute:    "stdout",
+					logtagAttribute:      "F",
+				}
+				e.Resource = expectedResource()
+				return e
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, func(cfg *Config) {
+				cfg.Format = tc.format
+			})
+
+			input := newTestEntry(tc.input)
+			expected := tc.expected()
+			expected.ObservedTimestamp = input.ObservedTimestamp
+
+			require.NoError(t, parser.Process(context.Background(), input))
+			fake.ExpectEntry(t, expected)
+		})
+	}
+}
+
+func TestParserPartialLines(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	lines := []string{
+		"2023-06-22T10:10:38.108935742Z stdout P first part, ",
+		"2023-06-22T10:10:38.108935743Z stdout P second part, ",
+		"2023-06-22T10:10:38.108935744Z stdout F last part",
+		"2023-06-22T10:10:39.108935742Z stdout F next line",
+	}
+	for _, line := range lines {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry(line)))
+	}
+
+	fake.ExpectBody(t, "first part, second part, last part")
+	fake.ExpectBody(t, "next line")
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestParserPartialDockerLines(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	lines := []string{
+		`{"log":"first part, ","stream":"stdout","time":"2029-03-30T08:31:20.545192187Z"}`,
+		`{"log":"second part, ","stream":"stdout","time":"2029-03-30T08:31:20.545192188Z"}`,
+		`{"log":"last part\n","stream":"stdout","time":"2029-03-30T08:31:20.545192189Z"}`,
+		`{"log":"next line\n","stream":"stdout","time":"2029-03-30T08:31:21.545192187Z"}`,
+	}
+	for _, line := range lines {
+		require.NoError(t, parser.Process(context.Background(), newTestEntry(line)))
+	}
+
+	fake.ExpectBody(t, "first part, second part, last part")
+	fake.ExpectBody(t, "next line")
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestParserPartialLinesBySource(t *testing.T) {
+	parser, fake := newTestParser(t, nil)
+
+	otherPath := "/var/log/pods/default_other-pod_0a1b2c3d-6a0e-4e7b-9d6a-0f0b5e4b3b5a/other-container/0.log"
+	other := func(line string) *entry.Entry {
+		e := newTestEntry(line)
+		e.Attributes[logFilePathAttribute] = otherPath
+		return e
+	}
+
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:10:38.108935742Z stdout P my ")))
+	require.NoError(t, parser.Process(context.Background(), other("2023-06-22T10:10:38.108935742Z stdout P other ")))
+	require.NoError(t, parser.Process(context.Background(), newTestEntry("2023-06-22T10:10:38.108935743Z stdout F line")))
+	require.NoError(t, parser.Process(context.Background(), other("2023-06-22T10:10:38.108935743Z stdout F line")))
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "my line", e.Body)
+		require.Equal(t, "my-pod", e.Resource[podNameAttribute])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "other line", e.Body)
+		require.Equal(t, "other-pod", e.Resource[podNameAttribute])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+}
+
+func TestParserNoMetadata(t *testing.T) {
+	parser, fake := newTestParser(t, func(cfg *Config) {
+		cfg.AddMetadataFromFilePath = false
+	})
+
+	e := entry.New()
+	e.Body = "2023-06-22T10:10:38.108935742Z stdout F no metadata"
+	require.NoError(t, parser.Process(context.Background(), e))
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, "no metadata", e.Body)
+		require.Empty(t, e.Resource)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+}
+
+func TestParserErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		format      string
+		entry       func() *entry.Entry
+		expectedErr string
+	}{
+		{
+			name:   "invalid_docker",
+			format: formatDocker,
+			entry: func() *entry.Entry {
+				return newTestEntry(`{"log":"line","time":"yesterday"}`)
+			},
+			expectedErr: "parse docker log time",
+		},
+		{
+			name:   "invalid_cri",
+			format: formatCRIO,
+			entry: func() *entry.Entry {
+				return newTestEntry("not a cri line")
+			},
+			expectedErr: "line does not match the CRI log format",
+		},
+		{
+			name:   "invalid_type",
+			format: formatAuto,
+			entry: func() *entry.Entry {
+				e := newTestEntry("")
+				e.Body = map[string]interface{}{"log": "line"}
+				return e
+			},
+			expectedErr: "cannot be parsed as a container log",
+		},
+		{
+			name:   "missing_file_path",
+			format: formatAuto,
+			entry: func() *entry.Entry {
+				e := newTestEntry("2023-06-22T10:10:38.108935742Z stdout F line")
+				e.Attributes = nil
+				return e
+			},
+			expectedErr: "`include_file_path` must be enabled",
+		},
+		{
+			name:   "not_a_pod_log",
+			format: formatAuto,
+			entry: func() *entry.Entry {
+				e := newTestEntry("2023-06-22T10:10:38.108935742Z stdout F line")
+				e.Attributes[logFilePathAttribute] = "/var/log/syslog"
+				return e
+			},
+			expectedErr: "does not match the path of the pod logs",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			parser, fake := newTestParser(t, func(cfg *Config) {
+				cfg.Format = tc.format
+			})
+
+			err := parser.Process(context.Background(), tc.entry())
+			require.ErrorContains(t, err, tc.expectedErr)
+
+			// The entry is sent as is with the default on_error
+			select {
+			case <-fake.Received:
+			case <-time.After(time.Second):
+				require.FailNow(t, "Timed out waiting for entry")
+			}
+		})
+	}
+}
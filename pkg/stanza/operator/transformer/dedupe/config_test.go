@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupe
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "custom_id",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OperatorID = "dedupe-errors"
+					return cfg
+				}(),
+			},
+			{
+				Name: "fields",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Fields = []entry.Field{
+						entry.NewBodyField("message"),
+						entry.NewAttributeField("level"),
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "interval",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Interval = time.Minute
+					return cfg
+				}(),
+			},
+			{
+				Name: "count_attribute",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.CountAttribute = "duplicates"
+					return cfg
+				}(),
+			},
+			{
+				Name: "max_entries",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.MaxEntries = 50
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
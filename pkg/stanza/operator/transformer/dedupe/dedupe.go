@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupe // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/dedupe"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType          = "dedupe"
+	defaultCountAttribute = "log_count"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new dedupe config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new dedupe config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		Fields:            []entry.Field{entry.NewBodyField(), entry.NewAttributeField(), entry.NewResourceField()},
+		Interval:          10 * time.Second,
+		CountAttribute:    defaultCountAttribute,
+		MaxEntries:        1000,
+	}
+}
+
+// Config is the configuration of a dedupe operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Fields                   []entry.Field `mapstructure:"fields"`
+	Interval                 time.Duration `mapstructure:"interval"`
+	CountAttribute           string        `mapstructure:"count_attribute"`
+	MaxEntries               int           `mapstructure:"max_entries"`
+}
+
+// Build creates a new Transformer from a config
+func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
+	transformer, err := c.TransformerConfig.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transformer config: %w", err)
+	}
+
+	if len(c.Fields) == 0 {
+		return nil, fmt.Errorf("at least one field must be set in 'fields'")
+	}
+	if c.Interval <= 0 {
+		return nil, fmt.Errorf("'interval' must be positive")
+	}
+	if c.CountAttribute == "" {
+		return nil, fmt.Errorf("missing required argument 'count_attribute'")
+	}
+	if c.MaxEntries <= 0 {
+		return nil, fmt.Errorf("'max_entries' must be positive")
+	}
+
+	return &Transformer{
+		TransformerOperator: transformer,
+		fields:              c.Fields,
+		interval:            c.Interval,
+		countField:          entry.NewAttributeField(c.CountAttribute),
+		maxEntries:          c.MaxEntries,
+		aggregates:          make(map[string]*aggregate),
+		chClose:             make(chan struct{}),
+	}, nil
+}
+
+// Transformer is an operator that collapses the identical entries received
+// within an interval into a single entry counting them
+type Transformer struct {
+	helper.TransformerOperator
+	fields     []entry.Field
+	interval   time.Duration
+	countField entry.Field
+	maxEntries int
+	chClose    chan struct{}
+	wg         sync.WaitGroup
+
+	sync.Mutex
+	aggregates map[string]*aggregate
+	// keys holds the keys of the aggregates in the order of their first entry
+	keys []string
+}
+
+// aggregate is the first entry of a series of identical entries and the size of the series
+type aggregate struct {
+	entry *entry.Entry
+	count int
+}
+
+func (t *Transformer) Start(_ operator.Persister) error {
+	t.wg.Add(1)
+	go t.flushLoop()
+	return nil
+}
+
+func (t *Transformer) flushLoop() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.Lock()
+			t.flush(context.Background())
+			t.Unlock()
+		case <-t.chClose:
+			return
+		}
+	}
+}
+
+func (t *Transformer) Stop() error {
+	close(t.chClose)
+	t.wg.Wait()
+
+	t.Lock()
+	defer t.Unlock()
+	t.flush(context.Background())
+	return nil
+}
+
+// Process aggregates an entry with the identical entries received in the current interval
+func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := t.Skip(ctx, e)
+	if err != nil {
+		return t.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		t.Write(ctx, e)
+		return nil
+	}
+
+	key := t.key(e)
+
+	t.Lock()
+	defer t.Unlock()
+
+	if agg, ok := t.aggregates[key]; ok {
+		agg.count++
+		return nil
+	}
+
+	if len(t.aggregates) >= t.maxEntries {
+		t.Warn("Number of distinct entries exceeds max_entries. Flushing the aggregated entries early. Consider increasing max_entries parameter")
+		t.flush(ctx)
+	}
+	t.aggregates[key] = &aggregate{entry: e, count: 1}
+	t.keys = append(t.keys, key)
+	return nil
+}
+
+// key returns the values of the deduplicated fields of an entry, missing fields included
+func (t *Transformer) key(e *entry.Entry) string {
+	var sb strings.Builder
+	for _, field := range t.fields {
+		value, _ := e.Get(field)
+		// %#v prints the type of the value as well as the keys of the maps in sorted order
+		fmt.Fprintf(&sb, "%#v\x00", value)
+	}
+	return sb.String()
+}
+
+// flush writes an entry for each series of identical entries of the interval, with its count.
+// The caller must hold the lock.
+func (t *Transformer) flush(ctx context.Context) {
+	for _, key := range t.keys {
+		agg := t.aggregates[key]
+		if err := agg.entry.Set(t.countField, agg.count); err != nil {
+			t.Errorw("Failed to set count attribute", zap.Error(err))
+		}
+		t.Write(ctx, agg.entry)
+		delete(t.aggregates, key)
+	}
+	t.keys = t.keys[:0]
+}
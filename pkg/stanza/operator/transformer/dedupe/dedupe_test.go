@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedupe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		expectedErr string
+	}{
+		{
+			name:      "default",
+			configure: func(*Config) {},
+		},
+		{
+			name: "no_fields",
+			configure: func(cfg *Config) {
+				cfg.Fields = nil
+			},
+			expectedErr: "at least one field must be set in 'fields'",
+		},
+		{
+			name: "zero_interval",
+			configure: func(cfg *Config) {
+				cfg.Interval = 0
+			},
+			expectedErr: "'interval' must be positive",
+		},
+		{
+			name: "empty_count_attribute",
+			configure: func(cfg *Config) {
+				cfg.CountAttribute = ""
+			},
+			expectedErr: "missing required argument 'count_attribute'",
+		},
+		{
+			name: "zero_max_entries",
+			configure: func(cfg *Config) {
+				cfg.MaxEntries = 0
+			},
+			expectedErr: "'max_entries' must be positive",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			tc.configure(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func newTestTransformer(t *testing.T, configure func(*Config)) (operator.Operator, *testutil.FakeOutput) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Interval = time.Hour
+	configure(cfg)
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewMockPersister("test")))
+	return op, fake
+}
+
+func entryWithBody(body interface{}, attributes map[string]interface{}) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.Attributes = attributes
+	return e
+}
+
+func expectCount(t *testing.T, fake *testutil.FakeOutput, body interface{}, count int) {
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, body, e.Body)
+		require.Equal(t, count, e.Attributes[defaultCountAttribute])
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+}
+
+func TestTransformer(t *testing.T) {
+	op, fake := newTestTransformer(t, func(*Config) {})
+
+	for _, body := range []string{"error", "info", "error", "error", "info", "warn"} {
+		require.NoError(t, op.Process(context.Background(), entryWithBody(body, nil)))
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, op.Stop())
+	expectCount(t, fake, "error", 3)
+	expectCount(t, fake, "info", 2)
+	expectCount(t, fake, "warn", 1)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestTransformerDefaultFields(t *testing.T) {
+	op, fake := newTestTransformer(t, func(*Config) {})
+
+	fromFile := func(path string) *entry.Entry {
+		e := entryWithBody("error", map[string]interface{}{"log.file.path": path})
+		e.Resource = map[string]interface{}{"host.name": "host"}
+		return e
+	}
+	otherHost := fromFile("a.log")
+	otherHost.Resource = map[string]interface{}{"host.name": "other"}
+
+	for _, e := range []*entry.Entry{fromFile("a.log"), fromFile("b.log"), fromFile("a.log"), otherHost} {
+		require.NoError(t, op.Process(context.Background(), e))
+	}
+
+	require.NoError(t, op.Stop())
+	// The same line from different sources isn't collapsed
+	expectCount(t, fake, "error", 2)
+	expectCount(t, fake, "error", 1)
+	expectCount(t, fake, "error", 1)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestTransformerFields(t *testing.T) {
+	op, fake := newTestTransformer(t, func(cfg *Config) {
+		cfg.Fields = []entry.Field{entry.NewBodyField("message"), entry.NewAttributeField("level")}
+	})
+
+	inputs := []*entry.Entry{
+		entryWithBody(map[string]interface{}{"message": "failed", "id": "1"}, map[string]interface{}{"level": "error"}),
+		entryWithBody(map[string]interface{}{"message": "failed", "id": "2"}, map[string]interface{}{"level": "error"}),
+		entryWithBody(map[string]interface{}{"message": "failed", "id": "3"}, map[string]interface{}{"level": "warn"}),
+		entryWithBody(map[string]interface{}{"message": "failed", "id": "4"}, nil),
+		entryWithBody(map[string]interface{}{"message": "failed", "id": "5"}, nil),
+	}
+	for _, e := range inputs {
+		require.NoError(t, op.Process(context.Background(), e))
+	}
+
+	require.NoError(t, op.Stop())
+	// The first entry of each series is kept
+	expectCount(t, fake, map[string]interface{}{"message": "failed", "id": "1"}, 2)
+	expectCount(t, fake, map[string]interface{}{"message": "failed", "id": "3"}, 1)
+	expectCount(t, fake, map[string]interface{}{"message": "failed", "id": "4"}, 2)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestTransformerValueTypes(t *testing.T) {
+	op, fake := newTestTransformer(t, func(*Config) {})
+
+	require.NoError(t, op.Process(context.Background(), entryWithBody("1", nil)))
+	require.NoError(t, op.Process(context.Background(), entryWithBody(1, nil)))
+	require.NoError(t, op.Process(context.Background(), entryWithBody(map[string]interface{}{"a": "1", "b": "2"}, nil)))
+	require.NoError(t, op.Process(context.Background(), entryWithBody(map[string]interface{}{"b": "2", "a": "1"}, nil)))
+
+	require.NoError(t, op.Stop())
+	expectCount(t, fake, "1", 1)
+	expectCount(t, fake, 1, 1)
+	expectCount(t, fake, map[string]interface{}{"a": "1", "b": "2"}, 2)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+}
+
+func TestTransformerInterval(t *testing.T) {
+	op, fake := newTestTransformer(t, func(cfg *Config) {
+		cfg.Interval = 50 * time.Millisecond
+	})
+	defer func() {
+		require.NoError(t, op.Stop())
+	}()
+
+	require.NoError(t, op.Process(context.Background(), entryWithBody("error", nil)))
+	require.NoError(t, op.Process(context.Background(), entryWithBody("error", nil)))
+	expectCount(t, fake, "error", 2)
+
+	// A new interval starts a new series
+	require.NoError(t, op.Process(context.Background(), entryWithBody("error", nil)))
+	expectCount(t, fake, "error", 1)
+}
+
+func TestTransformerMaxEntries(t *testing.T) {
+	op, fake := newTestTransformer(t, func(cfg *Config) {
+		cfg.MaxEntries = 2
+	})
+
+	for _, body := range []string{"a", "b", "a", "c"} {
+		require.NoError(t, op.Process(context.Background(), entryWithBody(body, nil)))
+	}
+	expectCount(t, fake, "a", 2)
+	expectCount(t, fake, "b", 1)
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	require.NoError(t, op.Stop())
+	expectCount(t, fake, "c", 1)
+}
+
+func TestTransformerCountAttribute(t *testing.T) {
+	op, fake := newTestTransformer(t, func(cfg *Config) {
+		cfg.CountAttribute = "duplicates"
+	})
+
+	require.NoError(t, op.Process(context.Background(), entryWithBody("error", nil)))
+	require.NoError(t, op.Process(context.Background(), entryWithBody("error", nil)))
+	require.NoError(t, op.Stop())
+
+	select {
+	case e := <-fake.Received:
+		require.Equal(t, map[string]interface{}{"duplicates": 2}, e.Attributes)
+	case <-time.After(time.Second):
+		require.FailNow(t, "Timed out waiting for entry")
+	}
+}
+
+func TestTransformerSkip(t *testing.T) {
+	op, fake := newTestTransformer(t, func(cfg *Config) {
+		cfg.IfExpr = `body != "error"`
+	})
+
+	e := entryWithBody("error", nil)
+	require.NoError(t, op.Process(context.Background(), e))
+	fake.ExpectEntry(t, e)
+
+	require.NoError(t, op.Process(context.Background(), entryWithBody("info", nil)))
+	require.NoError(t, op.Stop())
+	expectCount(t, fake, "info", 1)
+}
@@ -86,12 +86,14 @@ func (f *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
 	matches, err := vm.Run(f.expression, env)
 	if err != nil {
 		f.Errorf("Running expressing returned an error", zap.Error(err))
+		helper.RecordDropped(ctx)
 		return nil
 	}
 
 	filtered, ok := matches.(bool)
 	if !ok {
 		f.Errorf("Expression did not compile as a boolean")
+		helper.RecordDropped(ctx)
 		return nil
 	}
 
@@ -107,7 +109,9 @@ func (f *Transformer) Process(ctx context.Context, entry *entry.Entry) error {
 
 	if i.Cmp(f.dropCutoff) >= 0 {
 		f.Write(ctx, entry)
+		return nil
 	}
 
+	helper.RecordDropped(ctx)
 	return nil
 }
@@ -75,6 +75,15 @@ func TestUnmarshal(t *testing.T) {
 					return cfg
 				}(),
 			},
+			{
+				Name:      "stack_trace_detectors",
+				ExpectErr: false,
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.StackTraceDetectors = []string{"java", "python"}
+					return cfg
+				}(),
+			},
 			{
 				Name:      "custom_max_log_size",
 				ExpectErr: false,
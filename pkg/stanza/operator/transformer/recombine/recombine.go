@@ -51,6 +51,7 @@ type Config struct {
 	helper.TransformerConfig `mapstructure:",squash"`
 	IsFirstEntry             string          `mapstructure:"is_first_entry"`
 	IsLastEntry              string          `mapstructure:"is_last_entry"`
+	StackTraceDetectors      []string        `mapstructure:"stack_trace_detectors"`
 	MaxBatchSize             int             `mapstructure:"max_batch_size"`
 	CombineField             entry.Field     `mapstructure:"combine_field"`
 	CombineWith              string          `mapstructure:"combine_with"`
@@ -72,19 +73,30 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, fmt.Errorf("only one of is_first_entry and is_last_entry can be set")
 	}
 
-	if c.IsLastEntry == "" && c.IsFirstEntry == "" {
-		return nil, fmt.Errorf("one of is_first_entry and is_last_entry must be set")
+	if len(c.StackTraceDetectors) > 0 && (c.IsLastEntry != "" || c.IsFirstEntry != "") {
+		return nil, fmt.Errorf("stack_trace_detectors cannot be set with is_first_entry or is_last_entry")
+	}
+
+	if c.IsLastEntry == "" && c.IsFirstEntry == "" && len(c.StackTraceDetectors) == 0 {
+		return nil, fmt.Errorf("one of is_first_entry, is_last_entry and stack_trace_detectors must be set")
 	}
 
 	var matchesFirst bool
 	var prog *vm.Program
-	if c.IsFirstEntry != "" {
+	var detector *stackTraceDetector
+	switch {
+	case len(c.StackTraceDetectors) > 0:
+		detector, err = newStackTraceDetector(c.StackTraceDetectors)
+		if err != nil {
+			return nil, err
+		}
+	case c.IsFirstEntry != "":
 		matchesFirst = true
 		prog, err = helper.ExprCompileBool(c.IsFirstEntry)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile is_first_entry: %w", err)
 		}
-	} else {
+	default:
 		matchesFirst = false
 		prog, err = helper.ExprCompileBool(c.IsLastEntry)
 		if err != nil {
@@ -110,6 +122,7 @@ func (c *Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		TransformerOperator: transformer,
 		matchFirstLine:      matchesFirst,
 		prog:                prog,
+		detector:            detector,
 		maxBatchSize:        c.MaxBatchSize,
 		maxSources:          c.MaxSources,
 		overwriteWithOldest: overwriteWithOldest,
@@ -138,6 +151,7 @@ type Transformer struct {
 	helper.TransformerOperator
 	matchFirstLine      bool
 	prog                *vm.Program
+	detector            *stackTraceDetector
 	maxBatchSize        int
 	maxSources          int
 	overwriteWithOldest bool
@@ -159,6 +173,8 @@ type sourceBatch struct {
 	entries                []*entry.Entry
 	recombined             *bytes.Buffer
 	firstEntryObservedTime time.Time
+	// traceState is the state of the stack trace detector for the source
+	traceState string
 }
 
 func (r *Transformer) Start(_ operator.Persister) error {
@@ -212,6 +228,10 @@ func (r *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	r.Lock()
 	defer r.Unlock()
 
+	if r.detector != nil {
+		return r.processStackTrace(ctx, e, r.sourceOf(e))
+	}
+
 	// Get the environment for executing the expression.
 	// In the future, we may want to provide access to the currently
 	// batched entries so users can do comparisons to other entries
@@ -226,16 +246,7 @@ func (r *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 
 	// this is guaranteed to be a boolean because of expr.AsBool
 	matches := m.(bool)
-	var s string
-	err = e.Read(r.sourceIdentifier, &s)
-	if err != nil {
-		r.Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
-		s = DefaultSourceIdentifier
-	}
-
-	if s == "" {
-		s = DefaultSourceIdentifier
-	}
+	s := r.sourceOf(e)
 
 	switch {
 	// This is the first entry in the next batch
@@ -264,6 +275,58 @@ func (r *Transformer) Process(ctx context.Context, e *entry.Entry) error {
 	return nil
 }
 
+// sourceOf returns the source of the entry, used to combine the entries of each source separately
+func (r *Transformer) sourceOf(e *entry.Entry) string {
+	var s string
+	err := e.Read(r.sourceIdentifier, &s)
+	if err != nil {
+		r.Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
+		s = DefaultSourceIdentifier
+	}
+
+	if s == "" {
+		s = DefaultSourceIdentifier
+	}
+	return s
+}
+
+// processStackTrace combines the lines of the stack traces found by the detector.
+// The other entries are sent as is.
+func (r *Transformer) processStackTrace(ctx context.Context, e *entry.Entry, source string) error {
+	state := startState
+	if batch, ok := r.batchMap[source]; ok {
+		state = batch.traceState
+	}
+
+	action := noTrace
+	var line string
+	if err := e.Read(r.combineField, &line); err == nil {
+		action, state = r.detector.update(state, line)
+	}
+
+	switch action {
+	case traceStart:
+		// Flush the previous stack trace
+		if err := r.flushSource(source, true); err != nil {
+			return err
+		}
+		fallthrough
+	case traceInside:
+		r.addToBatch(ctx, e, source)
+		// The batch is gone if all the sources were flushed because of max_sources
+		if batch, ok := r.batchMap[source]; ok {
+			batch.traceState = state
+		}
+		return nil
+	}
+
+	if err := r.flushSource(source, true); err != nil {
+		return err
+	}
+	r.Write(ctx, e)
+	return nil
+}
+
 func (r *Transformer) matchIndicatesFirst() bool {
 	return r.matchFirstLine
 }
@@ -372,6 +435,7 @@ func (r *Transformer) addNewBatch(source string, e *entry.Entry) *sourceBatch {
 	batch.entries = append(batch.entries[:0], e)
 	batch.recombined.Reset()
 	batch.firstEntryObservedTime = e.ObservedTimestamp
+	batch.traceState = startState
 	r.batchMap[source] = batch
 	return batch
 }
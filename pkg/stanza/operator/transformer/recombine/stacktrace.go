@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/recombine"
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The detectors are state machines fed with the lines of a source, one at a time.
+// Each rule moves the state machine to its next state when its pattern matches a line,
+// a line for which no rule matches ends the stack trace in progress. A stack trace is
+// only known to be complete once the next line is received, or after force_flush_period.
+// The rules are adapted from the exception detector of Google Cloud Logging.
+
+const (
+	stackTraceJava   = "java"
+	stackTracePython = "python"
+	stackTraceGo     = "go"
+	stackTraceDotnet = "dotnet"
+
+	startState = "start"
+)
+
+type stackTraceRule struct {
+	from    []string
+	pattern *regexp.Regexp
+	to      string
+}
+
+func rule(from []string, pattern string, to string) stackTraceRule {
+	return stackTraceRule{from: from, pattern: regexp.MustCompile(pattern), to: to}
+}
+
+var stackTraceRules = map[string][]stackTraceRule{
+	stackTraceJava: {
+		rule([]string{startState}, `(?:Exception|Error|Throwable|V8 errors stack trace)(?::|$)`, "java_after_exception"),
+		rule([]string{"java_after_exception"}, `^[\t ]*nested exception is:[\t ]*`, "java_start_exception"),
+		rule([]string{"java_after_exception"}, `^[\r\n]*$`, "java_after_exception"),
+		rule([]string{"java_start_exception"}, `^[\t ]*[\w$.]+(?::|$)`, "java_after_exception"),
+		rule([]string{"java_after_exception", "java"}, `^[\t ]+(?:eval )?at `, "java"),
+		rule([]string{"java_after_exception", "java"}, `^[\t ]*(?:Caused by|Suppressed):`, "java_after_exception"),
+		rule([]string{"java_after_exception", "java"}, `^[\t ]*\.\.\. \d+ (?:more|common frames omitted)`, "java"),
+	},
+	stackTracePython: {
+		rule([]string{startState}, `^Traceback \(most recent call last\):$`, "python"),
+		rule([]string{"python", "python_code"}, `^[\t ]+File `, "python_code"),
+		rule([]string{"python", "python_code"}, `^(?:[^\s.():]+\.)*[^\s.():]+:`, "python_after_error"),
+		rule([]string{"python", "python_code"}, `^[\t ]+\S`, "python"),
+		rule([]string{"python_after_error", "python_chained"}, `^$`, "python_chained"),
+		rule([]string{"python_after_error", "python_chained"}, `^(?:During handling of the above exception, another exception occurred|The above exception was the direct cause of the following exception):$`, "python_chained"),
+		rule([]string{"python_chained"}, `^Traceback \(most recent call last\):$`, "python"),
+	},
+	stackTraceGo: {
+		rule([]string{startState}, `\bpanic: `, "go_after_panic"),
+		rule([]string{startState}, `http: panic serving`, "go_goroutine"),
+		rule([]string{"go_after_panic"}, `^\t?panic: `, "go_after_panic"),
+		rule([]string{"go_after_panic"}, `^\[signal `, "go_after_signal"),
+		rule([]string{"go_after_panic", "go_after_signal", "go_frame_1"}, `^$`, "go_goroutine"),
+		rule([]string{"go_goroutine"}, `^goroutine \d+ \[[^\]]+\]:$`, "go_frame_1"),
+		rule([]string{"go_frame_1"}, `^(?:[^\s.:]+\.)*[^\s.():]+\(|^created by `, "go_frame_2"),
+		rule([]string{"go_frame_2"}, `^\s`, "go_frame_1"),
+	},
+	stackTraceDotnet: {
+		rule([]string{startState}, `(?:^|[\s:])(?:[A-Za-z_]\w*\.)+\w*Exception(?::|$)`, "dotnet_after_exception"),
+		rule([]string{"dotnet_after_exception", "dotnet"}, `^[\t ]+at `, "dotnet"),
+		rule([]string{"dotnet_after_exception", "dotnet"}, `^[\t ]*---> `, "dotnet_after_exception"),
+		rule([]string{"dotnet_after_exception", "dotnet"}, `^[\t ]*--- End of (?:inner exception|stack trace from previous location)`, "dotnet"),
+	},
+}
+
+// traceAction is the action to take on a line fed to a stackTraceDetector
+type traceAction int
+
+const (
+	// noTrace is a line that is not part of a stack trace
+	noTrace traceAction = iota
+	// traceStart is the first line of a stack trace
+	traceStart
+	// traceInside is a line following the first line of a stack trace
+	traceInside
+)
+
+// stackTraceDetector detects the lines of the stack traces of a set of languages
+type stackTraceDetector struct {
+	rules map[string][]stackTraceRule
+}
+
+func newStackTraceDetector(languages []string) (*stackTraceDetector, error) {
+	d := &stackTraceDetector{rules: map[string][]stackTraceRule{}}
+	for _, language := range languages {
+		rules, ok := stackTraceRules[language]
+		if !ok {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'stack_trace_detectors'", language)
+		}
+		// The rules of the languages are tried in the configured order
+		for _, r := range rules {
+			for _, from := range r.from {
+				d.rules[from] = append(d.rules[from], r)
+			}
+		}
+	}
+	return d, nil
+}
+
+// update returns the action to take on the line, and the state of the source after the line
+func (d *stackTraceDetector) update(state string, line string) (traceAction, string) {
+	if state != startState {
+		if next, ok := d.transition(state, line); ok {
+			return traceInside, next
+		}
+	}
+
+	// The line does not continue the stack trace in progress, it may begin a new one
+	if next, ok := d.transition(startState, line); ok {
+		return traceStart, next
+	}
+	return noTrace, startState
+}
+
+func (d *stackTraceDetector) transition(state string, line string) (string, bool) {
+	for _, r := range d.rules[state] {
+		if r.pattern.MatchString(line) {
+			return r.to, true
+		}
+	}
+	return startState, false
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package recombine
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const javaTrace = `Exception in thread "main" java.lang.IllegalStateException: A book has a null property
+	at com.example.myproject.Author.getBookIds(Author.java:38)
+	at com.example.myproject.Bootstrap.main(Bootstrap.java:14)
+Caused by: java.lang.NullPointerException
+	at com.example.myproject.Book.getId(Book.java:22)
+	at com.example.myproject.Author.getBookIds(Author.java:35)
+	... 1 more`
+
+const pythonTrace = `Traceback (most recent call last):
+  File "/app/main.py", line 5, in <module>
+    parse(data)
+  File "/app/parser.py", line 12, in parse
+    return int(data)
+ValueError: invalid literal for int() with base 10: 'abc'
+
+During handling of the above exception, another exception occurred:
+
+Traceback (most recent call last):
+  File "/app/main.py", line 7, in <module>
+    raise RuntimeError("cannot parse")
+RuntimeError: cannot parse`
+
+const goTrace = `panic: runtime error: index out of range [5] with length 3
+
+goroutine 1 [running]:
+main.lookup(...)
+	/app/main.go:12
+main.main()
+	/app/main.go:8 +0x1d
+
+goroutine 6 [chan receive]:
+main.worker(0xc000012345)
+	/app/worker.go:21 +0x45
+created by main.main
+	/app/main.go:6 +0x35`
+
+const dotnetTrace = `Unhandled exception. System.InvalidOperationException: Cannot process the order
+ ---> System.ArgumentNullException: Value cannot be null. (Parameter 'customer')
+   at App.Orders.Validate(Order order) in /app/Orders.cs:line 42
+   --- End of inner exception stack trace ---
+   at App.Orders.Process(Order order) in /app/Orders.cs:line 17
+   at App.Program.Main(String[] args) in /app/Program.cs:line 9`
+
+func TestStackTraceDetectors(t *testing.T) {
+	cases := []struct {
+		name      string
+		detectors []string
+		input     []string
+		expected  []string
+	}{
+		{
+			name:      "java",
+			detectors: []string{stackTraceJava},
+			input:     []string{"starting", javaTrace, "done"},
+			expected:  []string{"starting", javaTrace, "done"},
+		},
+		{
+			name:      "python",
+			detectors: []string{stackTracePython},
+			input:     []string{"starting", pythonTrace, "done"},
+			expected:  []string{"starting", pythonTrace, "done"},
+		},
+		{
+			name:      "go",
+			detectors: []string{stackTraceGo},
+			input:     []string{"starting", goTrace, "exit status 2"},
+			expected:  []string{"starting", goTrace, "exit status 2"},
+		},
+		{
+			name:      "dotnet",
+			detectors: []string{stackTraceDotnet},
+			input:     []string{"starting", dotnetTrace, "done"},
+			expected:  []string{"starting", dotnetTrace, "done"},
+		},
+		{
+			name:      "consecutive_traces",
+			detectors: []string{stackTraceJava},
+			input:     []string{javaTrace, javaTrace, "done"},
+			expected:  []string{javaTrace, javaTrace, "done"},
+		},
+		{
+			name:      "multiple_languages",
+			detectors: []string{stackTraceJava, stackTracePython, stackTraceGo},
+			input:     []string{pythonTrace, "between", goTrace, javaTrace, "done"},
+			expected:  []string{pythonTrace, "between", goTrace, javaTrace, "done"},
+		},
+		{
+			name:      "language_not_selected",
+			detectors: []string{stackTraceJava},
+			input:     []string{goTrace},
+			expected:  strings.Split(goTrace, "\n"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.CombineField = entry.NewBodyField()
+			cfg.StackTraceDetectors = tc.detectors
+			cfg.OutputIDs = []string{"fake"}
+			op, err := cfg.Build(testutil.Logger(t))
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			for _, lines := range tc.input {
+				for _, line := range strings.Split(lines, "\n") {
+					e := entry.New()
+					e.Body = line
+					require.NoError(t, op.Process(context.Background(), e))
+				}
+			}
+			require.NoError(t, op.Stop())
+
+			for _, expected := range tc.expected {
+				select {
+				case e := <-fake.Received:
+					require.Equal(t, expected, e.Body)
+				case <-time.After(time.Second):
+					require.FailNow(t, "Timed out waiting for entry")
+				}
+			}
+			fake.ExpectNoEntry(t, 100*time.Millisecond)
+		})
+	}
+}
+
+func TestStackTraceDetectorsBySource(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.CombineField = entry.NewBodyField()
+	cfg.StackTraceDetectors = []string{stackTraceJava}
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	process := func(source string, body string) {
+		e := entry.New()
+		e.Body = body
+		e.AddAttribute("file.path", source)
+		require.NoError(t, op.Process(context.Background(), e))
+	}
+	process("file1", "java.lang.NullPointerException: first")
+	process("file2", "unrelated line")
+	process("file1", "\tat com.example.First.run(First.java:1)")
+	process("file1", "done")
+
+	fake.ExpectBody(t, "unrelated line")
+	fake.ExpectBody(t, "java.lang.NullPointerException: first\n\tat com.example.First.run(First.java:1)")
+	fake.ExpectBody(t, "done")
+	require.NoError(t, op.Stop())
+}
+
+func TestStackTraceDetectorsFlushTimeout(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.CombineField = entry.NewBodyField()
+	cfg.StackTraceDetectors = []string{stackTraceJava}
+	cfg.ForceFlushTimeout = 100 * time.Millisecond
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(nil))
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	for _, line := range strings.Split(javaTrace, "\n") {
+		e := entry.New()
+		e.ObservedTimestamp = time.Now()
+		e.Body = line
+		require.NoError(t, op.Process(context.Background(), e))
+	}
+	fake.ExpectBody(t, javaTrace)
+}
+
+func TestBuildStackTraceDetectors(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.CombineField = entry.NewBodyField()
+	cfg.StackTraceDetectors = []string{stackTraceJava, "cobol"}
+	_, err := cfg.Build(testutil.Logger(t))
+	require.EqualError(t, err, "invalid value 'cobol' for parameter 'stack_trace_detectors'")
+
+	cfg.StackTraceDetectors = []string{stackTraceJava}
+	cfg.IsFirstEntry = MatchAll
+	_, err = cfg.Build(testutil.Logger(t))
+	require.EqualError(t, err, "stack_trace_detectors cannot be set with is_first_entry or is_last_entry")
+
+	cfg.StackTraceDetectors = nil
+	cfg.IsFirstEntry = ""
+	_, err = cfg.Build(testutil.Logger(t))
+	require.EqualError(t, err, "one of is_first_entry, is_last_entry and stack_trace_detectors must be set")
+}
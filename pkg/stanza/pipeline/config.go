@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -18,6 +20,11 @@ import (
 type Config struct {
 	DefaultOutput operator.Operator
 	Operators     []operator.Config
+
+	// MeterProvider, if set, is used to count the entries processed by each operator.
+	MeterProvider metric.MeterProvider
+	// MetricAttributes are added to the metrics of the operators, e.g. to identify the receiver.
+	MetricAttributes []attribute.KeyValue
 }
 
 // Build will build a pipeline from the config.
@@ -69,7 +76,20 @@ func (c Config) Build(logger *zap.SugaredLogger) (*DirectedPipeline, error) {
 		}
 	}
 
-	return NewDirectedPipeline(ops)
+	var t *telemetry
+	if c.MeterProvider != nil {
+		var err error
+		if t, err = newTelemetry(c.MeterProvider, c.MetricAttributes); err != nil {
+			return nil, fmt.Errorf("create operator metrics: %w", err)
+		}
+	}
+
+	pipe, err := newDirectedPipeline(ops, t)
+	if err != nil {
+		return nil, err
+	}
+	pipe.SugaredLogger = logger
+	return pipe, nil
 }
 
 func dedeplucateIDs(ops []operator.Config) {
@@ -11,6 +11,7 @@ import (
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"gonum.org/v1/gonum/graph/encoding/dot"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
@@ -51,6 +52,8 @@ func (p *DirectedPipeline) Stop() error {
 }
 
 func (p *DirectedPipeline) start(persister operator.Persister) error {
+	p.logGraph()
+
 	sortedNodes, _ := topo.Sort(p.Graph)
 	for i := len(sortedNodes) - 1; i >= 0; i-- {
 		op := sortedNodes[i].(OperatorNode).Operator()
@@ -80,6 +83,19 @@ func (p *DirectedPipeline) stop() error {
 	return err
 }
 
+// logGraph logs the graph of the operators in the DOT format, in debug level
+func (p *DirectedPipeline) logGraph() {
+	if p.SugaredLogger == nil || !p.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		return
+	}
+	graph, err := p.Render()
+	if err != nil {
+		p.Debugw("Failed to render pipeline graph", zap.Error(err))
+		return
+	}
+	p.Debugw("Starting pipeline", "graph", string(graph))
+}
+
 // Render will render the pipeline as a dot graph
 func (p *DirectedPipeline) Render() ([]byte, error) {
 	return dot.Marshal(p.Graph, "G", "", " ")
@@ -182,13 +198,18 @@ func connectNode(graph *simple.DirectedGraph, inputNode OperatorNode) error {
 }
 
 // setOperatorOutputs will set the outputs on operators that can output.
-func setOperatorOutputs(operators []operator.Operator) error {
+// When telemetry is set, the outputs count the entries they process.
+func setOperatorOutputs(operators []operator.Operator, telemetry *telemetry) error {
 	for _, operator := range operators {
 		if !operator.CanOutput() {
 			continue
 		}
 
-		if err := operator.SetOutputs(operators); err != nil {
+		outputs := operators
+		if telemetry != nil {
+			outputs = telemetry.instrumentOutputs(operator, operators)
+		}
+		if err := operator.SetOutputs(outputs); err != nil {
 			return stanzaerrors.WithDetails(err, "operator_id", operator.ID())
 		}
 	}
@@ -197,7 +218,11 @@ func setOperatorOutputs(operators []operator.Operator) error {
 
 // NewDirectedPipeline creates a new directed pipeline
 func NewDirectedPipeline(operators []operator.Operator) (*DirectedPipeline, error) {
-	if err := setOperatorOutputs(operators); err != nil {
+	return newDirectedPipeline(operators, nil)
+}
+
+func newDirectedPipeline(operators []operator.Operator, telemetry *telemetry) (*DirectedPipeline, error) {
+	if err := setOperatorOutputs(operators, telemetry); err != nil {
 		return nil, err
 	}
 
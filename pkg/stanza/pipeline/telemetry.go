@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+
+	operatorIDKey   = "operator_id"
+	operatorTypeKey = "operator_type"
+)
+
+// telemetry holds the counters reporting the entries processed by the operators of a pipeline
+type telemetry struct {
+	received metric.Int64Counter
+	emitted  metric.Int64Counter
+	dropped  metric.Int64Counter
+	errors   metric.Int64Counter
+
+	attributes []attribute.KeyValue
+}
+
+func newTelemetry(meterProvider metric.MeterProvider, attributes []attribute.KeyValue) (*telemetry, error) {
+	meter := meterProvider.Meter(scopeName)
+
+	received, err := meter.Int64Counter(
+		"stanza_operator_entries_received",
+		metric.WithDescription("Number of entries received by an operator"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	emitted, err := meter.Int64Counter(
+		"stanza_operator_entries_emitted",
+		metric.WithDescription("Number of entries emitted by an operator, counted once per output"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	dropped, err := meter.Int64Counter(
+		"stanza_operator_entries_dropped",
+		metric.WithDescription("Number of entries dropped by an operator, e.g. by a filter or with the drop on_error mode"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	errors, err := meter.Int64Counter(
+		"stanza_operator_errors",
+		metric.WithDescription("Number of entries an operator failed to process"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &telemetry{
+		received:   received,
+		emitted:    emitted,
+		dropped:    dropped,
+		errors:     errors,
+		attributes: attributes,
+	}, nil
+}
+
+// operatorAttributes returns the attributes identifying an operator in the metrics
+func (t *telemetry) operatorAttributes(op operator.Operator) metric.MeasurementOption {
+	attrs := make([]attribute.KeyValue, 0, len(t.attributes)+2)
+	attrs = append(attrs, t.attributes...)
+	attrs = append(attrs,
+		attribute.String(operatorIDKey, op.ID()),
+		attribute.String(operatorTypeKey, op.Type()),
+	)
+	return metric.WithAttributes(attrs...)
+}
+
+// instrumentOutputs returns the operators as seen as outputs by the source operator:
+// the entries they process are counted as emitted by the source and received by them.
+func (t *telemetry) instrumentOutputs(source operator.Operator, operators []operator.Operator) []operator.Operator {
+	sourceAttrs := t.operatorAttributes(source)
+	outputs := make([]operator.Operator, 0, len(operators))
+	for _, op := range operators {
+		if !op.CanProcess() {
+			outputs = append(outputs, op)
+			continue
+		}
+		outputs = append(outputs, &instrumentedOutput{
+			Operator:    op,
+			telemetry:   t,
+			sourceAttrs: sourceAttrs,
+			attrs:       t.operatorAttributes(op),
+		})
+	}
+	return outputs
+}
+
+// instrumentedOutput is an operator counting the entries it processes
+type instrumentedOutput struct {
+	operator.Operator
+	telemetry   *telemetry
+	sourceAttrs metric.MeasurementOption
+	attrs       metric.MeasurementOption
+}
+
+// Process counts the entry before processing it with the wrapped operator
+func (o *instrumentedOutput) Process(ctx context.Context, e *entry.Entry) error {
+	o.telemetry.emitted.Add(ctx, 1, o.sourceAttrs)
+	o.telemetry.received.Add(ctx, 1, o.attrs)
+
+	err := o.Operator.Process(helper.ContextWithDroppedRecorder(ctx, o.recordDropped), e)
+	if err != nil {
+		o.telemetry.errors.Add(ctx, 1, o.attrs)
+	}
+	return err
+}
+
+func (o *instrumentedOutput) recordDropped(ctx context.Context) {
+	o.telemetry.dropped.Add(ctx, 1, o.attrs)
+}
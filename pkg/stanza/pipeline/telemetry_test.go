@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/json"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/filter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/noop"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func TestPipelineMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	filterCfg := filter.NewConfigWithID("filter")
+	filterCfg.Expression = `body == "drop"`
+	jsonCfg := json.NewConfigWithID("json")
+	jsonCfg.OnError = helper.DropOnError
+
+	fake := testutil.NewFakeOutput(t)
+	pipe, err := Config{
+		Operators: []operator.Config{
+			{Builder: filterCfg},
+			{Builder: jsonCfg},
+		},
+		DefaultOutput:    fake,
+		MeterProvider:    meterProvider,
+		MetricAttributes: []attribute.KeyValue{attribute.String("receiver", "filelog")},
+	}.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.NoError(t, pipe.Start(testutil.NewMockPersister("test")))
+	defer func() { require.NoError(t, pipe.Stop()) }()
+
+	var first operator.Operator
+	for _, op := range pipe.Operators() {
+		if op.ID() == "filter" {
+			first = op
+		}
+	}
+	require.NotNil(t, first)
+
+	for _, body := range []string{"drop", `{"key":"value"}`, "not json", `{"key":"other"}`} {
+		e := entry.New()
+		e.Body = body
+		require.NoError(t, first.Process(context.Background(), e))
+	}
+	fake.ExpectBody(t, `{"key":"value"}`)
+	fake.ExpectBody(t, `{"key":"other"}`)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	expected := map[string]map[string]int64{
+		"stanza_operator_entries_received": {"json": 3, "fake": 2},
+		"stanza_operator_entries_emitted":  {"filter": 3, "json": 2},
+		"stanza_operator_entries_dropped":  {"json": 1},
+		"stanza_operator_errors":           {"json": 1},
+	}
+	actual := map[string]map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		require.True(t, ok, "metric %s is not an int64 sum", m.Name)
+		actual[m.Name] = map[string]int64{}
+		for _, dp := range sum.DataPoints {
+			receiver, _ := dp.Attributes.Value("receiver")
+			require.Equal(t, "filelog", receiver.AsString())
+			id, _ := dp.Attributes.Value(operatorIDKey)
+			_, ok := dp.Attributes.Value(operatorTypeKey)
+			require.True(t, ok)
+			actual[m.Name][id.AsString()] = dp.Value
+		}
+	}
+	require.Equal(t, expected, actual)
+}
+
+// Without the telemetry.useOtelForInternalMetrics feature gate, the collector
+// provides a noop meter provider: the pipeline must process entries as usual.
+func TestPipelineMetricsNoopMeterProvider(t *testing.T) {
+	fake := testutil.NewFakeOutput(t)
+	pipe, err := Config{
+		Operators: []operator.Config{
+			{Builder: json.NewConfigWithID("json")},
+		},
+		DefaultOutput:    fake,
+		MeterProvider:    noopmetric.NewMeterProvider(),
+		MetricAttributes: []attribute.KeyValue{attribute.String("receiver", "filelog")},
+	}.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.NoError(t, pipe.Start(testutil.NewMockPersister("test")))
+	defer func() { require.NoError(t, pipe.Stop()) }()
+
+	ops := pipe.Operators()
+	require.NotEmpty(t, ops)
+	e := entry.New()
+	e.Body = `{"key":"value"}`
+	require.NoError(t, ops[0].Process(context.Background(), e))
+	fake.ExpectBody(t, `{"key":"value"}`)
+}
+
+func TestPipelineMetricsFilterDropped(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	filterCfg := filter.NewConfigWithID("filter")
+	filterCfg.Expression = `body == "drop"`
+
+	pipe, err := Config{
+		Operators: []operator.Config{
+			{Builder: noop.NewConfigWithID("noop")},
+			{Builder: filterCfg},
+		},
+		DefaultOutput: testutil.NewFakeOutput(t),
+		MeterProvider: meterProvider,
+	}.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	var first operator.Operator
+	for _, op := range pipe.Operators() {
+		if op.ID() == "noop" {
+			first = op
+		}
+	}
+	require.NotNil(t, first)
+
+	e := entry.New()
+	e.Body = "drop"
+	require.NoError(t, first.Process(context.Background(), e))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "stanza_operator_entries_dropped" {
+			continue
+		}
+		sum := m.Data.(metricdata.Sum[int64])
+		require.Len(t, sum.DataPoints, 1)
+		id, _ := sum.DataPoints[0].Attributes.Value(operatorIDKey)
+		require.Equal(t, "filter", id.AsString())
+		require.Equal(t, int64(1), sum.DataPoints[0].Value)
+		return
+	}
+	require.Fail(t, "stanza_operator_entries_dropped not reported")
+}
+
+func TestPipelineLogGraph(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	pipe, err := Config{
+		Operators: []operator.Config{
+			{Builder: json.NewConfigWithID("json")},
+		},
+		DefaultOutput: testutil.NewFakeOutput(t),
+	}.Build(zap.New(core).Sugar())
+	require.NoError(t, err)
+	require.NoError(t, pipe.Start(testutil.NewMockPersister("test")))
+	defer func() { require.NoError(t, pipe.Stop()) }()
+
+	started := logs.FilterMessage("Starting pipeline").All()
+	require.Len(t, started, 1)
+	graph, ok := started[0].ContextMap()["graph"].(string)
+	require.True(t, ok)
+	require.Contains(t, graph, "json -> fake")
+}
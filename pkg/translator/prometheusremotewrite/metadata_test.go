@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMetadataFromMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	sms := md.ResourceMetrics().AppendEmpty().ScopeMetrics()
+	metrics := sms.AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("system.memory.usage")
+	gauge.SetDescription("Bytes of memory in use.")
+	gauge.SetUnit("By")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+
+	counter := metrics.AppendEmpty()
+	counter.SetName("http.server.requests")
+	counter.SetDescription("Number of requests.")
+	counter.SetEmptySum().SetIsMonotonic(true)
+	counter.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	upDownCounter := metrics.AppendEmpty()
+	upDownCounter.SetName("queue.size")
+	upDownCounter.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("http.server.duration")
+	histogram.SetUnit("s")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	expHistogram := metrics.AppendEmpty()
+	expHistogram.SetName("rpc.server.duration")
+	expHistogram.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("gc.pause")
+	summary.SetEmptySummary()
+
+	delta := metrics.AppendEmpty()
+	delta.SetName("delta.requests")
+	delta.SetEmptySum().SetIsMonotonic(true)
+	delta.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+
+	empty := metrics.AppendEmpty()
+	empty.SetName("empty")
+
+	// the same family reported by another scope is only described once
+	duplicate := sms.AppendEmpty().Metrics().AppendEmpty()
+	duplicate.SetName("system.memory.usage")
+	duplicate.SetDescription("Another description.")
+	duplicate.SetEmptyGauge()
+
+	expected := []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "test_system_memory_usage", Help: "Bytes of memory in use.", Unit: "By"},
+		{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "test_http_server_requests", Help: "Number of requests."},
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "test_queue_size"},
+		{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "test_http_server_duration", Unit: "s"},
+		{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "test_rpc_server_duration"},
+		{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: "test_gc_pause"},
+	}
+	assert.Equal(t, expected, MetadataFromMetrics(md, Settings{Namespace: "test", AddMetricSuffixes: true}))
+	assert.Nil(t, MetadataFromMetrics(pmetric.NewMetrics(), Settings{}))
+}
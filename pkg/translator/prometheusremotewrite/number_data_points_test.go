@@ -46,6 +46,36 @@ func TestAddSingleGaugeNumberDataPoint(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "gauge with exemplars",
+			metric: func() pmetric.Metric {
+				m := getIntGaugeMetric(
+					"test",
+					pcommon.NewMap(),
+					1, ts,
+				)
+				m.Gauge().DataPoints().At(0).Exemplars().AppendEmpty().SetIntValue(2)
+				return m
+			},
+			want: func() map[string]*prompb.TimeSeries {
+				labels := []prompb.Label{
+					{Name: model.MetricNameLabel, Value: "test"},
+				}
+				return map[string]*prompb.TimeSeries{
+					timeSeriesSignature(pmetric.MetricTypeGauge.String(), &labels): {
+						Labels: labels,
+						Samples: []prompb.Sample{
+							{
+								Value:     1,
+								Timestamp: convertTimeStamp(pcommon.Timestamp(ts)),
+							}},
+						Exemplars: []prompb.Exemplar{
+							{Value: 2},
+						},
+					},
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
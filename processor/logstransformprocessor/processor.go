@@ -14,6 +14,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
@@ -35,9 +37,9 @@ type logsTransformProcessor struct {
 	wg            sync.WaitGroup
 }
 
-func newProcessor(config *Config, nextConsumer consumer.Logs, logger *zap.Logger) (*logsTransformProcessor, error) {
+func newProcessor(config *Config, nextConsumer consumer.Logs, set processor.CreateSettings) (*logsTransformProcessor, error) {
 	p := &logsTransformProcessor{
-		logger:   logger,
+		logger:   set.Logger,
 		config:   config,
 		consumer: nextConsumer,
 	}
@@ -46,8 +48,10 @@ func newProcessor(config *Config, nextConsumer consumer.Logs, logger *zap.Logger
 
 	p.emitter = adapter.NewLogEmitter(p.logger.Sugar())
 	pipe, err := pipeline.Config{
-		Operators:     baseCfg.Operators,
-		DefaultOutput: p.emitter,
+		Operators:        baseCfg.Operators,
+		DefaultOutput:    p.emitter,
+		MeterProvider:    set.MeterProvider,
+		MetricAttributes: []attribute.KeyValue{attribute.String("processor", set.ID.String())},
 	}.Build(p.logger.Sugar())
 	if err != nil {
 		return nil, err
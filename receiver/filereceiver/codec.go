@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filereceiver"
+
+import "github.com/klauspost/compress/zstd"
+
+// decompressFunc defines how to decompress encoded telemetry data.
+type decompressFunc func(src []byte) ([]byte, error)
+
+var decoder, _ = zstd.NewReader(nil)
+
+var decoders = map[string]decompressFunc{
+	compressionZSTD: zstdDecompress,
+}
+
+func buildDecompressor(compression string) decompressFunc {
+	if compression == "" {
+		return noneDecompress
+	}
+	return decoders[compression]
+}
+
+// zstdDecompress decompresses a buffer compressed with zstd
+func zstdDecompress(src []byte) ([]byte, error) {
+	return decoder.DecodeAll(src, nil)
+}
+
+// noneDecompress returns src
+func noneDecompress(src []byte) ([]byte, error) {
+	return src, nil
+}
@@ -20,11 +20,20 @@ type Config struct {
 	// replay will be slower by a corresponding amount. Use a value between 0 and 1
 	// to replay telemetry at a higher speed. Default: 1.
 	Throttle float64 `mapstructure:"throttle"`
+	// FormatType is the data format of the encoded telemetry, as written by the File Exporter.
+	// Options:
+	// - json[default]: OTLP json bytes.
+	// - proto: OTLP binary protobuf bytes.
+	FormatType string `mapstructure:"format"`
+	// Compression is the codec the telemetry was compressed with by the File Exporter.
+	// Supported compression algorithms: `zstd`
+	Compression string `mapstructure:"compression"`
 }
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		Throttle: 1,
+		Throttle:   1,
+		FormatType: formatTypeJSON,
 	}
 }
 
@@ -35,5 +44,11 @@ func (c Config) Validate() error {
 	if c.Throttle < 0 {
 		return errors.New("throttle cannot be negative")
 	}
+	if c.FormatType != formatTypeJSON && c.FormatType != formatTypeProto {
+		return errors.New("format type is not supported")
+	}
+	if c.Compression != "" && c.Compression != compressionZSTD {
+		return errors.New("compression is not supported")
+	}
 	return nil
 }
@@ -21,7 +21,7 @@ func TestLoadConfig_Validate_Invalid(t *testing.T) {
 }
 
 func TestConfig_Validate_Valid(t *testing.T) {
-	cfg := Config{Path: "/foo/bar"}
+	cfg := Config{Path: "/foo/bar", FormatType: formatTypeJSON}
 	assert.NoError(t, cfg.Validate())
 }
 
@@ -40,12 +40,27 @@ func TestLoadConfig(t *testing.T) {
 		}, {
 			id: component.NewIDWithName(metadata.Type, "1"),
 			expected: &Config{
-				Path:     "./filename.json",
-				Throttle: 1,
+				Path:       "./filename.json",
+				Throttle:   1,
+				FormatType: formatTypeJSON,
 			},
 		}, {
 			id:           component.NewIDWithName(metadata.Type, "2"),
 			errorMessage: "throttle cannot be negative",
+		}, {
+			id: component.NewIDWithName(metadata.Type, "3"),
+			expected: &Config{
+				Path:        "./filename.pb",
+				Throttle:    1,
+				FormatType:  formatTypeProto,
+				Compression: compressionZSTD,
+			},
+		}, {
+			id:           component.NewIDWithName(metadata.Type, "4"),
+			errorMessage: "format type is not supported",
+		}, {
+			id:           component.NewIDWithName(metadata.Type, "5"),
+			errorMessage: "compression is not supported",
 		},
 	}
 
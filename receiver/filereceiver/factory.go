@@ -13,6 +13,15 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filereceiver/internal/metadata"
 )
 
+const (
+	// the format of encoded telemetry data
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	// the type of compression codec
+	compressionZSTD = "zstd"
+)
+
 func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		metadata.Type,
@@ -29,9 +38,11 @@ func createMetricsReceiver(
 ) (receiver.Metrics, error) {
 	cfg := cc.(*Config)
 	return &fileReceiver{
-		consumer: consumer,
-		path:     cfg.Path,
-		logger:   settings.Logger,
-		throttle: cfg.Throttle,
+		consumer:    consumer,
+		path:        cfg.Path,
+		logger:      settings.Logger,
+		throttle:    cfg.Throttle,
+		formatType:  cfg.FormatType,
+		compression: cfg.Compression,
 	}, nil
 }
@@ -6,6 +6,7 @@ package filereceiver // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"bufio"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -22,32 +23,90 @@ type stringReader interface {
 	ReadString(delim byte) (string, error)
 }
 
+// messageReader reads the encoded messages written by a File Exporter.
+type messageReader interface {
+	readMessage() ([]byte, error)
+}
+
+// lineReader reads messages written one per line, as the File Exporter does for
+// uncompressed JSON.
+type lineReader struct {
+	stringReader stringReader
+}
+
+func (r lineReader) readMessage() ([]byte, error) {
+	line, err := r.stringReader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read line from input file: %w", err)
+	}
+	return []byte(line), nil
+}
+
+// maxMessageSize bounds the size of the size delimited messages, so that a corrupt
+// size doesn't allocate up to 4 GiB.
+const maxMessageSize = 64 * 1024 * 1024
+
+// sizeDelimitedReader reads messages each preceded by 4 bytes (an unsigned 32 bit
+// integer) holding their size, as the File Exporter does for proto or compressed data.
+type sizeDelimitedReader struct {
+	reader io.Reader
+}
+
+func (r sizeDelimitedReader) readMessage() ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r.reader, binary.BigEndian, &size); err != nil {
+		return nil, fmt.Errorf("failed to read message size from input file: %w", err)
+	}
+	if size > maxMessageSize {
+		return nil, fmt.Errorf("message size %d exceeds the maximum of %d bytes", size, maxMessageSize)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r.reader, buf); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("failed to read message from input file: %w", err)
+	}
+	return buf, nil
+}
+
 // fileReader
 type fileReader struct {
-	stringReader stringReader
-	unm          pmetric.Unmarshaler
-	consumer     consumer.Metrics
-	timer        *replayTimer
+	messageReader messageReader
+	decompress    decompressFunc
+	unm           pmetric.Unmarshaler
+	consumer      consumer.Metrics
+	timer         *replayTimer
 }
 
-func newFileReader(consumer consumer.Metrics, file *os.File, timer *replayTimer) fileReader {
-	return fileReader{
-		consumer:     consumer,
-		stringReader: bufio.NewReader(file),
-		unm:          &pmetric.JSONUnmarshaler{},
-		timer:        timer,
+func newFileReader(consumer consumer.Metrics, file *os.File, timer *replayTimer, formatType string, compression string) fileReader {
+	fr := fileReader{
+		consumer:   consumer,
+		decompress: buildDecompressor(compression),
+		unm:        &pmetric.JSONUnmarshaler{},
+		timer:      timer,
+	}
+	if formatType == formatTypeProto {
+		fr.unm = &pmetric.ProtoUnmarshaler{}
+	}
+	// uncompressed JSON is written one message per line, anything else is size delimited
+	if formatType == formatTypeProto || compression != "" {
+		fr.messageReader = sizeDelimitedReader{reader: bufio.NewReader(file)}
+	} else {
+		fr.messageReader = lineReader{stringReader: bufio.NewReader(file)}
 	}
+	return fr
 }
 
-// readAll calls readline for each line in the file until all lines have been
-// read or the context is cancelled.
+// readAll calls readMessage for each message in the file until all messages
+// have been read or the context is cancelled.
 func (fr fileReader) readAll(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		default:
-			err := fr.readLine(ctx)
+			err := fr.readMessage(ctx)
 			if err != nil {
 				if errors.Is(err, io.EOF) {
 					return nil
@@ -58,20 +117,24 @@ func (fr fileReader) readAll(ctx context.Context) error {
 	}
 }
 
-// readLine reads the next line in the file, converting it into metrics and
+// readMessage reads the next message in the file, converting it into metrics and
 // passing it to the the consumer member.
-func (fr fileReader) readLine(ctx context.Context) error {
-	line, err := fr.stringReader.ReadString('\n')
+func (fr fileReader) readMessage(ctx context.Context) error {
+	buf, err := fr.messageReader.readMessage()
+	if err != nil {
+		return err
+	}
+	buf, err = fr.decompress(buf)
 	if err != nil {
-		return fmt.Errorf("failed to read line from input file: %w", err)
+		return fmt.Errorf("failed to decompress message: %w", err)
 	}
-	metrics, err := fr.unm.UnmarshalMetrics([]byte(line))
+	metrics, err := fr.unm.UnmarshalMetrics(buf)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal metrics: %w", err)
 	}
 	err = fr.timer.wait(ctx, getFirstTimestamp(metrics))
 	if err != nil {
-		return fmt.Errorf("readLine interrupted while waiting for timer: %w", err)
+		return fmt.Errorf("readMessage interrupted while waiting for timer: %w", err)
 	}
 	return fr.consumer.ConsumeMetrics(ctx, metrics)
 }
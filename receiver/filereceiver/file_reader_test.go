@@ -4,24 +4,29 @@
 package filereceiver
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/binary"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
-func TestFileReader_Readline(t *testing.T) {
+func TestFileReader_ReadMessage(t *testing.T) {
 	tc := testConsumer{}
 	f, err := os.Open(filepath.Join("testdata", "metrics.json"))
 	require.NoError(t, err)
-	fr := newFileReader(&tc, f, newReplayTimer(0))
-	err = fr.readLine(context.Background())
+	fr := newFileReader(&tc, f, newReplayTimer(0), formatTypeJSON, "")
+	err = fr.readMessage(context.Background())
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(tc.consumed))
 	metrics := tc.consumed[0]
@@ -35,8 +40,8 @@ func TestFileReader_Readline(t *testing.T) {
 
 func TestFileReader_Cancellation(t *testing.T) {
 	fr := fileReader{
-		consumer:     consumertest.NewNop(),
-		stringReader: blockingStringReader{},
+		consumer:      consumertest.NewNop(),
+		messageReader: lineReader{stringReader: blockingStringReader{}},
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	errs := make(chan error)
@@ -56,7 +61,7 @@ func TestFileReader_ReadAll(t *testing.T) {
 		throttle:  2,
 		sleepFunc: sleeper.fakeSleep,
 	}
-	fr := newFileReader(&tc, f, rt)
+	fr := newFileReader(&tc, f, rt, formatTypeJSON, "")
 	err = fr.readAll(context.Background())
 	require.NoError(t, err)
 	const expectedSleeps = 10
@@ -70,6 +75,120 @@ func TestFileReader_ReadAll(t *testing.T) {
 	}
 }
 
+func TestFileReader_Formats(t *testing.T) {
+	tests := []struct {
+		name        string
+		formatType  string
+		compression string
+	}{
+		{name: "json", formatType: formatTypeJSON},
+		{name: "json_zstd", formatType: formatTypeJSON, compression: compressionZSTD},
+		{name: "proto", formatType: formatTypeProto},
+		{name: "proto_zstd", formatType: formatTypeProto, compression: compressionZSTD},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := readTestdataMetrics(t)
+			path := writeMetrics(t, expected, tt.formatType, tt.compression)
+
+			tc := testConsumer{}
+			f, err := os.Open(path)
+			require.NoError(t, err)
+			defer f.Close()
+			fr := newFileReader(&tc, f, newReplayTimer(0), tt.formatType, tt.compression)
+			require.NoError(t, fr.readAll(context.Background()))
+
+			require.Len(t, tc.consumed, len(expected))
+			for i, metrics := range tc.consumed {
+				assert.Equal(t, expected[i].MetricCount(), metrics.MetricCount())
+				assert.Equal(t, expected[i].DataPointCount(), metrics.DataPointCount())
+				assert.Equal(t, getFirstTimestamp(expected[i]), getFirstTimestamp(metrics))
+			}
+		})
+	}
+}
+
+func TestFileReader_TruncatedMessage(t *testing.T) {
+	path := writeMetrics(t, readTestdataMetrics(t)[:1], formatTypeProto, "")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data[:len(data)-1], 0600))
+
+	tc := testConsumer{}
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	fr := newFileReader(&tc, f, newReplayTimer(0), formatTypeProto, "")
+	err = fr.readAll(context.Background())
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Empty(t, tc.consumed)
+}
+
+func TestFileReader_MessageTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, binary.Write(&buf, binary.BigEndian, uint32(maxMessageSize+1)))
+	buf.WriteString("not a message")
+
+	tc := testConsumer{}
+	fr := fileReader{
+		messageReader: sizeDelimitedReader{reader: &buf},
+		consumer:      &tc,
+	}
+	err := fr.readAll(context.Background())
+	assert.ErrorContains(t, err, "exceeds the maximum")
+	assert.Empty(t, tc.consumed)
+}
+
+// readTestdataMetrics reads the metrics of testdata/metrics.json
+func readTestdataMetrics(t *testing.T) []pmetric.Metrics {
+	f, err := os.Open(filepath.Join("testdata", "metrics.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var out []pmetric.Metrics
+	unm := &pmetric.JSONUnmarshaler{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		metrics, err := unm.UnmarshalMetrics(scanner.Bytes())
+		require.NoError(t, err)
+		out = append(out, metrics)
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+// writeMetrics writes the metrics to a file the way the File Exporter does
+func writeMetrics(t *testing.T, metrics []pmetric.Metrics, formatType string, compression string) string {
+	var marshaler pmetric.Marshaler = &pmetric.JSONMarshaler{}
+	if formatType == formatTypeProto {
+		marshaler = &pmetric.ProtoMarshaler{}
+	}
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	defer encoder.Close()
+
+	var file bytes.Buffer
+	for _, md := range metrics {
+		buf, err := marshaler.MarshalMetrics(md)
+		require.NoError(t, err)
+		if compression == compressionZSTD {
+			buf = encoder.EncodeAll(buf, nil)
+		}
+		if formatType == formatTypeJSON && compression == "" {
+			file.Write(buf)
+			file.WriteString("\n")
+			continue
+		}
+		require.NoError(t, binary.Write(&file, binary.BigEndian, uint32(len(buf))))
+		file.Write(buf)
+	}
+
+	path := filepath.Join(t.TempDir(), "metrics")
+	require.NoError(t, os.WriteFile(path, file.Bytes(), 0600))
+	return path
+}
+
 type blockingStringReader struct {
 }
 
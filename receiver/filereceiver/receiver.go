@@ -21,6 +21,9 @@ type fileReceiver struct {
 	cancel   context.CancelFunc
 	path     string
 	throttle float64
+
+	formatType  string
+	compression string
 }
 
 func (r *fileReceiver) Start(ctx context.Context, _ component.Host) error {
@@ -31,7 +34,7 @@ func (r *fileReceiver) Start(ctx context.Context, _ component.Host) error {
 		return fmt.Errorf("failed to open file %q: %w", r.path, err)
 	}
 
-	fr := newFileReader(r.consumer, file, newReplayTimer(r.throttle))
+	fr := newFileReader(r.consumer, file, newReplayTimer(r.throttle), r.formatType, r.compression)
 	go func() {
 		err := fr.readAll(ctx)
 		if err != nil {
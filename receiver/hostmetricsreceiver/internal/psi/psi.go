@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package psi parses the Linux pressure stall information files found in /proc/pressure and in
+// the cgroup v2 hierarchy. See https://docs.kernel.org/accounting/psi.html.
+package psi // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/psi"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Line holds the share of wall time in which tasks were stalled on a resource, averaged over
+// 10, 60 and 300 seconds, and the total stall time.
+type Line struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  time.Duration
+}
+
+// Stats holds the pressure of a resource. Some is the pressure when at least some tasks were stalled,
+// Full the pressure when all non-idle tasks were stalled. Full is nil when the kernel doesn't report it.
+type Stats struct {
+	Some *Line
+	Full *Line
+}
+
+// ReadFile reads the pressure stall information file at path.
+func ReadFile(path string) (*Stats, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stats, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return stats, nil
+}
+
+// Parse parses the content of a pressure stall information file, formatted as:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func Parse(r io.Reader) (*Stats, error) {
+	stats := &Stats{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		line, err := parseLine(fields[1:])
+		if err != nil {
+			return nil, err
+		}
+		switch fields[0] {
+		case "some":
+			stats.Some = line
+		case "full":
+			stats.Full = line
+		default:
+			return nil, fmt.Errorf("unknown pressure kind %q", fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if stats.Some == nil {
+		return nil, fmt.Errorf("missing some pressure")
+	}
+	return stats, nil
+}
+
+func parseLine(fields []string) (*Line, error) {
+	line := &Line{}
+	for _, field := range fields {
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			return nil, fmt.Errorf("malformed field %q", field)
+		}
+		var err error
+		switch key {
+		case "avg10":
+			line.Avg10, err = strconv.ParseFloat(value, 64)
+		case "avg60":
+			line.Avg60, err = strconv.ParseFloat(value, 64)
+		case "avg300":
+			line.Avg300, err = strconv.ParseFloat(value, 64)
+		case "total":
+			var total uint64
+			total, err = strconv.ParseUint(value, 10, 64)
+			line.Total = time.Duration(total) * time.Microsecond
+		}
+		if err != nil {
+			return nil, fmt.Errorf("malformed field %q: %w", field, err)
+		}
+	}
+	return line, nil
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package psi
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFile(t *testing.T) {
+	stats, err := ReadFile(filepath.Join("testdata", "memory"))
+	require.NoError(t, err)
+	assert.Equal(t, &Stats{
+		Some: &Line{Avg10: 1.53, Avg60: 0.87, Avg300: 0.29, Total: 1056032 * time.Microsecond},
+		Full: &Line{Avg10: 0.5, Avg60: 0.25, Avg300: 0.05, Total: 524 * time.Millisecond},
+	}, stats)
+
+	_, err = ReadFile(filepath.Join("testdata", "missing"))
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *Stats
+		err      string
+	}{
+		{
+			name:     "some only",
+			content:  "some avg10=0.00 avg60=0.10 avg300=0.00 total=10\n",
+			expected: &Stats{Some: &Line{Avg60: 0.1, Total: 10 * time.Microsecond}},
+		},
+		{
+			name:    "missing some",
+			content: "full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			err:     "missing some pressure",
+		},
+		{
+			name:    "unknown kind",
+			content: "most avg10=0.00 avg60=0.00 avg300=0.00 total=0\n",
+			err:     `unknown pressure kind "most"`,
+		},
+		{
+			name:    "malformed field",
+			content: "some avg10 avg60=0.00 avg300=0.00 total=0\n",
+			err:     `malformed field "avg10"`,
+		},
+		{
+			name:    "malformed total",
+			content: "some avg10=0.00 avg60=0.00 avg300=0.00 total=-1\n",
+			err:     `malformed field "total=-1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := Parse(strings.NewReader(tt.content))
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stats)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// This file parses the interface files of the cgroup v2 controllers.
+// See https://docs.kernel.org/admin-guide/cgroup-v2.html#interface-files.
+
+// ioStat holds the IO statistics of a device, from a line of io.stat.
+type ioStat struct {
+	device string
+	rbytes int64
+	wbytes int64
+	rios   int64
+	wios   int64
+}
+
+// readFlatKeyed reads a file of "<key> <value>" lines such as cpu.stat and memory.events.
+func readFlatKeyed(path string) (map[string]int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]int64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed line %q in %s", scanner.Text(), path)
+		}
+		value, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed value of %s in %s: %w", fields[0], path, err)
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
+
+// readSingleValue reads a file holding a single value such as memory.current and memory.max.
+// The value of files set to "max" is reported as unlimited.
+func readSingleValue(path string) (value int64, unlimited bool, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false, err
+	}
+	str := strings.TrimSpace(string(content))
+	if str == "max" {
+		return 0, true, nil
+	}
+	value, err = strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("malformed value in %s: %w", path, err)
+	}
+	return value, false, nil
+}
+
+// readIOStat reads io.stat, made of "<major>:<minor> rbytes=0 wbytes=0 rios=0 wios=0 dbytes=0 dios=0" lines.
+func readIOStat(path string) ([]ioStat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var stats []ioStat
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		stat := ioStat{device: fields[0]}
+		for _, field := range fields[1:] {
+			key, str, found := strings.Cut(field, "=")
+			if !found {
+				return nil, fmt.Errorf("malformed field %q in %s", field, path)
+			}
+			value, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("malformed field %q in %s: %w", field, path, err)
+			}
+			switch key {
+			case "rbytes":
+				stat.rbytes = value
+			case "wbytes":
+				stat.wbytes = value
+			case "rios":
+				stat.rios = value
+			case "wios":
+				stat.wios = value
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, scanner.Err()
+}
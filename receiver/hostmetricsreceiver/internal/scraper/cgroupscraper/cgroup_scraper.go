@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/common"
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/psi"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	// cgroupDir is the mount point of the cgroup v2 hierarchy.
+	cgroupDir = "/sys/fs/cgroup"
+
+	cpuMetricsLen          = 4
+	memoryUsageMetricsLen  = 1
+	memoryLimitMetricsLen  = 1
+	memoryEventsMetricsLen = 1
+	ioMetricsLen           = 2
+	pressureMetricsLen     = 1
+)
+
+var pressureResources = []struct {
+	file     string
+	resource metadata.AttributeResource
+}{
+	{file: "cpu.pressure", resource: metadata.AttributeResourceCpu},
+	{file: "memory.pressure", resource: metadata.AttributeResourceMemory},
+	{file: "io.pressure", resource: metadata.AttributeResourceIo},
+}
+
+var memoryEvents = map[string]metadata.AttributeEvent{
+	"low":      metadata.AttributeEventLow,
+	"high":     metadata.AttributeEventHigh,
+	"max":      metadata.AttributeEventMax,
+	"oom":      metadata.AttributeEventOom,
+	"oom_kill": metadata.AttributeEventOomKill,
+}
+
+// scraper for Cgroup Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	dir       string
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime func(context.Context) (uint64, error)
+}
+
+// newCgroupScraper creates a scraper walking the cgroup v2 hierarchy below the root path.
+func newCgroupScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	if cfg.MaxDepth < 0 {
+		return nil, fmt.Errorf("max_depth can't be negative, got %d", cfg.MaxDepth)
+	}
+
+	scraper := &scraper{
+		settings: settings,
+		config:   cfg,
+		dir:      filepath.Join(cfg.RootPath, cgroupDir),
+		bootTime: host.BootTimeWithContext,
+	}
+
+	var err error
+
+	if len(cfg.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *scraper) start(ctx context.Context, _ component.Host) error {
+	// cgroup.controllers only exists in the cgroup v2 hierarchy.
+	if _, err := os.Stat(filepath.Join(s.dir, "cgroup.controllers")); err != nil {
+		return fmt.Errorf("cgroup v2 hierarchy not found at %s: %w", s.dir, err)
+	}
+
+	ctx = context.WithValue(ctx, common.EnvKey, s.config.EnvMap)
+	bootTime, err := s.bootTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) scrape(context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	var errs scrapererror.ScrapeErrors
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == s.dir {
+				return err
+			}
+			// The cgroup was removed while walking the hierarchy.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			errs.Add(err)
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		cgroupPath, depth := "/", 0
+		if rel != "." {
+			cgroupPath = "/" + filepath.ToSlash(rel)
+			depth = strings.Count(cgroupPath, "/")
+		}
+		if depth > s.config.MaxDepth {
+			return fs.SkipDir
+		}
+
+		if s.included(cgroupPath) {
+			s.scrapeCgroup(now, path, cgroupPath, &errs)
+		}
+		return nil
+	})
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) included(cgroupPath string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(cgroupPath)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(cgroupPath))
+}
+
+// scrapeCgroup records the metrics of a cgroup. The interface files of the controllers which aren't
+// enabled in the cgroup don't exist, so missing files aren't reported as errors.
+func (s *scraper) scrapeCgroup(now pcommon.Timestamp, dir string, cgroupPath string, errs *scrapererror.ScrapeErrors) {
+	addPartial := func(err error, failed int) {
+		if !errors.Is(err, fs.ErrNotExist) {
+			errs.AddPartial(failed, err)
+		}
+	}
+
+	if stat, err := readFlatKeyed(filepath.Join(dir, "cpu.stat")); err != nil {
+		addPartial(err, cpuMetricsLen)
+	} else {
+		s.recordCPUStat(now, stat)
+	}
+
+	if usage, _, err := readSingleValue(filepath.Join(dir, "memory.current")); err != nil {
+		addPartial(err, memoryUsageMetricsLen)
+	} else {
+		s.mb.RecordCgroupMemoryUsageDataPoint(now, usage)
+	}
+
+	if limit, unlimited, err := readSingleValue(filepath.Join(dir, "memory.max")); err != nil {
+		addPartial(err, memoryLimitMetricsLen)
+	} else if !unlimited {
+		s.mb.RecordCgroupMemoryLimitDataPoint(now, limit)
+	}
+
+	if events, err := readFlatKeyed(filepath.Join(dir, "memory.events")); err != nil {
+		addPartial(err, memoryEventsMetricsLen)
+	} else {
+		for name, event := range memoryEvents {
+			if count, ok := events[name]; ok {
+				s.mb.RecordCgroupMemoryEventsDataPoint(now, count, event)
+			}
+		}
+	}
+
+	if stats, err := readIOStat(filepath.Join(dir, "io.stat")); err != nil {
+		addPartial(err, ioMetricsLen)
+	} else {
+		for _, stat := range stats {
+			s.mb.RecordCgroupIoBytesDataPoint(now, stat.rbytes, stat.device, metadata.AttributeDirectionRead)
+			s.mb.RecordCgroupIoBytesDataPoint(now, stat.wbytes, stat.device, metadata.AttributeDirectionWrite)
+			s.mb.RecordCgroupIoOperationsDataPoint(now, stat.rios, stat.device, metadata.AttributeDirectionRead)
+			s.mb.RecordCgroupIoOperationsDataPoint(now, stat.wios, stat.device, metadata.AttributeDirectionWrite)
+		}
+	}
+
+	for _, r := range pressureResources {
+		stats, err := psi.ReadFile(filepath.Join(dir, r.file))
+		if err != nil {
+			addPartial(err, pressureMetricsLen)
+			continue
+		}
+		s.mb.RecordCgroupPressureStallTimeDataPoint(now, stats.Some.Total.Seconds(), r.resource, metadata.AttributeScopeSome)
+		if stats.Full != nil {
+			s.mb.RecordCgroupPressureStallTimeDataPoint(now, stats.Full.Total.Seconds(), r.resource, metadata.AttributeScopeFull)
+		}
+	}
+
+	rb := s.mb.NewResourceBuilder()
+	rb.SetCgroupPath(cgroupPath)
+	s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+// recordCPUStat records the usage and throttling statistics of cpu.stat, reported in microseconds.
+// The throttling statistics are only reported when the cpu controller is enabled.
+func (s *scraper) recordCPUStat(now pcommon.Timestamp, stat map[string]int64) {
+	if usec, ok := stat["user_usec"]; ok {
+		s.mb.RecordCgroupCPUTimeDataPoint(now, microsecondsToSeconds(usec), metadata.AttributeStateUser)
+	}
+	if usec, ok := stat["system_usec"]; ok {
+		s.mb.RecordCgroupCPUTimeDataPoint(now, microsecondsToSeconds(usec), metadata.AttributeStateSystem)
+	}
+	if periods, ok := stat["nr_periods"]; ok {
+		s.mb.RecordCgroupCPUPeriodsDataPoint(now, periods)
+	}
+	if periods, ok := stat["nr_throttled"]; ok {
+		s.mb.RecordCgroupCPUThrottledPeriodsDataPoint(now, periods)
+	}
+	if usec, ok := stat["throttled_usec"]; ok {
+		s.mb.RecordCgroupCPUThrottledTimeDataPoint(now, microsecondsToSeconds(usec))
+	}
+}
+
+func microsecondsToSeconds(usec int64) float64 {
+	return float64(usec) / 1e6
+}
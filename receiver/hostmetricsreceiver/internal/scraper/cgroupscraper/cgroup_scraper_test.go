@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+func newTestConfig(rootPath string) *Config {
+	return &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		ScraperConfig:        internal.ScraperConfig{RootPath: rootPath},
+		MaxDepth:             defaultMaxDepth,
+	}
+}
+
+func newTestScraper(t *testing.T, cfg *Config) *scraper {
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// cgroupMetrics indexes the metrics scraped for each cgroup by cgroup path and metric name.
+func cgroupMetrics(t *testing.T, md pmetric.Metrics) map[string]map[string]pmetric.Metric {
+	cgroups := map[string]map[string]pmetric.Metric{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		path, ok := rm.Resource().Attributes().Get("cgroup.path")
+		require.True(t, ok)
+		metrics := map[string]pmetric.Metric{}
+		ms := rm.ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			metrics[ms.At(j).Name()] = ms.At(j)
+		}
+		cgroups[path.Str()] = metrics
+	}
+	return cgroups
+}
+
+// sumValues indexes the values of the data points of a sum by their attribute values.
+func sumValues(metric pmetric.Metric) map[string]float64 {
+	values := map[string]float64{}
+	dps := metric.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := ""
+		dp.Attributes().Range(func(_ string, v pcommon.Value) bool {
+			key += "/" + v.AsString()
+			return true
+		})
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			values[key] = float64(dp.IntValue())
+		} else {
+			values[key] = dp.DoubleValue()
+		}
+	}
+	return values
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, newTestConfig("testdata"))
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	cgroups := cgroupMetrics(t, md)
+	// The nested cgroup is deeper than the default max depth.
+	assert.Len(t, cgroups, 4)
+	require.Contains(t, cgroups, "/")
+	require.Contains(t, cgroups, "/system.slice")
+	require.Contains(t, cgroups, "/user.slice")
+	require.Contains(t, cgroups, "/system.slice/docker-abc.scope")
+
+	root := cgroups["/"]
+	assert.Len(t, root, 2)
+	assert.Equal(t, map[string]float64{"/user": 60, "/system": 30}, sumValues(root["cgroup.cpu.time"]))
+	assert.Equal(t, map[string]float64{"/cpu/some": 1, "/cpu/full": 0}, sumValues(root["cgroup.pressure.stall_time"]))
+
+	// memory.max is set to max, so the limit isn't reported.
+	systemSlice := cgroups["/system.slice"]
+	assert.NotContains(t, systemSlice, "cgroup.memory.limit")
+	assert.Equal(t, map[string]float64{"": 209715200}, sumValues(systemSlice["cgroup.memory.usage"]))
+
+	container := cgroups["/system.slice/docker-abc.scope"]
+	assert.Len(t, container, 10)
+	assert.Equal(t, map[string]float64{"/user": 2, "/system": 1}, sumValues(container["cgroup.cpu.time"]))
+	assert.Equal(t, map[string]float64{"": 100}, sumValues(container["cgroup.cpu.periods"]))
+	assert.Equal(t, map[string]float64{"": 25}, sumValues(container["cgroup.cpu.throttled.periods"]))
+	assert.Equal(t, map[string]float64{"": 0.5}, sumValues(container["cgroup.cpu.throttled.time"]))
+	assert.Equal(t, map[string]float64{"": 104857600}, sumValues(container["cgroup.memory.usage"]))
+	assert.Equal(t, map[string]float64{"": 268435456}, sumValues(container["cgroup.memory.limit"]))
+	assert.Equal(t, map[string]float64{"/low": 0, "/high": 0, "/max": 12, "/oom": 1, "/oom_kill": 1}, sumValues(container["cgroup.memory.events"]))
+	assert.Equal(t, map[string]float64{"/8:0/read": 4096, "/8:0/write": 8192}, sumValues(container["cgroup.io.bytes"]))
+	assert.Equal(t, map[string]float64{"/8:0/read": 1, "/8:0/write": 2}, sumValues(container["cgroup.io.operations"]))
+	assert.Equal(t, map[string]float64{"/memory/some": 2.5, "/memory/full": 0.5}, sumValues(container["cgroup.pressure.stall_time"]))
+}
+
+func TestScrapeFilters(t *testing.T) {
+	cfg := newTestConfig("testdata")
+	cfg.MaxDepth = 3
+	cfg.Include = MatchConfig{Config: filterset.Config{MatchType: filterset.Regexp}, Paths: []string{"^/system.slice/"}}
+	cfg.Exclude = MatchConfig{Config: filterset.Config{MatchType: filterset.Strict}, Paths: []string{"/system.slice/docker-abc.scope"}}
+	s := newTestScraper(t, cfg)
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	cgroups := cgroupMetrics(t, md)
+	assert.Len(t, cgroups, 1)
+	assert.Contains(t, cgroups, "/system.slice/docker-abc.scope/nested")
+}
+
+func TestScrapeErrors(t *testing.T) {
+	rootPath := t.TempDir()
+	dir := filepath.Join(rootPath, cgroupDir)
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cgroup.controllers"), []byte("cpu memory\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cpu.stat"), []byte("user_usec ten\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.current"), []byte("1024\n"), 0600))
+
+	s := newTestScraper(t, newTestConfig(rootPath))
+	md, err := s.scrape(context.Background())
+
+	require.Error(t, err)
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, cpuMetricsLen, partialErr.Failed)
+
+	cgroups := cgroupMetrics(t, md)
+	assert.Equal(t, map[string]float64{"": 1024}, sumValues(cgroups["/"]["cgroup.memory.usage"]))
+}
+
+func TestStartWithoutCgroupV2(t *testing.T) {
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), newTestConfig(t.TempDir()))
+	require.NoError(t, err)
+	assert.ErrorContains(t, s.start(context.Background(), componenttest.NewNopHost()), "cgroup v2 hierarchy not found")
+}
+
+func TestReadIOStat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "io.stat")
+	require.NoError(t, os.WriteFile(path, []byte("8:0 rbytes=1 wbytes=2 rios=3 wios=4 dbytes=5 dios=6\n253:1 rbytes=10\n"), 0600))
+
+	stats, err := readIOStat(path)
+	require.NoError(t, err)
+	assert.Equal(t, []ioStat{
+		{device: "8:0", rbytes: 1, wbytes: 2, rios: 3, wios: 4},
+		{device: "253:1", rbytes: 10},
+	}, stats)
+
+	require.NoError(t, os.WriteFile(path, []byte("8:0 rbytes\n"), 0600))
+	_, err = readIOStat(path)
+	assert.ErrorContains(t, err, `malformed field "rbytes"`)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cgroupscraper
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.IsType(t, &Config{}, cfg)
+}
+
+func TestCreateMetricsScraper(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{}
+
+	scraper, err := factory.CreateMetricsScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+
+	if runtime.GOOS == "linux" {
+		assert.NoError(t, err)
+		assert.NotNil(t, scraper)
+	} else {
+		assert.Error(t, err)
+		assert.Nil(t, scraper)
+	}
+}
+
+func TestCreateMetricsScraperInvalidFilter(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("cgroup scraper only available on Linux")
+	}
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Include = MatchConfig{Paths: []string{"/system.slice/*"}}
+
+	_, err := factory.CreateMetricsScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, err, "error creating cgroup include filters")
+
+	cfg = factory.CreateDefaultConfig().(*Config)
+	cfg.MaxDepth = -1
+	_, err = factory.CreateMetricsScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	assert.EqualError(t, err, "max_depth can't be negative, got -1")
+}
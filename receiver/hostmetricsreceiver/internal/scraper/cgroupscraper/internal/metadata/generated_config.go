@@ -0,0 +1,104 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import "go.opentelemetry.io/collector/confmap"
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for hostmetricsreceiver/cgroup metrics.
+type MetricsConfig struct {
+	CgroupCPUPeriods          MetricConfig `mapstructure:"cgroup.cpu.periods"`
+	CgroupCPUThrottledPeriods MetricConfig `mapstructure:"cgroup.cpu.throttled.periods"`
+	CgroupCPUThrottledTime    MetricConfig `mapstructure:"cgroup.cpu.throttled.time"`
+	CgroupCPUTime             MetricConfig `mapstructure:"cgroup.cpu.time"`
+	CgroupIoBytes             MetricConfig `mapstructure:"cgroup.io.bytes"`
+	CgroupIoOperations        MetricConfig `mapstructure:"cgroup.io.operations"`
+	CgroupMemoryEvents        MetricConfig `mapstructure:"cgroup.memory.events"`
+	CgroupMemoryLimit         MetricConfig `mapstructure:"cgroup.memory.limit"`
+	CgroupMemoryUsage         MetricConfig `mapstructure:"cgroup.memory.usage"`
+	CgroupPressureStallTime   MetricConfig `mapstructure:"cgroup.pressure.stall_time"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		CgroupCPUPeriods: MetricConfig{
+			Enabled: true,
+		},
+		CgroupCPUThrottledPeriods: MetricConfig{
+			Enabled: true,
+		},
+		CgroupCPUThrottledTime: MetricConfig{
+			Enabled: true,
+		},
+		CgroupCPUTime: MetricConfig{
+			Enabled: true,
+		},
+		CgroupIoBytes: MetricConfig{
+			Enabled: true,
+		},
+		CgroupIoOperations: MetricConfig{
+			Enabled: true,
+		},
+		CgroupMemoryEvents: MetricConfig{
+			Enabled: true,
+		},
+		CgroupMemoryLimit: MetricConfig{
+			Enabled: true,
+		},
+		CgroupMemoryUsage: MetricConfig{
+			Enabled: true,
+		},
+		CgroupPressureStallTime: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ResourceAttributesConfig provides config for hostmetricsreceiver/cgroup resource attributes.
+type ResourceAttributesConfig struct {
+	CgroupPath ResourceAttributeConfig `mapstructure:"cgroup.path"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		CgroupPath: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for hostmetricsreceiver/cgroup metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
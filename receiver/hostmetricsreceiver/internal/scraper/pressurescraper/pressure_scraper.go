@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/common"
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/psi"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const (
+	// pressureDir is the directory holding the pressure stall information of the host.
+	pressureDir = "/proc/pressure"
+
+	// metricsLen is the number of metrics recorded per resource.
+	metricsLen = 2
+)
+
+// resources maps the pressure stall information files to the resource they report on. The irq file is
+// only available since Linux 6.1, so its absence isn't reported as an error.
+var resources = []struct {
+	file     string
+	resource metadata.AttributeResource
+	optional bool
+}{
+	{file: "cpu", resource: metadata.AttributeResourceCpu},
+	{file: "memory", resource: metadata.AttributeResourceMemory},
+	{file: "io", resource: metadata.AttributeResourceIo},
+	{file: "irq", resource: metadata.AttributeResourceIrq, optional: true},
+}
+
+// scraper for Pressure Metrics
+type scraper struct {
+	settings receiver.CreateSettings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+	dir      string
+
+	// for mocking
+	bootTime func(context.Context) (uint64, error)
+}
+
+// newPressureScraper creates a scraper reading the pressure stall information below the root path.
+func newPressureScraper(_ context.Context, settings receiver.CreateSettings, cfg *Config) *scraper {
+	return &scraper{
+		settings: settings,
+		config:   cfg,
+		dir:      filepath.Join(cfg.RootPath, pressureDir),
+		bootTime: host.BootTimeWithContext,
+	}
+}
+
+func (s *scraper) start(ctx context.Context, _ component.Host) error {
+	if _, err := os.Stat(s.dir); err != nil {
+		return fmt.Errorf("pressure stall information is not available, it requires Linux 4.20 built with CONFIG_PSI: %w", err)
+	}
+
+	ctx = context.WithValue(ctx, common.EnvKey, s.config.EnvMap)
+	bootTime, err := s.bootTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) scrape(context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	var errs scrapererror.ScrapeErrors
+	for _, r := range resources {
+		stats, err := psi.ReadFile(filepath.Join(s.dir, r.file))
+		if err != nil {
+			if !r.optional || !errors.Is(err, fs.ErrNotExist) {
+				errs.AddPartial(metricsLen, err)
+			}
+			continue
+		}
+		s.recordStats(now, r.resource, metadata.AttributeScopeSome, stats.Some)
+		if stats.Full != nil {
+			s.recordStats(now, r.resource, metadata.AttributeScopeFull, stats.Full)
+		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) recordStats(now pcommon.Timestamp, resource metadata.AttributeResource, scope metadata.AttributeScope, line *psi.Line) {
+	s.mb.RecordSystemPressureStallTimeDataPoint(now, line.Total.Seconds(), resource, scope)
+	// The kernel reports the averages as percentages.
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, line.Avg10/100, resource, scope, metadata.AttributeWindow10s)
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, line.Avg60/100, resource, scope, metadata.AttributeWindow60s)
+	s.mb.RecordSystemPressureStallRatioDataPoint(now, line.Avg300/100, resource, scope, metadata.AttributeWindow300s)
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pressurescraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const bootTime = 100
+
+func newTestScraper(t *testing.T, rootPath string) *scraper {
+	cfg := &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		ScraperConfig:        internal.ScraperConfig{RootPath: rootPath},
+	}
+	s := newPressureScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	s.bootTime = func(context.Context) (uint64, error) { return bootTime, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// dataPoints indexes the data points of a metric by their attributes.
+func dataPoints(t *testing.T, metrics pmetric.MetricSlice, name string) map[string]pmetric.NumberDataPoint {
+	points := map[string]pmetric.NumberDataPoint{}
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		if metric.Name() != name {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		if metric.Type() == pmetric.MetricTypeSum {
+			assert.True(t, metric.Sum().IsMonotonic())
+			dps = metric.Sum().DataPoints()
+		} else {
+			dps = metric.Gauge().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			key := ""
+			for _, attr := range []string{"resource", "scope", "window"} {
+				if v, ok := dp.Attributes().Get(attr); ok {
+					key += "/" + v.Str()
+				}
+			}
+			points[key] = dp
+		}
+	}
+	return points
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, "testdata")
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, 2, metrics.Len())
+
+	stallTime := dataPoints(t, metrics, "system.pressure.stall_time")
+	assert.Len(t, stallTime, 6)
+	assert.Equal(t, 3.0, stallTime["/cpu/some"].DoubleValue())
+	assert.Equal(t, 0.0, stallTime["/cpu/full"].DoubleValue())
+	assert.Equal(t, 1.5, stallTime["/memory/some"].DoubleValue())
+	assert.Equal(t, 1.0, stallTime["/memory/full"].DoubleValue())
+	assert.Equal(t, 0.25, stallTime["/io/some"].DoubleValue())
+	assert.Equal(t, 0.2, stallTime["/io/full"].DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), stallTime["/cpu/some"].StartTimestamp())
+
+	stallRatio := dataPoints(t, metrics, "system.pressure.stall_ratio")
+	assert.Len(t, stallRatio, 18)
+	assert.Equal(t, 0.125, stallRatio["/cpu/some/10s"].DoubleValue())
+	assert.Equal(t, 0.08, stallRatio["/cpu/some/60s"].DoubleValue())
+	assert.Equal(t, 0.02, stallRatio["/cpu/some/300s"].DoubleValue())
+	assert.Equal(t, 0.0025, stallRatio["/memory/full/300s"].DoubleValue())
+}
+
+func TestScrapeErrors(t *testing.T) {
+	rootPath := t.TempDir()
+	dir := filepath.Join(rootPath, pressureDir)
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cpu"), []byte("some avg10=0.00 avg60=0.00 avg300=0.00 total=1000000\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "memory"), []byte("invalid\n"), 0600))
+
+	s := newTestScraper(t, rootPath)
+	md, err := s.scrape(context.Background())
+
+	// memory can't be parsed and io is missing, the missing irq file isn't an error.
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, 2*metricsLen, partialErr.Failed)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Len(t, dataPoints(t, metrics, "system.pressure.stall_time"), 1)
+}
+
+func TestStartWithoutPressure(t *testing.T) {
+	cfg := &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		ScraperConfig:        internal.ScraperConfig{RootPath: t.TempDir()},
+	}
+	s := newPressureScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, s.start(context.Background(), componenttest.NewNopHost()), "pressure stall information is not available")
+}
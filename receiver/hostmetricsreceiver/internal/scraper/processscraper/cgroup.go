@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/common"
+)
+
+// containerIDPrefixes are the prefixes of the cgroup names container runtimes create through systemd.
+var containerIDPrefixes = []string{"docker-", "cri-containerd-", "crio-", "libpod-"}
+
+var containerIDRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// hostProc returns the proc filesystem of the host, which is mounted below the root path
+// when the collector runs in a container.
+func hostProc(ctx context.Context) string {
+	if env, ok := ctx.Value(common.EnvKey).(common.EnvMap); ok {
+		if proc, ok := env[common.HostProcEnvKey]; ok && proc != "" {
+			return proc
+		}
+	}
+	if proc := os.Getenv(string(common.HostProcEnvKey)); proc != "" {
+		return proc
+	}
+	return "/proc"
+}
+
+// parseProcessCgroup returns the cgroup path of a process from its proc/[pid]/cgroup file.
+// The cgroup v2 entry, with a hierarchy ID of 0, is preferred over the cgroup v1 ones.
+func parseProcessCgroup(r io.Reader) (string, error) {
+	var cgroupV1 string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			return "", fmt.Errorf("malformed cgroup entry %q", scanner.Text())
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			return fields[2], nil
+		}
+		if cgroupV1 == "" {
+			cgroupV1 = fields[2]
+		}
+	}
+	return cgroupV1, scanner.Err()
+}
+
+// containerID returns the ID of the container owning the cgroup, or an empty string when the cgroup
+// path doesn't end with a container ID, e.g. /docker/<id> or /system.slice/docker-<id>.scope.
+func containerID(cgroup string) string {
+	name := strings.TrimSuffix(path.Base(cgroup), ".scope")
+	for _, prefix := range containerIDPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			name = strings.TrimPrefix(name, prefix)
+			break
+		}
+	}
+	if !containerIDRegexp.MatchString(name) {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processscraper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcessCgroup(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		err      string
+	}{
+		{
+			name:     "cgroup v2",
+			content:  "0::/system.slice/ssh.service\n",
+			expected: "/system.slice/ssh.service",
+		},
+		{
+			name:     "cgroup v1",
+			content:  "12:memory:/docker/abc\n11:cpu,cpuacct:/docker/abc\n1:name=systemd:/docker/abc\n",
+			expected: "/docker/abc",
+		},
+		{
+			name:     "hybrid",
+			content:  "12:memory:/user.slice\n1:name=systemd:/user.slice/session-1.scope\n0::/user.slice/session-1.scope\n",
+			expected: "/user.slice/session-1.scope",
+		},
+		{
+			name:    "malformed",
+			content: "0:/\n",
+			err:     `malformed cgroup entry "0:/"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cgroup, err := parseProcessCgroup(strings.NewReader(tt.content))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cgroup)
+		})
+	}
+}
+
+func TestContainerID(t *testing.T) {
+	const id = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := map[string]string{
+		"/docker/" + id:                         id,
+		"/system.slice/docker-" + id + ".scope": id,
+		"/kubepods.slice/kubepods-pod1.slice/cri-containerd-" + id + ".scope": id,
+		"/kubepods/burstable/pod1/" + id:                                      id,
+		"/machine.slice/libpod-" + id + ".scope":                              id,
+		"/system.slice/ssh.service":                                           "",
+		"/docker/0123":                                                        "",
+		"/":                                                                   "",
+	}
+
+	for cgroup, expected := range tests {
+		assert.Equal(t, expected, containerID(cgroup), cgroup)
+	}
+}
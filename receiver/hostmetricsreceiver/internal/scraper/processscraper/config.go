@@ -16,8 +16,8 @@ type Config struct {
 	// MetricsBuilderConfig allows to customize scraped metrics/attributes representation.
 	metadata.MetricsBuilderConfig `mapstructure:",squash"`
 	internal.ScraperConfig
-	// Include specifies a filter on the process names that should be included from the generated metrics.
-	// Exclude specifies a filter on the process names that should be excluded from the generated metrics.
+	// Include specifies a filter on the process names and cgroups that should be included from the generated metrics.
+	// Exclude specifies a filter on the process names and cgroups that should be excluded from the generated metrics.
 	// If neither `include` or `exclude` are set, process metrics will be generated for all processes.
 	Include MatchConfig `mapstructure:"include"`
 	Exclude MatchConfig `mapstructure:"exclude"`
@@ -44,4 +44,8 @@ type MatchConfig struct {
 	filterset.Config `mapstructure:",squash"`
 
 	Names []string `mapstructure:"names"`
+
+	// Cgroups are matched against the cgroup path of the processes, e.g. /kubepods.slice/*.
+	// A process must match both the names and the cgroups when they are set.
+	Cgroups []string `mapstructure:"cgroups"`
 }
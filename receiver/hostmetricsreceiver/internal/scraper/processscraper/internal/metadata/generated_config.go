@@ -34,6 +34,7 @@ type MetricsConfig struct {
 	ProcessMemoryUsage         MetricConfig `mapstructure:"process.memory.usage"`
 	ProcessMemoryUtilization   MetricConfig `mapstructure:"process.memory.utilization"`
 	ProcessMemoryVirtual       MetricConfig `mapstructure:"process.memory.virtual"`
+	ProcessNetworkConnections  MetricConfig `mapstructure:"process.network.connections"`
 	ProcessOpenFileDescriptors MetricConfig `mapstructure:"process.open_file_descriptors"`
 	ProcessPagingFaults        MetricConfig `mapstructure:"process.paging.faults"`
 	ProcessSignalsPending      MetricConfig `mapstructure:"process.signals_pending"`
@@ -69,6 +70,9 @@ func DefaultMetricsConfig() MetricsConfig {
 		ProcessMemoryVirtual: MetricConfig{
 			Enabled: true,
 		},
+		ProcessNetworkConnections: MetricConfig{
+			Enabled: false,
+		},
 		ProcessOpenFileDescriptors: MetricConfig{
 			Enabled: false,
 		},
@@ -91,6 +95,8 @@ type ResourceAttributeConfig struct {
 
 // ResourceAttributesConfig provides config for hostmetricsreceiver/process resource attributes.
 type ResourceAttributesConfig struct {
+	CgroupPath            ResourceAttributeConfig `mapstructure:"cgroup.path"`
+	ContainerID           ResourceAttributeConfig `mapstructure:"container.id"`
 	ProcessCommand        ResourceAttributeConfig `mapstructure:"process.command"`
 	ProcessCommandLine    ResourceAttributeConfig `mapstructure:"process.command_line"`
 	ProcessExecutableName ResourceAttributeConfig `mapstructure:"process.executable.name"`
@@ -102,6 +108,12 @@ type ResourceAttributesConfig struct {
 
 func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 	return ResourceAttributesConfig{
+		CgroupPath: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		ContainerID: ResourceAttributeConfig{
+			Enabled: false,
+		},
 		ProcessCommand: ResourceAttributeConfig{
 			Enabled: true,
 		},
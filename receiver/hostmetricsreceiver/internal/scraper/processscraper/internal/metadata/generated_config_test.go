@@ -35,12 +35,15 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					ProcessMemoryUsage:         MetricConfig{Enabled: true},
 					ProcessMemoryUtilization:   MetricConfig{Enabled: true},
 					ProcessMemoryVirtual:       MetricConfig{Enabled: true},
+					ProcessNetworkConnections:  MetricConfig{Enabled: true},
 					ProcessOpenFileDescriptors: MetricConfig{Enabled: true},
 					ProcessPagingFaults:        MetricConfig{Enabled: true},
 					ProcessSignalsPending:      MetricConfig{Enabled: true},
 					ProcessThreads:             MetricConfig{Enabled: true},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
+					CgroupPath:            ResourceAttributeConfig{Enabled: true},
+					ContainerID:           ResourceAttributeConfig{Enabled: true},
 					ProcessCommand:        ResourceAttributeConfig{Enabled: true},
 					ProcessCommandLine:    ResourceAttributeConfig{Enabled: true},
 					ProcessExecutableName: ResourceAttributeConfig{Enabled: true},
@@ -64,12 +67,15 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					ProcessMemoryUsage:         MetricConfig{Enabled: false},
 					ProcessMemoryUtilization:   MetricConfig{Enabled: false},
 					ProcessMemoryVirtual:       MetricConfig{Enabled: false},
+					ProcessNetworkConnections:  MetricConfig{Enabled: false},
 					ProcessOpenFileDescriptors: MetricConfig{Enabled: false},
 					ProcessPagingFaults:        MetricConfig{Enabled: false},
 					ProcessSignalsPending:      MetricConfig{Enabled: false},
 					ProcessThreads:             MetricConfig{Enabled: false},
 				},
 				ResourceAttributes: ResourceAttributesConfig{
+					CgroupPath:            ResourceAttributeConfig{Enabled: false},
+					ContainerID:           ResourceAttributeConfig{Enabled: false},
 					ProcessCommand:        ResourceAttributeConfig{Enabled: false},
 					ProcessCommandLine:    ResourceAttributeConfig{Enabled: false},
 					ProcessExecutableName: ResourceAttributeConfig{Enabled: false},
@@ -113,6 +119,8 @@ func TestResourceAttributesConfig(t *testing.T) {
 		{
 			name: "all_set",
 			want: ResourceAttributesConfig{
+				CgroupPath:            ResourceAttributeConfig{Enabled: true},
+				ContainerID:           ResourceAttributeConfig{Enabled: true},
 				ProcessCommand:        ResourceAttributeConfig{Enabled: true},
 				ProcessCommandLine:    ResourceAttributeConfig{Enabled: true},
 				ProcessExecutableName: ResourceAttributeConfig{Enabled: true},
@@ -125,6 +133,8 @@ func TestResourceAttributesConfig(t *testing.T) {
 		{
 			name: "none_set",
 			want: ResourceAttributesConfig{
+				CgroupPath:            ResourceAttributeConfig{Enabled: false},
+				ContainerID:           ResourceAttributeConfig{Enabled: false},
 				ProcessCommand:        ResourceAttributeConfig{Enabled: false},
 				ProcessCommandLine:    ResourceAttributeConfig{Enabled: false},
 				ProcessExecutableName: ResourceAttributeConfig{Enabled: false},
@@ -21,6 +21,20 @@ func NewResourceBuilder(rac ResourceAttributesConfig) *ResourceBuilder {
 	}
 }
 
+// SetCgroupPath sets provided value as "cgroup.path" attribute.
+func (rb *ResourceBuilder) SetCgroupPath(val string) {
+	if rb.config.CgroupPath.Enabled {
+		rb.res.Attributes().PutStr("cgroup.path", val)
+	}
+}
+
+// SetContainerID sets provided value as "container.id" attribute.
+func (rb *ResourceBuilder) SetContainerID(val string) {
+	if rb.config.ContainerID.Enabled {
+		rb.res.Attributes().PutStr("container.id", val)
+	}
+}
+
 // SetProcessCommand sets provided value as "process.command" attribute.
 func (rb *ResourceBuilder) SetProcessCommand(val string) {
 	if rb.config.ProcessCommand.Enabled {
@@ -28,6 +28,7 @@ type processMetadata struct {
 	username   string
 	handle     processHandle
 	createTime int64
+	cgroup     string
 }
 
 type executableMetadata struct {
@@ -59,6 +60,12 @@ func (m *processMetadata) buildResource(rb *metadata.ResourceBuilder) pcommon.Re
 	if m.username != "" {
 		rb.SetProcessOwner(m.username)
 	}
+	if m.cgroup != "" {
+		rb.SetCgroupPath(m.cgroup)
+		if id := containerID(m.cgroup); id != "" {
+			rb.SetContainerID(id)
+		}
+	}
 	return rb.Emit()
 }
 
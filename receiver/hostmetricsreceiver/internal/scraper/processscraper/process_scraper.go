@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/shirou/gopsutil/v3/common"
+	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -35,10 +36,23 @@ const (
 	fileDescriptorMetricsLen    = 1
 	handleMetricsLen            = 1
 	signalMetricsLen            = 1
+	connectionMetricsLen        = 1
 
 	metricsLen = cpuMetricsLen + memoryMetricsLen + diskMetricsLen + memoryUtilizationMetricsLen + pagingMetricsLen + threadMetricsLen + contextSwitchMetricsLen + fileDescriptorMetricsLen + signalMetricsLen
 )
 
+// Socket types of the connections reported by gopsutil, the same on all the supported platforms.
+const (
+	sockStream = 1
+	sockDgram  = 2
+)
+
+// connectionKey identifies the connections of a process counted together.
+type connectionKey struct {
+	protocol metadata.AttributeProtocol
+	state    string
+}
+
 // scraper for Process Metrics
 type scraper struct {
 	settings           receiver.CreateSettings
@@ -46,12 +60,16 @@ type scraper struct {
 	mb                 *metadata.MetricsBuilder
 	includeFS          filterset.FilterSet
 	excludeFS          filterset.FilterSet
+	includeCgroupFS    filterset.FilterSet
+	excludeCgroupFS    filterset.FilterSet
 	scrapeProcessDelay time.Duration
 	ucals              map[int32]*ucal.CPUUtilizationCalculator
 
 	// for mocking
 	getProcessCreateTime func(p processHandle) (int64, error)
 	getProcessHandles    func(context.Context) (processHandles, error)
+	getProcessCgroup     func(context.Context, int32) (string, error)
+	getConnections       func(context.Context) ([]net.ConnectionStat, error)
 
 	handleCountManager handlecount.Manager
 }
@@ -63,6 +81,8 @@ func newProcessScraper(settings receiver.CreateSettings, cfg *Config) (*scraper,
 		config:               cfg,
 		getProcessCreateTime: processHandle.CreateTime,
 		getProcessHandles:    getProcessHandlesInternal,
+		getProcessCgroup:     getProcessCgroup,
+		getConnections:       getConnectionsInternal,
 		scrapeProcessDelay:   cfg.ScrapeProcessDelay,
 		ucals:                make(map[int32]*ucal.CPUUtilizationCalculator),
 		handleCountManager:   handlecount.NewManager(),
@@ -84,6 +104,20 @@ func newProcessScraper(settings receiver.CreateSettings, cfg *Config) (*scraper,
 		}
 	}
 
+	if len(cfg.Include.Cgroups) > 0 {
+		scraper.includeCgroupFS, err = filterset.CreateFilterSet(cfg.Include.Cgroups, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating process cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Cgroups) > 0 {
+		scraper.excludeCgroupFS, err = filterset.CreateFilterSet(cfg.Exclude.Cgroups, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating process cgroup exclude filters: %w", err)
+		}
+	}
+
 	return scraper, nil
 }
 
@@ -105,6 +139,11 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 		errs.AddPartial(partialErr.Failed, partialErr)
 	}
 
+	connections, err := s.getProcessConnections()
+	if err != nil {
+		errs.AddPartial(connectionMetricsLen, fmt.Errorf("error reading network connections: %w", err))
+	}
+
 	presentPIDs := make(map[int32]struct{}, len(data))
 
 	for _, md := range data {
@@ -152,6 +191,8 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 			errs.AddPartial(signalMetricsLen, fmt.Errorf("error reading pending signals for process %q (pid %v): %w", md.executable.name, md.pid, err))
 		}
 
+		s.appendConnectionsMetric(now, connections[md.pid])
+
 		s.mb.EmitForResource(metadata.WithResource(md.buildResource(s.mb.NewResourceBuilder())),
 			metadata.WithStartTimeOverride(pcommon.Timestamp(md.createTime*1e6)))
 	}
@@ -211,6 +252,20 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 			continue
 		}
 
+		var cgroup string
+		if s.needsCgroup() {
+			cgroup, err = s.getProcessCgroup(ctx, pid)
+			if err != nil {
+				errs.AddPartial(0, fmt.Errorf("error reading cgroup for process %q (pid %v): %w", executable.name, pid, err))
+			}
+
+			// filter processes by cgroup
+			if (s.includeCgroupFS != nil && !s.includeCgroupFS.Matches(cgroup)) ||
+				(s.excludeCgroupFS != nil && s.excludeCgroupFS.Matches(cgroup)) {
+				continue
+			}
+		}
+
 		command, err := getProcessCommand(handle)
 		if err != nil {
 			errs.AddPartial(0, fmt.Errorf("error reading command for process %q (pid %v): %w", executable.name, pid, err))
@@ -244,6 +299,7 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 			username:   username,
 			handle:     handle,
 			createTime: createTime,
+			cgroup:     cgroup,
 		}
 
 		data = append(data, md)
@@ -252,6 +308,14 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 	return data, errs.Combine()
 }
 
+// needsCgroup returns whether the cgroup of the processes is read, to filter them or set the
+// cgroup resource attributes.
+func (s *scraper) needsCgroup() bool {
+	resourceAttributes := s.config.MetricsBuilderConfig.ResourceAttributes
+	return s.includeCgroupFS != nil || s.excludeCgroupFS != nil ||
+		resourceAttributes.CgroupPath.Enabled || resourceAttributes.ContainerID.Enabled
+}
+
 func (s *scraper) scrapeAndAppendCPUTimeMetric(now pcommon.Timestamp, handle processHandle, pid int32) error {
 	if !s.config.MetricsBuilderConfig.Metrics.ProcessCPUTime.Enabled && !s.config.MetricsBuilderConfig.Metrics.ProcessCPUUtilization.Enabled {
 		return nil
@@ -433,3 +497,46 @@ func (s *scraper) scrapeAndAppendSignalsPendingMetric(now pcommon.Timestamp, han
 
 	return nil
 }
+
+func getConnectionsInternal(ctx context.Context) ([]net.ConnectionStat, error) {
+	return net.ConnectionsWithContext(ctx, "inet")
+}
+
+// getProcessConnections lists the sockets of all the processes at once, which is cheaper than listing
+// them for each process, and counts the connections of each process by protocol and state.
+func (s *scraper) getProcessConnections() (map[int32]map[connectionKey]int64, error) {
+	if !s.config.MetricsBuilderConfig.Metrics.ProcessNetworkConnections.Enabled {
+		return nil, nil
+	}
+
+	ctx := context.WithValue(context.Background(), common.EnvKey, s.config.EnvMap)
+	connections, err := s.getConnections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int32]map[connectionKey]int64)
+	for _, connection := range connections {
+		var protocol metadata.AttributeProtocol
+		switch connection.Type {
+		case sockStream:
+			protocol = metadata.AttributeProtocolTcp
+		case sockDgram:
+			protocol = metadata.AttributeProtocolUdp
+		default:
+			continue
+		}
+
+		if counts[connection.Pid] == nil {
+			counts[connection.Pid] = make(map[connectionKey]int64)
+		}
+		counts[connection.Pid][connectionKey{protocol: protocol, state: connection.Status}]++
+	}
+	return counts, nil
+}
+
+func (s *scraper) appendConnectionsMetric(now pcommon.Timestamp, connections map[connectionKey]int64) {
+	for key, count := range connections {
+		s.mb.RecordProcessNetworkConnectionsDataPoint(now, count, key.protocol, key.state)
+	}
+}
@@ -7,6 +7,7 @@
 package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 
 import (
+	"context"
 	"regexp"
 
 	"github.com/shirou/gopsutil/v3/cpu"
@@ -58,3 +59,7 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	return command, nil
 
 }
+
+func getProcessCgroup(context.Context, int32) (string, error) {
+	return "", nil
+}
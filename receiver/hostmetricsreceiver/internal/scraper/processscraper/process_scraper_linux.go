@@ -7,6 +7,11 @@
 package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+
 	"github.com/shirou/gopsutil/v3/cpu"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
@@ -58,3 +63,13 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLineSlice: cmdline}
 	return command, nil
 }
+
+func getProcessCgroup(ctx context.Context, pid int32) (string, error) {
+	file, err := os.Open(filepath.Join(hostProc(ctx), strconv.Itoa(int(pid)), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return parseProcessCgroup(file)
+}
@@ -7,6 +7,8 @@
 package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 
 import (
+	"context"
+
 	"github.com/shirou/gopsutil/v3/cpu"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
@@ -28,3 +30,7 @@ func getProcessExecutable(processHandle) (string, error) {
 func getProcessCommand(processHandle) (*commandMetadata, error) {
 	return nil, nil
 }
+
+func getProcessCgroup(context.Context, int32) (string, error) {
+	return "", nil
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/net"
 	"github.com/shirou/gopsutil/v3/process"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -1171,3 +1172,179 @@ func TestScrapeMetrics_CpuUtilizationWhenCpuTimesIsDisabled(t *testing.T) {
 	}
 
 }
+
+func TestScrapeMetrics_Cgroup(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	const containerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	cgroups := map[string]string{
+		"nginx":   "/system.slice/docker-" + containerID + ".scope",
+		"sshd":    "/system.slice/ssh.service",
+		"unknown": "",
+	}
+
+	type testCase struct {
+		name          string
+		include       []string
+		exclude       []string
+		expectedNames []string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "No Filter",
+			expectedNames: []string{"nginx", "sshd", "unknown"},
+		},
+		{
+			name:          "Include",
+			include:       []string{`^/system\.slice/docker-`},
+			expectedNames: []string{"nginx"},
+		},
+		{
+			name:          "Exclude",
+			exclude:       []string{`^/system\.slice/`},
+			expectedNames: []string{"unknown"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			metricsBuilderConfig := metadata.DefaultMetricsBuilderConfig()
+			metricsBuilderConfig.ResourceAttributes.CgroupPath.Enabled = true
+			metricsBuilderConfig.ResourceAttributes.ContainerID.Enabled = true
+			config := &Config{MetricsBuilderConfig: metricsBuilderConfig}
+			if len(test.include) > 0 {
+				config.Include = MatchConfig{Cgroups: test.include, Config: filterset.Config{MatchType: filterset.Regexp}}
+			}
+			if len(test.exclude) > 0 {
+				config.Exclude = MatchConfig{Cgroups: test.exclude, Config: filterset.Config{MatchType: filterset.Regexp}}
+			}
+
+			scraper, err := newProcessScraper(receivertest.NewNopCreateSettings(), config)
+			require.NoError(t, err)
+			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+			var names []string
+			handles := make([]*processHandleMock, 0, len(cgroups))
+			for _, name := range []string{"nginx", "sshd", "unknown"} {
+				handleMock := newDefaultHandleMock()
+				handleMock.On("Name").Return(name, nil)
+				handleMock.On("Exe").Return(name, nil)
+				handleMock.On("CreateTime").Return(time.Now().UnixMilli(), nil)
+				handles = append(handles, handleMock)
+				names = append(names, name)
+			}
+			var i int
+			scraper.getProcessCgroup = func(context.Context, int32) (string, error) {
+				name := names[i]
+				i++
+				if cgroups[name] == "" {
+					return "", errors.New("no such file or directory")
+				}
+				return cgroups[name], nil
+			}
+			scraper.getProcessHandles = func(context.Context) (processHandles, error) {
+				return &processHandlesMock{handles: handles}, nil
+			}
+
+			md, err := scraper.scrape(context.Background())
+			require.Error(t, err)
+			assert.ErrorContains(t, err, `error reading cgroup for process "unknown"`)
+
+			require.Equal(t, len(test.expectedNames), md.ResourceMetrics().Len())
+			for i, expectedName := range test.expectedNames {
+				attrs := md.ResourceMetrics().At(i).Resource().Attributes()
+				name, _ := attrs.Get(conventions.AttributeProcessExecutableName)
+				assert.Equal(t, expectedName, name.Str())
+
+				cgroup, ok := attrs.Get("cgroup.path")
+				assert.Equal(t, cgroups[expectedName] != "", ok)
+				if ok {
+					assert.Equal(t, cgroups[expectedName], cgroup.Str())
+				}
+				id, ok := attrs.Get("container.id")
+				assert.Equal(t, expectedName == "nginx", ok)
+				if ok {
+					assert.Equal(t, containerID, id.Str())
+				}
+			}
+		})
+	}
+}
+
+func TestScrapeMetrics_CgroupNotRead(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper, err := newProcessScraper(receivertest.NewNopCreateSettings(), &Config{MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig()})
+	require.NoError(t, err)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	handleMock := newDefaultHandleMock()
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("CreateTime").Return(time.Now().UnixMilli(), nil)
+	scraper.getProcessHandles = func(context.Context) (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+	scraper.getProcessCgroup = func(context.Context, int32) (string, error) {
+		t.Fatal("the cgroup is read while it isn't used")
+		return "", nil
+	}
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	_, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("cgroup.path")
+	assert.False(t, ok)
+}
+
+func TestScrapeMetrics_NetworkConnections(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	metricsBuilderConfig := metadata.DefaultMetricsBuilderConfig()
+	metricsBuilderConfig.Metrics.ProcessNetworkConnections.Enabled = true
+	scraper, err := newProcessScraper(receivertest.NewNopCreateSettings(), &Config{MetricsBuilderConfig: metricsBuilderConfig})
+	require.NoError(t, err)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	handleMock := newDefaultHandleMock()
+	handleMock.On("Name").Return("test", nil)
+	handleMock.On("Exe").Return("test", nil)
+	handleMock.On("CreateTime").Return(time.Now().UnixMilli(), nil)
+	scraper.getProcessHandles = func(context.Context) (processHandles, error) {
+		return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
+	}
+	// processHandlesMock reports all the processes with the pid 1.
+	scraper.getConnections = func(context.Context) ([]net.ConnectionStat, error) {
+		return []net.ConnectionStat{
+			{Pid: 1, Type: sockStream, Status: "ESTABLISHED"},
+			{Pid: 1, Type: sockStream, Status: "ESTABLISHED"},
+			{Pid: 1, Type: sockStream, Status: "LISTEN"},
+			{Pid: 1, Type: sockDgram, Status: "NONE"},
+			{Pid: 2, Type: sockStream, Status: "ESTABLISHED"},
+		}, nil
+	}
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	metric := getMetric(t, "process.network.connections", md.ResourceMetrics())
+	counts := map[string]int64{}
+	for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+		dp := metric.Sum().DataPoints().At(i)
+		protocol, _ := dp.Attributes().Get("protocol")
+		state, _ := dp.Attributes().Get("state")
+		counts[protocol.Str()+"/"+state.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"tcp/ESTABLISHED": 2, "tcp/LISTEN": 1, "udp/NONE": 1}, counts)
+
+	scraper.getConnections = func(context.Context) ([]net.ConnectionStat, error) {
+		return nil, errors.New("permission denied")
+	}
+	md, err = scraper.scrape(context.Background())
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, connectionMetricsLen, partialErr.Failed)
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+	assertMetricMissing(t, md.ResourceMetrics(), "process.network.connections")
+}
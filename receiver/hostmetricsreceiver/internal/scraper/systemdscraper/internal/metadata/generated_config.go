@@ -0,0 +1,92 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import "go.opentelemetry.io/collector/confmap"
+
+// MetricConfig provides common config for a particular metric.
+type MetricConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsConfig provides config for hostmetricsreceiver/systemd metrics.
+type MetricsConfig struct {
+	SystemdServiceRestarts MetricConfig `mapstructure:"systemd.service.restarts"`
+	SystemdUnitCPUTime     MetricConfig `mapstructure:"systemd.unit.cpu.time"`
+	SystemdUnitIo          MetricConfig `mapstructure:"systemd.unit.io"`
+	SystemdUnitMemoryUsage MetricConfig `mapstructure:"systemd.unit.memory.usage"`
+	SystemdUnitState       MetricConfig `mapstructure:"systemd.unit.state"`
+	SystemdUnitSubState    MetricConfig `mapstructure:"systemd.unit.sub_state"`
+	SystemdUnitTasks       MetricConfig `mapstructure:"systemd.unit.tasks"`
+}
+
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		SystemdServiceRestarts: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitCPUTime: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitIo: MetricConfig{
+			Enabled: false,
+		},
+		SystemdUnitMemoryUsage: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitState: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitSubState: MetricConfig{
+			Enabled: true,
+		},
+		SystemdUnitTasks: MetricConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ResourceAttributesConfig provides config for hostmetricsreceiver/systemd resource attributes.
+type ResourceAttributesConfig struct {
+	SystemdUnitName ResourceAttributeConfig `mapstructure:"systemd.unit.name"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		SystemdUnitName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
+
+// MetricsBuilderConfig is a configuration for hostmetricsreceiver/systemd metrics builder.
+type MetricsBuilderConfig struct {
+	Metrics            MetricsConfig            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsConfig(),
+		ResourceAttributes: DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/shirou/gopsutil/v3/common"
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper/internal/metadata"
+)
+
+const accountingMetricsLen = 5
+
+// accountingInterfaces are the D-Bus interfaces, named after the unit types, exposing the resource
+// accounting properties of the units which have a cgroup.
+var accountingInterfaces = map[string]string{
+	"service": "Service",
+	"socket":  "Socket",
+	"mount":   "Mount",
+	"swap":    "Swap",
+	"slice":   "Slice",
+	"scope":   "Scope",
+}
+
+// systemdConn is the subset of the D-Bus connection to systemd used by the scraper.
+type systemdConn interface {
+	ListUnitsByPatternsContext(ctx context.Context, states []string, patterns []string) ([]dbus.UnitStatus, error)
+	GetUnitTypePropertiesContext(ctx context.Context, unit string, unitType string) (map[string]interface{}, error)
+	Close()
+}
+
+// scraper for Systemd Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	conn      systemdConn
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime func(context.Context) (uint64, error)
+	connect  func(context.Context) (systemdConn, error)
+}
+
+// newSystemdScraper creates a scraper reading the units of the systemd system manager over D-Bus.
+func newSystemdScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	if len(cfg.UnitTypes) == 0 {
+		return nil, errors.New("unit_types must not be empty")
+	}
+
+	scraper := &scraper{
+		settings: settings,
+		config:   cfg,
+		bootTime: host.BootTimeWithContext,
+		connect: func(ctx context.Context) (systemdConn, error) {
+			return dbus.NewWithContext(ctx)
+		},
+	}
+
+	var err error
+
+	if len(cfg.Include.Units) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Units, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating unit include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Units) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Units, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating unit exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *scraper) start(ctx context.Context, _ component.Host) error {
+	ctx = context.WithValue(ctx, common.EnvKey, s.config.EnvMap)
+	bootTime, err := s.bootTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.conn, err = s.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to systemd: %w", err)
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) shutdown(context.Context) error {
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	return nil
+}
+
+func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	patterns := make([]string, 0, len(s.config.UnitTypes))
+	for _, unitType := range s.config.UnitTypes {
+		patterns = append(patterns, "*."+unitType)
+	}
+	units, err := s.conn.ListUnitsByPatternsContext(ctx, nil, patterns)
+	if err != nil {
+		return pmetric.NewMetrics(), fmt.Errorf("failed to list systemd units: %w", err)
+	}
+
+	var errs scrapererror.ScrapeErrors
+	for _, unit := range units {
+		if !s.included(unit.Name) {
+			continue
+		}
+
+		s.recordUnitState(now, unit)
+		if err := s.recordAccounting(ctx, now, unit.Name); err != nil {
+			errs.AddPartial(accountingMetricsLen, err)
+		}
+
+		rb := s.mb.NewResourceBuilder()
+		rb.SetSystemdUnitName(unit.Name)
+		s.mb.EmitForResource(metadata.WithResource(rb.Emit()))
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) included(unit string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(unit)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(unit))
+}
+
+func (s *scraper) recordUnitState(now pcommon.Timestamp, unit dbus.UnitStatus) {
+	for name, state := range metadata.MapAttributeActiveState {
+		var value int64
+		if name == unit.ActiveState {
+			value = 1
+		}
+		s.mb.RecordSystemdUnitStateDataPoint(now, value, state)
+	}
+	s.mb.RecordSystemdUnitSubStateDataPoint(now, 1, unit.SubState)
+}
+
+// recordAccounting records the restart count and resource accounting of the unit. systemd reports
+// the accounting properties which are disabled or not available for the unit as the maximum uint64.
+func (s *scraper) recordAccounting(ctx context.Context, now pcommon.Timestamp, unit string) error {
+	unitType, ok := accountingInterfaces[unit[strings.LastIndexByte(unit, '.')+1:]]
+	if !ok {
+		return nil
+	}
+
+	props, err := s.conn.GetUnitTypePropertiesContext(ctx, unit, unitType)
+	if err != nil {
+		return fmt.Errorf("failed to read the properties of unit %s: %w", unit, err)
+	}
+
+	if restarts, ok := props["NRestarts"].(uint32); ok {
+		s.mb.RecordSystemdServiceRestartsDataPoint(now, int64(restarts))
+	}
+	if nsec, ok := accountingValue(props, "CPUUsageNSec"); ok {
+		s.mb.RecordSystemdUnitCPUTimeDataPoint(now, float64(nsec)/1e9)
+	}
+	if bytes, ok := accountingValue(props, "MemoryCurrent"); ok {
+		s.mb.RecordSystemdUnitMemoryUsageDataPoint(now, bytes)
+	}
+	if tasks, ok := accountingValue(props, "TasksCurrent"); ok {
+		s.mb.RecordSystemdUnitTasksDataPoint(now, tasks)
+	}
+	if bytes, ok := accountingValue(props, "IOReadBytes"); ok {
+		s.mb.RecordSystemdUnitIoDataPoint(now, bytes, metadata.AttributeDirectionRead)
+	}
+	if bytes, ok := accountingValue(props, "IOWriteBytes"); ok {
+		s.mb.RecordSystemdUnitIoDataPoint(now, bytes, metadata.AttributeDirectionWrite)
+	}
+	return nil
+}
+
+func accountingValue(props map[string]interface{}, name string) (int64, bool) {
+	value, ok := props[name].(uint64)
+	if !ok || value == math.MaxUint64 {
+		return 0, false
+	}
+	return int64(value), true
+}
@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package systemdscraper
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper/internal/metadata"
+)
+
+// fakeConn serves units and their properties in place of systemd.
+type fakeConn struct {
+	units    []dbus.UnitStatus
+	props    map[string]map[string]interface{}
+	patterns []string
+	listErr  error
+	closed   bool
+}
+
+func (c *fakeConn) ListUnitsByPatternsContext(_ context.Context, _ []string, patterns []string) ([]dbus.UnitStatus, error) {
+	c.patterns = patterns
+	return c.units, c.listErr
+}
+
+func (c *fakeConn) GetUnitTypePropertiesContext(_ context.Context, unit string, unitType string) (map[string]interface{}, error) {
+	props, ok := c.props[unit+"/"+unitType]
+	if !ok {
+		return nil, errors.New("org.freedesktop.systemd1.NoSuchUnit")
+	}
+	return props, nil
+}
+
+func (c *fakeConn) Close() {
+	c.closed = true
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		units: []dbus.UnitStatus{
+			{Name: "nginx.service", ActiveState: "active", SubState: "running"},
+			{Name: "backup.service", ActiveState: "inactive", SubState: "dead"},
+			{Name: "docker.socket", ActiveState: "active", SubState: "listening"},
+			{Name: "backup.timer", ActiveState: "active", SubState: "waiting"},
+		},
+		props: map[string]map[string]interface{}{
+			"nginx.service/Service": {
+				"NRestarts":     uint32(3),
+				"CPUUsageNSec":  uint64(1500000000),
+				"MemoryCurrent": uint64(4096),
+				"TasksCurrent":  uint64(5),
+				"IOReadBytes":   uint64(10),
+				"IOWriteBytes":  uint64(20),
+			},
+			"backup.service/Service": {
+				"NRestarts":     uint32(0),
+				"CPUUsageNSec":  uint64(math.MaxUint64),
+				"MemoryCurrent": uint64(math.MaxUint64),
+				"TasksCurrent":  uint64(math.MaxUint64),
+			},
+			"docker.socket/Socket": {
+				"CPUUsageNSec":  uint64(math.MaxUint64),
+				"MemoryCurrent": uint64(8192),
+				"TasksCurrent":  uint64(0),
+			},
+		},
+	}
+}
+
+func newTestScraper(t *testing.T, cfg *Config, conn systemdConn) *scraper {
+	s, err := newSystemdScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	s.connect = func(context.Context) (systemdConn, error) { return conn, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func newTestConfig(unitTypes ...string) *Config {
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.SystemdUnitIo.Enabled = true
+	return &Config{
+		MetricsBuilderConfig: mbc,
+		UnitTypes:            unitTypes,
+	}
+}
+
+// unitMetrics indexes the metrics scraped for each unit by unit name and metric name.
+func unitMetrics(t *testing.T, md pmetric.Metrics) map[string]map[string]pmetric.Metric {
+	units := map[string]map[string]pmetric.Metric{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		name, ok := rm.Resource().Attributes().Get("systemd.unit.name")
+		require.True(t, ok)
+		metrics := map[string]pmetric.Metric{}
+		ms := rm.ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			metrics[ms.At(j).Name()] = ms.At(j)
+		}
+		units[name.Str()] = metrics
+	}
+	return units
+}
+
+// sumValues indexes the values of the data points of a sum by their attribute values.
+func sumValues(metric pmetric.Metric) map[string]float64 {
+	values := map[string]float64{}
+	dps := metric.Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		key := ""
+		dp.Attributes().Range(func(_ string, v pcommon.Value) bool {
+			key += "/" + v.AsString()
+			return true
+		})
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			values[key] = float64(dp.IntValue())
+		} else {
+			values[key] = dp.DoubleValue()
+		}
+	}
+	return values
+}
+
+func TestScrape(t *testing.T) {
+	conn := newFakeConn()
+	s := newTestScraper(t, newTestConfig("service", "socket", "timer"), conn)
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.service", "*.socket", "*.timer"}, conn.patterns)
+
+	units := unitMetrics(t, md)
+	require.Len(t, units, 4)
+
+	nginx := units["nginx.service"]
+	assert.Equal(t, map[string]float64{
+		"/active":       1,
+		"/reloading":    0,
+		"/inactive":     0,
+		"/failed":       0,
+		"/activating":   0,
+		"/deactivating": 0,
+	}, sumValues(nginx["systemd.unit.state"]))
+	assert.Equal(t, map[string]float64{"/running": 1}, sumValues(nginx["systemd.unit.sub_state"]))
+	assert.Equal(t, map[string]float64{"": 3}, sumValues(nginx["systemd.service.restarts"]))
+	assert.Equal(t, map[string]float64{"": 1.5}, sumValues(nginx["systemd.unit.cpu.time"]))
+	assert.Equal(t, map[string]float64{"": 4096}, sumValues(nginx["systemd.unit.memory.usage"]))
+	assert.Equal(t, map[string]float64{"": 5}, sumValues(nginx["systemd.unit.tasks"]))
+	assert.Equal(t, map[string]float64{"/read": 10, "/write": 20}, sumValues(nginx["systemd.unit.io"]))
+
+	// The accounting of the stopped service is not available.
+	backup := units["backup.service"]
+	assert.Equal(t, float64(1), sumValues(backup["systemd.unit.state"])["/inactive"])
+	assert.Equal(t, map[string]float64{"": 0}, sumValues(backup["systemd.service.restarts"]))
+	assert.NotContains(t, backup, "systemd.unit.cpu.time")
+	assert.NotContains(t, backup, "systemd.unit.memory.usage")
+	assert.NotContains(t, backup, "systemd.unit.tasks")
+
+	socket := units["docker.socket"]
+	assert.NotContains(t, socket, "systemd.service.restarts")
+	assert.NotContains(t, socket, "systemd.unit.cpu.time")
+	assert.Equal(t, map[string]float64{"": 8192}, sumValues(socket["systemd.unit.memory.usage"]))
+	assert.Equal(t, map[string]float64{"": 0}, sumValues(socket["systemd.unit.tasks"]))
+
+	// Timers don't have a cgroup, only their state is reported.
+	assert.Len(t, units["backup.timer"], 2)
+	assert.Equal(t, map[string]float64{"/waiting": 1}, sumValues(units["backup.timer"]["systemd.unit.sub_state"]))
+
+	require.NoError(t, s.shutdown(context.Background()))
+	assert.True(t, conn.closed)
+}
+
+func TestScrapeFilters(t *testing.T) {
+	cfg := newTestConfig("service", "socket", "timer")
+	cfg.Include = MatchConfig{Config: filterset.Config{MatchType: filterset.Regexp}, Units: []string{`^backup\.`, `\.socket$`}}
+	cfg.Exclude = MatchConfig{Config: filterset.Config{MatchType: filterset.Strict}, Units: []string{"backup.timer"}}
+	s := newTestScraper(t, cfg, newFakeConn())
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	units := unitMetrics(t, md)
+	assert.Len(t, units, 2)
+	assert.Contains(t, units, "backup.service")
+	assert.Contains(t, units, "docker.socket")
+}
+
+func TestScrapeErrors(t *testing.T) {
+	conn := newFakeConn()
+	delete(conn.props, "backup.service/Service")
+	s := newTestScraper(t, newTestConfig("service"), conn)
+
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, accountingMetricsLen, partialErr.Failed)
+	assert.ErrorContains(t, err, "failed to read the properties of unit backup.service")
+
+	// The state of the unit is still reported.
+	units := unitMetrics(t, md)
+	assert.Contains(t, units["backup.service"], "systemd.unit.state")
+
+	conn.listErr = errors.New("connection closed")
+	_, err = s.scrape(context.Background())
+	assert.EqualError(t, err, "failed to list systemd units: connection closed")
+}
+
+func TestStartConnectionError(t *testing.T) {
+	s, err := newSystemdScraper(receivertest.NewNopCreateSettings(), newTestConfig("service"))
+	require.NoError(t, err)
+	s.bootTime = func(context.Context) (uint64, error) { return 100, nil }
+	s.connect = func(context.Context) (systemdConn, error) { return nil, errors.New("no such file or directory") }
+
+	assert.EqualError(t, s.start(context.Background(), componenttest.NewNopHost()), "failed to connect to systemd: no such file or directory")
+	assert.NoError(t, s.shutdown(context.Background()))
+}
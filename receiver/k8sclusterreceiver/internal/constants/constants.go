@@ -28,6 +28,10 @@ const (
 	K8sKindReplicationController = "ReplicationController"
 	K8sKindReplicaSet            = "ReplicaSet"
 	K8sStatefulSet               = "StatefulSet"
+	K8sKindIngress               = "Ingress"
+	K8sKindPersistentVolume      = "PersistentVolume"
+	K8sKindPersistentVolumeClaim = "PersistentVolumeClaim"
+	K8sKindPodDisruptionBudget   = "PodDisruptionBudget"
 )
 
 // Keys for K8s metadata
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/ingress"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/constants"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+const (
+	// Keys for ingress metadata.
+	ingressClass = "ingress_class"
+)
+
+func RecordMetrics(mb *metadata.MetricsBuilder, ingress *networkingv1.Ingress, ts pcommon.Timestamp) {
+	mb.RecordK8sIngressRulesDataPoint(ts, int64(len(ingress.Spec.Rules)))
+	mb.RecordK8sIngressLoadBalancerAddressesDataPoint(ts, int64(len(ingress.Status.LoadBalancer.Ingress)))
+	rb := mb.NewResourceBuilder()
+	rb.SetK8sIngressUID(string(ingress.UID))
+	rb.SetK8sIngressName(ingress.Name)
+	rb.SetK8sNamespaceName(ingress.Namespace)
+	mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+func GetMetadata(ingress *networkingv1.Ingress) map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata {
+	km := metadata.GetGenericMetadata(&ingress.ObjectMeta, constants.K8sKindIngress)
+	if ingress.Spec.IngressClassName != nil {
+		km.Metadata[ingressClass] = *ingress.Spec.IngressClassName
+	}
+
+	return map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{experimentalmetricmetadata.ResourceID(ingress.UID): km}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func TestIngressMetrics(t *testing.T) {
+	ingress := testutils.NewIngress("1")
+
+	ts := pcommon.Timestamp(time.Now().UnixNano())
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.K8sIngressRules.Enabled = true
+	mbc.Metrics.K8sIngressLoadBalancerAddresses.Enabled = true
+	mb := metadata.NewMetricsBuilder(mbc, receivertest.NewNopCreateSettings())
+	RecordMetrics(mb, ingress, ts)
+	m := mb.Emit()
+
+	require.Equal(t, 1, m.ResourceMetrics().Len())
+	rm := m.ResourceMetrics().At(0)
+	assert.Equal(t,
+		map[string]any{
+			"k8s.ingress.uid":    "test-ingress-1-uid",
+			"k8s.ingress.name":   "test-ingress-1",
+			"k8s.namespace.name": "test-namespace",
+		},
+		rm.Resource().Attributes().AsRaw())
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	sms := rm.ScopeMetrics().At(0)
+	require.Equal(t, 2, sms.Metrics().Len())
+	sms.Metrics().Sort(func(a, b pmetric.Metric) bool {
+		return a.Name() < b.Name()
+	})
+	testutils.AssertMetricInt(t, sms.Metrics().At(0), "k8s.ingress.load_balancer_addresses", pmetric.MetricTypeGauge, 1)
+	testutils.AssertMetricInt(t, sms.Metrics().At(1), "k8s.ingress.rules", pmetric.MetricTypeGauge, 2)
+}
+
+func TestIngressMetadata(t *testing.T) {
+	ingress := testutils.NewIngress("1")
+
+	actualMetadata := GetMetadata(ingress)
+
+	require.Equal(t, 1, len(actualMetadata))
+	require.Equal(t,
+		metadata.KubernetesMetadata{
+			EntityType:    "k8s.ingress",
+			ResourceIDKey: "k8s.ingress.uid",
+			ResourceID:    "test-ingress-1-uid",
+			Metadata: map[string]string{
+				"k8s.workload.name":          "test-ingress-1",
+				"k8s.workload.kind":          "Ingress",
+				"ingress.creation_timestamp": "0001-01-01T00:00:00Z",
+				"ingress_class":              "nginx",
+			},
+		},
+		*actualMetadata["test-ingress-1-uid"],
+	)
+}
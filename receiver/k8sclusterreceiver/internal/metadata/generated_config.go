@@ -46,12 +46,23 @@ type MetricsConfig struct {
 	K8sHpaDesiredReplicas               MetricConfig `mapstructure:"k8s.hpa.desired_replicas"`
 	K8sHpaMaxReplicas                   MetricConfig `mapstructure:"k8s.hpa.max_replicas"`
 	K8sHpaMinReplicas                   MetricConfig `mapstructure:"k8s.hpa.min_replicas"`
+	K8sIngressLoadBalancerAddresses     MetricConfig `mapstructure:"k8s.ingress.load_balancer_addresses"`
+	K8sIngressRules                     MetricConfig `mapstructure:"k8s.ingress.rules"`
 	K8sJobActivePods                    MetricConfig `mapstructure:"k8s.job.active_pods"`
 	K8sJobDesiredSuccessfulPods         MetricConfig `mapstructure:"k8s.job.desired_successful_pods"`
 	K8sJobFailedPods                    MetricConfig `mapstructure:"k8s.job.failed_pods"`
 	K8sJobMaxParallelPods               MetricConfig `mapstructure:"k8s.job.max_parallel_pods"`
 	K8sJobSuccessfulPods                MetricConfig `mapstructure:"k8s.job.successful_pods"`
 	K8sNamespacePhase                   MetricConfig `mapstructure:"k8s.namespace.phase"`
+	K8sPdbCurrentHealthy                MetricConfig `mapstructure:"k8s.pdb.current_healthy"`
+	K8sPdbDesiredHealthy                MetricConfig `mapstructure:"k8s.pdb.desired_healthy"`
+	K8sPdbDisruptionsAllowed            MetricConfig `mapstructure:"k8s.pdb.disruptions_allowed"`
+	K8sPdbExpectedPods                  MetricConfig `mapstructure:"k8s.pdb.expected_pods"`
+	K8sPersistentvolumeCapacity         MetricConfig `mapstructure:"k8s.persistentvolume.capacity"`
+	K8sPersistentvolumePhase            MetricConfig `mapstructure:"k8s.persistentvolume.phase"`
+	K8sPersistentvolumeclaimCapacity    MetricConfig `mapstructure:"k8s.persistentvolumeclaim.capacity"`
+	K8sPersistentvolumeclaimPhase       MetricConfig `mapstructure:"k8s.persistentvolumeclaim.phase"`
+	K8sPersistentvolumeclaimRequested   MetricConfig `mapstructure:"k8s.persistentvolumeclaim.requested"`
 	K8sPodPhase                         MetricConfig `mapstructure:"k8s.pod.phase"`
 	K8sReplicasetAvailable              MetricConfig `mapstructure:"k8s.replicaset.available"`
 	K8sReplicasetDesired                MetricConfig `mapstructure:"k8s.replicaset.desired"`
@@ -134,6 +145,12 @@ func DefaultMetricsConfig() MetricsConfig {
 		K8sHpaMinReplicas: MetricConfig{
 			Enabled: true,
 		},
+		K8sIngressLoadBalancerAddresses: MetricConfig{
+			Enabled: false,
+		},
+		K8sIngressRules: MetricConfig{
+			Enabled: false,
+		},
 		K8sJobActivePods: MetricConfig{
 			Enabled: true,
 		},
@@ -152,6 +169,33 @@ func DefaultMetricsConfig() MetricsConfig {
 		K8sNamespacePhase: MetricConfig{
 			Enabled: true,
 		},
+		K8sPdbCurrentHealthy: MetricConfig{
+			Enabled: false,
+		},
+		K8sPdbDesiredHealthy: MetricConfig{
+			Enabled: false,
+		},
+		K8sPdbDisruptionsAllowed: MetricConfig{
+			Enabled: false,
+		},
+		K8sPdbExpectedPods: MetricConfig{
+			Enabled: false,
+		},
+		K8sPersistentvolumeCapacity: MetricConfig{
+			Enabled: false,
+		},
+		K8sPersistentvolumePhase: MetricConfig{
+			Enabled: false,
+		},
+		K8sPersistentvolumeclaimCapacity: MetricConfig{
+			Enabled: false,
+		},
+		K8sPersistentvolumeclaimPhase: MetricConfig{
+			Enabled: false,
+		},
+		K8sPersistentvolumeclaimRequested: MetricConfig{
+			Enabled: false,
+		},
 		K8sPodPhase: MetricConfig{
 			Enabled: true,
 		},
@@ -219,12 +263,20 @@ type ResourceAttributesConfig struct {
 	K8sDeploymentUID             ResourceAttributeConfig `mapstructure:"k8s.deployment.uid"`
 	K8sHpaName                   ResourceAttributeConfig `mapstructure:"k8s.hpa.name"`
 	K8sHpaUID                    ResourceAttributeConfig `mapstructure:"k8s.hpa.uid"`
+	K8sIngressName               ResourceAttributeConfig `mapstructure:"k8s.ingress.name"`
+	K8sIngressUID                ResourceAttributeConfig `mapstructure:"k8s.ingress.uid"`
 	K8sJobName                   ResourceAttributeConfig `mapstructure:"k8s.job.name"`
 	K8sJobUID                    ResourceAttributeConfig `mapstructure:"k8s.job.uid"`
 	K8sNamespaceName             ResourceAttributeConfig `mapstructure:"k8s.namespace.name"`
 	K8sNamespaceUID              ResourceAttributeConfig `mapstructure:"k8s.namespace.uid"`
 	K8sNodeName                  ResourceAttributeConfig `mapstructure:"k8s.node.name"`
 	K8sNodeUID                   ResourceAttributeConfig `mapstructure:"k8s.node.uid"`
+	K8sPdbName                   ResourceAttributeConfig `mapstructure:"k8s.pdb.name"`
+	K8sPdbUID                    ResourceAttributeConfig `mapstructure:"k8s.pdb.uid"`
+	K8sPersistentvolumeName      ResourceAttributeConfig `mapstructure:"k8s.persistentvolume.name"`
+	K8sPersistentvolumeUID       ResourceAttributeConfig `mapstructure:"k8s.persistentvolume.uid"`
+	K8sPersistentvolumeclaimName ResourceAttributeConfig `mapstructure:"k8s.persistentvolumeclaim.name"`
+	K8sPersistentvolumeclaimUID  ResourceAttributeConfig `mapstructure:"k8s.persistentvolumeclaim.uid"`
 	K8sPodName                   ResourceAttributeConfig `mapstructure:"k8s.pod.name"`
 	K8sPodUID                    ResourceAttributeConfig `mapstructure:"k8s.pod.uid"`
 	K8sReplicasetName            ResourceAttributeConfig `mapstructure:"k8s.replicaset.name"`
@@ -235,6 +287,7 @@ type ResourceAttributesConfig struct {
 	K8sResourcequotaUID          ResourceAttributeConfig `mapstructure:"k8s.resourcequota.uid"`
 	K8sStatefulsetName           ResourceAttributeConfig `mapstructure:"k8s.statefulset.name"`
 	K8sStatefulsetUID            ResourceAttributeConfig `mapstructure:"k8s.statefulset.uid"`
+	K8sStorageclassName          ResourceAttributeConfig `mapstructure:"k8s.storageclass.name"`
 	OpencensusResourcetype       ResourceAttributeConfig `mapstructure:"opencensus.resourcetype"`
 	OpenshiftClusterquotaName    ResourceAttributeConfig `mapstructure:"openshift.clusterquota.name"`
 	OpenshiftClusterquotaUID     ResourceAttributeConfig `mapstructure:"openshift.clusterquota.uid"`
@@ -278,6 +331,12 @@ func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 		K8sHpaUID: ResourceAttributeConfig{
 			Enabled: true,
 		},
+		K8sIngressName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sIngressUID: ResourceAttributeConfig{
+			Enabled: true,
+		},
 		K8sJobName: ResourceAttributeConfig{
 			Enabled: true,
 		},
@@ -296,6 +355,24 @@ func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 		K8sNodeUID: ResourceAttributeConfig{
 			Enabled: true,
 		},
+		K8sPdbName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPdbUID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeUID: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeclaimName: ResourceAttributeConfig{
+			Enabled: true,
+		},
+		K8sPersistentvolumeclaimUID: ResourceAttributeConfig{
+			Enabled: true,
+		},
 		K8sPodName: ResourceAttributeConfig{
 			Enabled: true,
 		},
@@ -326,6 +403,9 @@ func DefaultResourceAttributesConfig() ResourceAttributesConfig {
 		K8sStatefulsetUID: ResourceAttributeConfig{
 			Enabled: true,
 		},
+		K8sStorageclassName: ResourceAttributeConfig{
+			Enabled: true,
+		},
 		OpencensusResourcetype: ResourceAttributeConfig{
 			Enabled: true,
 		},
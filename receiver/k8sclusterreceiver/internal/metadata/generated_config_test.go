@@ -47,12 +47,23 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					K8sHpaDesiredReplicas:               MetricConfig{Enabled: true},
 					K8sHpaMaxReplicas:                   MetricConfig{Enabled: true},
 					K8sHpaMinReplicas:                   MetricConfig{Enabled: true},
+					K8sIngressLoadBalancerAddresses:     MetricConfig{Enabled: true},
+					K8sIngressRules:                     MetricConfig{Enabled: true},
 					K8sJobActivePods:                    MetricConfig{Enabled: true},
 					K8sJobDesiredSuccessfulPods:         MetricConfig{Enabled: true},
 					K8sJobFailedPods:                    MetricConfig{Enabled: true},
 					K8sJobMaxParallelPods:               MetricConfig{Enabled: true},
 					K8sJobSuccessfulPods:                MetricConfig{Enabled: true},
 					K8sNamespacePhase:                   MetricConfig{Enabled: true},
+					K8sPdbCurrentHealthy:                MetricConfig{Enabled: true},
+					K8sPdbDesiredHealthy:                MetricConfig{Enabled: true},
+					K8sPdbDisruptionsAllowed:            MetricConfig{Enabled: true},
+					K8sPdbExpectedPods:                  MetricConfig{Enabled: true},
+					K8sPersistentvolumeCapacity:         MetricConfig{Enabled: true},
+					K8sPersistentvolumePhase:            MetricConfig{Enabled: true},
+					K8sPersistentvolumeclaimCapacity:    MetricConfig{Enabled: true},
+					K8sPersistentvolumeclaimPhase:       MetricConfig{Enabled: true},
+					K8sPersistentvolumeclaimRequested:   MetricConfig{Enabled: true},
 					K8sPodPhase:                         MetricConfig{Enabled: true},
 					K8sReplicasetAvailable:              MetricConfig{Enabled: true},
 					K8sReplicasetDesired:                MetricConfig{Enabled: true},
@@ -82,12 +93,20 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					K8sDeploymentUID:             ResourceAttributeConfig{Enabled: true},
 					K8sHpaName:                   ResourceAttributeConfig{Enabled: true},
 					K8sHpaUID:                    ResourceAttributeConfig{Enabled: true},
+					K8sIngressName:               ResourceAttributeConfig{Enabled: true},
+					K8sIngressUID:                ResourceAttributeConfig{Enabled: true},
 					K8sJobName:                   ResourceAttributeConfig{Enabled: true},
 					K8sJobUID:                    ResourceAttributeConfig{Enabled: true},
 					K8sNamespaceName:             ResourceAttributeConfig{Enabled: true},
 					K8sNamespaceUID:              ResourceAttributeConfig{Enabled: true},
 					K8sNodeName:                  ResourceAttributeConfig{Enabled: true},
 					K8sNodeUID:                   ResourceAttributeConfig{Enabled: true},
+					K8sPdbName:                   ResourceAttributeConfig{Enabled: true},
+					K8sPdbUID:                    ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeName:      ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeUID:       ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeclaimName: ResourceAttributeConfig{Enabled: true},
+					K8sPersistentvolumeclaimUID:  ResourceAttributeConfig{Enabled: true},
 					K8sPodName:                   ResourceAttributeConfig{Enabled: true},
 					K8sPodUID:                    ResourceAttributeConfig{Enabled: true},
 					K8sReplicasetName:            ResourceAttributeConfig{Enabled: true},
@@ -98,6 +117,7 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					K8sResourcequotaUID:          ResourceAttributeConfig{Enabled: true},
 					K8sStatefulsetName:           ResourceAttributeConfig{Enabled: true},
 					K8sStatefulsetUID:            ResourceAttributeConfig{Enabled: true},
+					K8sStorageclassName:          ResourceAttributeConfig{Enabled: true},
 					OpencensusResourcetype:       ResourceAttributeConfig{Enabled: true},
 					OpenshiftClusterquotaName:    ResourceAttributeConfig{Enabled: true},
 					OpenshiftClusterquotaUID:     ResourceAttributeConfig{Enabled: true},
@@ -129,12 +149,23 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					K8sHpaDesiredReplicas:               MetricConfig{Enabled: false},
 					K8sHpaMaxReplicas:                   MetricConfig{Enabled: false},
 					K8sHpaMinReplicas:                   MetricConfig{Enabled: false},
+					K8sIngressLoadBalancerAddresses:     MetricConfig{Enabled: false},
+					K8sIngressRules:                     MetricConfig{Enabled: false},
 					K8sJobActivePods:                    MetricConfig{Enabled: false},
 					K8sJobDesiredSuccessfulPods:         MetricConfig{Enabled: false},
 					K8sJobFailedPods:                    MetricConfig{Enabled: false},
 					K8sJobMaxParallelPods:               MetricConfig{Enabled: false},
 					K8sJobSuccessfulPods:                MetricConfig{Enabled: false},
 					K8sNamespacePhase:                   MetricConfig{Enabled: false},
+					K8sPdbCurrentHealthy:                MetricConfig{Enabled: false},
+					K8sPdbDesiredHealthy:                MetricConfig{Enabled: false},
+					K8sPdbDisruptionsAllowed:            MetricConfig{Enabled: false},
+					K8sPdbExpectedPods:                  MetricConfig{Enabled: false},
+					K8sPersistentvolumeCapacity:         MetricConfig{Enabled: false},
+					K8sPersistentvolumePhase:            MetricConfig{Enabled: false},
+					K8sPersistentvolumeclaimCapacity:    MetricConfig{Enabled: false},
+					K8sPersistentvolumeclaimPhase:       MetricConfig{Enabled: false},
+					K8sPersistentvolumeclaimRequested:   MetricConfig{Enabled: false},
 					K8sPodPhase:                         MetricConfig{Enabled: false},
 					K8sReplicasetAvailable:              MetricConfig{Enabled: false},
 					K8sReplicasetDesired:                MetricConfig{Enabled: false},
@@ -164,12 +195,20 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					K8sDeploymentUID:             ResourceAttributeConfig{Enabled: false},
 					K8sHpaName:                   ResourceAttributeConfig{Enabled: false},
 					K8sHpaUID:                    ResourceAttributeConfig{Enabled: false},
+					K8sIngressName:               ResourceAttributeConfig{Enabled: false},
+					K8sIngressUID:                ResourceAttributeConfig{Enabled: false},
 					K8sJobName:                   ResourceAttributeConfig{Enabled: false},
 					K8sJobUID:                    ResourceAttributeConfig{Enabled: false},
 					K8sNamespaceName:             ResourceAttributeConfig{Enabled: false},
 					K8sNamespaceUID:              ResourceAttributeConfig{Enabled: false},
 					K8sNodeName:                  ResourceAttributeConfig{Enabled: false},
 					K8sNodeUID:                   ResourceAttributeConfig{Enabled: false},
+					K8sPdbName:                   ResourceAttributeConfig{Enabled: false},
+					K8sPdbUID:                    ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeName:      ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeUID:       ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeclaimName: ResourceAttributeConfig{Enabled: false},
+					K8sPersistentvolumeclaimUID:  ResourceAttributeConfig{Enabled: false},
 					K8sPodName:                   ResourceAttributeConfig{Enabled: false},
 					K8sPodUID:                    ResourceAttributeConfig{Enabled: false},
 					K8sReplicasetName:            ResourceAttributeConfig{Enabled: false},
@@ -180,6 +219,7 @@ func TestMetricsBuilderConfig(t *testing.T) {
 					K8sResourcequotaUID:          ResourceAttributeConfig{Enabled: false},
 					K8sStatefulsetName:           ResourceAttributeConfig{Enabled: false},
 					K8sStatefulsetUID:            ResourceAttributeConfig{Enabled: false},
+					K8sStorageclassName:          ResourceAttributeConfig{Enabled: false},
 					OpencensusResourcetype:       ResourceAttributeConfig{Enabled: false},
 					OpenshiftClusterquotaName:    ResourceAttributeConfig{Enabled: false},
 					OpenshiftClusterquotaUID:     ResourceAttributeConfig{Enabled: false},
@@ -231,12 +271,20 @@ func TestResourceAttributesConfig(t *testing.T) {
 				K8sDeploymentUID:             ResourceAttributeConfig{Enabled: true},
 				K8sHpaName:                   ResourceAttributeConfig{Enabled: true},
 				K8sHpaUID:                    ResourceAttributeConfig{Enabled: true},
+				K8sIngressName:               ResourceAttributeConfig{Enabled: true},
+				K8sIngressUID:                ResourceAttributeConfig{Enabled: true},
 				K8sJobName:                   ResourceAttributeConfig{Enabled: true},
 				K8sJobUID:                    ResourceAttributeConfig{Enabled: true},
 				K8sNamespaceName:             ResourceAttributeConfig{Enabled: true},
 				K8sNamespaceUID:              ResourceAttributeConfig{Enabled: true},
 				K8sNodeName:                  ResourceAttributeConfig{Enabled: true},
 				K8sNodeUID:                   ResourceAttributeConfig{Enabled: true},
+				K8sPdbName:                   ResourceAttributeConfig{Enabled: true},
+				K8sPdbUID:                    ResourceAttributeConfig{Enabled: true},
+				K8sPersistentvolumeName:      ResourceAttributeConfig{Enabled: true},
+				K8sPersistentvolumeUID:       ResourceAttributeConfig{Enabled: true},
+				K8sPersistentvolumeclaimName: ResourceAttributeConfig{Enabled: true},
+				K8sPersistentvolumeclaimUID:  ResourceAttributeConfig{Enabled: true},
 				K8sPodName:                   ResourceAttributeConfig{Enabled: true},
 				K8sPodUID:                    ResourceAttributeConfig{Enabled: true},
 				K8sReplicasetName:            ResourceAttributeConfig{Enabled: true},
@@ -247,6 +295,7 @@ func TestResourceAttributesConfig(t *testing.T) {
 				K8sResourcequotaUID:          ResourceAttributeConfig{Enabled: true},
 				K8sStatefulsetName:           ResourceAttributeConfig{Enabled: true},
 				K8sStatefulsetUID:            ResourceAttributeConfig{Enabled: true},
+				K8sStorageclassName:          ResourceAttributeConfig{Enabled: true},
 				OpencensusResourcetype:       ResourceAttributeConfig{Enabled: true},
 				OpenshiftClusterquotaName:    ResourceAttributeConfig{Enabled: true},
 				OpenshiftClusterquotaUID:     ResourceAttributeConfig{Enabled: true},
@@ -267,12 +316,20 @@ func TestResourceAttributesConfig(t *testing.T) {
 				K8sDeploymentUID:             ResourceAttributeConfig{Enabled: false},
 				K8sHpaName:                   ResourceAttributeConfig{Enabled: false},
 				K8sHpaUID:                    ResourceAttributeConfig{Enabled: false},
+				K8sIngressName:               ResourceAttributeConfig{Enabled: false},
+				K8sIngressUID:                ResourceAttributeConfig{Enabled: false},
 				K8sJobName:                   ResourceAttributeConfig{Enabled: false},
 				K8sJobUID:                    ResourceAttributeConfig{Enabled: false},
 				K8sNamespaceName:             ResourceAttributeConfig{Enabled: false},
 				K8sNamespaceUID:              ResourceAttributeConfig{Enabled: false},
 				K8sNodeName:                  ResourceAttributeConfig{Enabled: false},
 				K8sNodeUID:                   ResourceAttributeConfig{Enabled: false},
+				K8sPdbName:                   ResourceAttributeConfig{Enabled: false},
+				K8sPdbUID:                    ResourceAttributeConfig{Enabled: false},
+				K8sPersistentvolumeName:      ResourceAttributeConfig{Enabled: false},
+				K8sPersistentvolumeUID:       ResourceAttributeConfig{Enabled: false},
+				K8sPersistentvolumeclaimName: ResourceAttributeConfig{Enabled: false},
+				K8sPersistentvolumeclaimUID:  ResourceAttributeConfig{Enabled: false},
 				K8sPodName:                   ResourceAttributeConfig{Enabled: false},
 				K8sPodUID:                    ResourceAttributeConfig{Enabled: false},
 				K8sReplicasetName:            ResourceAttributeConfig{Enabled: false},
@@ -283,6 +340,7 @@ func TestResourceAttributesConfig(t *testing.T) {
 				K8sResourcequotaUID:          ResourceAttributeConfig{Enabled: false},
 				K8sStatefulsetName:           ResourceAttributeConfig{Enabled: false},
 				K8sStatefulsetUID:            ResourceAttributeConfig{Enabled: false},
+				K8sStorageclassName:          ResourceAttributeConfig{Enabled: false},
 				OpencensusResourcetype:       ResourceAttributeConfig{Enabled: false},
 				OpenshiftClusterquotaName:    ResourceAttributeConfig{Enabled: false},
 				OpenshiftClusterquotaUID:     ResourceAttributeConfig{Enabled: false},
@@ -1041,6 +1041,104 @@ func newMetricK8sHpaMinReplicas(cfg MetricConfig) metricK8sHpaMinReplicas {
 	return m
 }
 
+type metricK8sIngressLoadBalancerAddresses struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.ingress.load_balancer_addresses metric with initial data.
+func (m *metricK8sIngressLoadBalancerAddresses) init() {
+	m.data.SetName("k8s.ingress.load_balancer_addresses")
+	m.data.SetDescription("Number of load balancer addresses assigned to the ingress, 0 while the ingress isn't provisioned.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sIngressLoadBalancerAddresses) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sIngressLoadBalancerAddresses) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sIngressLoadBalancerAddresses) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sIngressLoadBalancerAddresses(cfg MetricConfig) metricK8sIngressLoadBalancerAddresses {
+	m := metricK8sIngressLoadBalancerAddresses{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sIngressRules struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.ingress.rules metric with initial data.
+func (m *metricK8sIngressRules) init() {
+	m.data.SetName("k8s.ingress.rules")
+	m.data.SetDescription("Number of rules of the ingress.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sIngressRules) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sIngressRules) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sIngressRules) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sIngressRules(cfg MetricConfig) metricK8sIngressRules {
+	m := metricK8sIngressRules{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricK8sJobActivePods struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -1335,6 +1433,447 @@ func newMetricK8sNamespacePhase(cfg MetricConfig) metricK8sNamespacePhase {
 	return m
 }
 
+type metricK8sPdbCurrentHealthy struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.pdb.current_healthy metric with initial data.
+func (m *metricK8sPdbCurrentHealthy) init() {
+	m.data.SetName("k8s.pdb.current_healthy")
+	m.data.SetDescription("Current number of healthy pods selected by the poddisruptionbudget.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPdbCurrentHealthy) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPdbCurrentHealthy) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPdbCurrentHealthy) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPdbCurrentHealthy(cfg MetricConfig) metricK8sPdbCurrentHealthy {
+	m := metricK8sPdbCurrentHealthy{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPdbDesiredHealthy struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.pdb.desired_healthy metric with initial data.
+func (m *metricK8sPdbDesiredHealthy) init() {
+	m.data.SetName("k8s.pdb.desired_healthy")
+	m.data.SetDescription("Minimum desired number of healthy pods selected by the poddisruptionbudget.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPdbDesiredHealthy) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPdbDesiredHealthy) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPdbDesiredHealthy) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPdbDesiredHealthy(cfg MetricConfig) metricK8sPdbDesiredHealthy {
+	m := metricK8sPdbDesiredHealthy{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPdbDisruptionsAllowed struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.pdb.disruptions_allowed metric with initial data.
+func (m *metricK8sPdbDisruptionsAllowed) init() {
+	m.data.SetName("k8s.pdb.disruptions_allowed")
+	m.data.SetDescription("Number of pod disruptions that are currently allowed by the poddisruptionbudget.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPdbDisruptionsAllowed) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPdbDisruptionsAllowed) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPdbDisruptionsAllowed) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPdbDisruptionsAllowed(cfg MetricConfig) metricK8sPdbDisruptionsAllowed {
+	m := metricK8sPdbDisruptionsAllowed{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPdbExpectedPods struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.pdb.expected_pods metric with initial data.
+func (m *metricK8sPdbExpectedPods) init() {
+	m.data.SetName("k8s.pdb.expected_pods")
+	m.data.SetDescription("Total number of pods counted by the poddisruptionbudget.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPdbExpectedPods) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPdbExpectedPods) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPdbExpectedPods) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPdbExpectedPods(cfg MetricConfig) metricK8sPdbExpectedPods {
+	m := metricK8sPdbExpectedPods{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeCapacity struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolume.capacity metric with initial data.
+func (m *metricK8sPersistentvolumeCapacity) init() {
+	m.data.SetName("k8s.persistentvolume.capacity")
+	m.data.SetDescription("Storage capacity of the persistentvolume.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeCapacity) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeCapacity) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeCapacity) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeCapacity(cfg MetricConfig) metricK8sPersistentvolumeCapacity {
+	m := metricK8sPersistentvolumeCapacity{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumePhase struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolume.phase metric with initial data.
+func (m *metricK8sPersistentvolumePhase) init() {
+	m.data.SetName("k8s.persistentvolume.phase")
+	m.data.SetDescription("Current phase of the persistentvolume (1 - Pending, 2 - Available, 3 - Bound, 4 - Released, 5 - Failed)")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumePhase) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumePhase) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumePhase) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumePhase(cfg MetricConfig) metricK8sPersistentvolumePhase {
+	m := metricK8sPersistentvolumePhase{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeclaimCapacity struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolumeclaim.capacity metric with initial data.
+func (m *metricK8sPersistentvolumeclaimCapacity) init() {
+	m.data.SetName("k8s.persistentvolumeclaim.capacity")
+	m.data.SetDescription("Storage capacity of the volume bound to the persistentvolumeclaim.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeclaimCapacity) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeclaimCapacity) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeclaimCapacity) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeclaimCapacity(cfg MetricConfig) metricK8sPersistentvolumeclaimCapacity {
+	m := metricK8sPersistentvolumeclaimCapacity{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeclaimPhase struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolumeclaim.phase metric with initial data.
+func (m *metricK8sPersistentvolumeclaimPhase) init() {
+	m.data.SetName("k8s.persistentvolumeclaim.phase")
+	m.data.SetDescription("Current phase of the persistentvolumeclaim (1 - Pending, 2 - Bound, 3 - Lost)")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeclaimPhase) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeclaimPhase) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeclaimPhase) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeclaimPhase(cfg MetricConfig) metricK8sPersistentvolumeclaimPhase {
+	m := metricK8sPersistentvolumeclaimPhase{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricK8sPersistentvolumeclaimRequested struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills k8s.persistentvolumeclaim.requested metric with initial data.
+func (m *metricK8sPersistentvolumeclaimRequested) init() {
+	m.data.SetName("k8s.persistentvolumeclaim.requested")
+	m.data.SetDescription("Storage requested by the persistentvolumeclaim.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricK8sPersistentvolumeclaimRequested) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricK8sPersistentvolumeclaimRequested) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricK8sPersistentvolumeclaimRequested) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricK8sPersistentvolumeclaimRequested(cfg MetricConfig) metricK8sPersistentvolumeclaimRequested {
+	m := metricK8sPersistentvolumeclaimRequested{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricK8sPodPhase struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -2113,12 +2652,23 @@ type MetricsBuilder struct {
 	metricK8sHpaDesiredReplicas               metricK8sHpaDesiredReplicas
 	metricK8sHpaMaxReplicas                   metricK8sHpaMaxReplicas
 	metricK8sHpaMinReplicas                   metricK8sHpaMinReplicas
+	metricK8sIngressLoadBalancerAddresses     metricK8sIngressLoadBalancerAddresses
+	metricK8sIngressRules                     metricK8sIngressRules
 	metricK8sJobActivePods                    metricK8sJobActivePods
 	metricK8sJobDesiredSuccessfulPods         metricK8sJobDesiredSuccessfulPods
 	metricK8sJobFailedPods                    metricK8sJobFailedPods
 	metricK8sJobMaxParallelPods               metricK8sJobMaxParallelPods
 	metricK8sJobSuccessfulPods                metricK8sJobSuccessfulPods
 	metricK8sNamespacePhase                   metricK8sNamespacePhase
+	metricK8sPdbCurrentHealthy                metricK8sPdbCurrentHealthy
+	metricK8sPdbDesiredHealthy                metricK8sPdbDesiredHealthy
+	metricK8sPdbDisruptionsAllowed            metricK8sPdbDisruptionsAllowed
+	metricK8sPdbExpectedPods                  metricK8sPdbExpectedPods
+	metricK8sPersistentvolumeCapacity         metricK8sPersistentvolumeCapacity
+	metricK8sPersistentvolumePhase            metricK8sPersistentvolumePhase
+	metricK8sPersistentvolumeclaimCapacity    metricK8sPersistentvolumeclaimCapacity
+	metricK8sPersistentvolumeclaimPhase       metricK8sPersistentvolumeclaimPhase
+	metricK8sPersistentvolumeclaimRequested   metricK8sPersistentvolumeclaimRequested
 	metricK8sPodPhase                         metricK8sPodPhase
 	metricK8sReplicasetAvailable              metricK8sReplicasetAvailable
 	metricK8sReplicasetDesired                metricK8sReplicasetDesired
@@ -2173,12 +2723,23 @@ func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSetting
 		metricK8sHpaDesiredReplicas:               newMetricK8sHpaDesiredReplicas(mbc.Metrics.K8sHpaDesiredReplicas),
 		metricK8sHpaMaxReplicas:                   newMetricK8sHpaMaxReplicas(mbc.Metrics.K8sHpaMaxReplicas),
 		metricK8sHpaMinReplicas:                   newMetricK8sHpaMinReplicas(mbc.Metrics.K8sHpaMinReplicas),
+		metricK8sIngressLoadBalancerAddresses:     newMetricK8sIngressLoadBalancerAddresses(mbc.Metrics.K8sIngressLoadBalancerAddresses),
+		metricK8sIngressRules:                     newMetricK8sIngressRules(mbc.Metrics.K8sIngressRules),
 		metricK8sJobActivePods:                    newMetricK8sJobActivePods(mbc.Metrics.K8sJobActivePods),
 		metricK8sJobDesiredSuccessfulPods:         newMetricK8sJobDesiredSuccessfulPods(mbc.Metrics.K8sJobDesiredSuccessfulPods),
 		metricK8sJobFailedPods:                    newMetricK8sJobFailedPods(mbc.Metrics.K8sJobFailedPods),
 		metricK8sJobMaxParallelPods:               newMetricK8sJobMaxParallelPods(mbc.Metrics.K8sJobMaxParallelPods),
 		metricK8sJobSuccessfulPods:                newMetricK8sJobSuccessfulPods(mbc.Metrics.K8sJobSuccessfulPods),
 		metricK8sNamespacePhase:                   newMetricK8sNamespacePhase(mbc.Metrics.K8sNamespacePhase),
+		metricK8sPdbCurrentHealthy:                newMetricK8sPdbCurrentHealthy(mbc.Metrics.K8sPdbCurrentHealthy),
+		metricK8sPdbDesiredHealthy:                newMetricK8sPdbDesiredHealthy(mbc.Metrics.K8sPdbDesiredHealthy),
+		metricK8sPdbDisruptionsAllowed:            newMetricK8sPdbDisruptionsAllowed(mbc.Metrics.K8sPdbDisruptionsAllowed),
+		metricK8sPdbExpectedPods:                  newMetricK8sPdbExpectedPods(mbc.Metrics.K8sPdbExpectedPods),
+		metricK8sPersistentvolumeCapacity:         newMetricK8sPersistentvolumeCapacity(mbc.Metrics.K8sPersistentvolumeCapacity),
+		metricK8sPersistentvolumePhase:            newMetricK8sPersistentvolumePhase(mbc.Metrics.K8sPersistentvolumePhase),
+		metricK8sPersistentvolumeclaimCapacity:    newMetricK8sPersistentvolumeclaimCapacity(mbc.Metrics.K8sPersistentvolumeclaimCapacity),
+		metricK8sPersistentvolumeclaimPhase:       newMetricK8sPersistentvolumeclaimPhase(mbc.Metrics.K8sPersistentvolumeclaimPhase),
+		metricK8sPersistentvolumeclaimRequested:   newMetricK8sPersistentvolumeclaimRequested(mbc.Metrics.K8sPersistentvolumeclaimRequested),
 		metricK8sPodPhase:                         newMetricK8sPodPhase(mbc.Metrics.K8sPodPhase),
 		metricK8sReplicasetAvailable:              newMetricK8sReplicasetAvailable(mbc.Metrics.K8sReplicasetAvailable),
 		metricK8sReplicasetDesired:                newMetricK8sReplicasetDesired(mbc.Metrics.K8sReplicasetDesired),
@@ -2277,12 +2838,23 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	mb.metricK8sHpaDesiredReplicas.emit(ils.Metrics())
 	mb.metricK8sHpaMaxReplicas.emit(ils.Metrics())
 	mb.metricK8sHpaMinReplicas.emit(ils.Metrics())
+	mb.metricK8sIngressLoadBalancerAddresses.emit(ils.Metrics())
+	mb.metricK8sIngressRules.emit(ils.Metrics())
 	mb.metricK8sJobActivePods.emit(ils.Metrics())
 	mb.metricK8sJobDesiredSuccessfulPods.emit(ils.Metrics())
 	mb.metricK8sJobFailedPods.emit(ils.Metrics())
 	mb.metricK8sJobMaxParallelPods.emit(ils.Metrics())
 	mb.metricK8sJobSuccessfulPods.emit(ils.Metrics())
 	mb.metricK8sNamespacePhase.emit(ils.Metrics())
+	mb.metricK8sPdbCurrentHealthy.emit(ils.Metrics())
+	mb.metricK8sPdbDesiredHealthy.emit(ils.Metrics())
+	mb.metricK8sPdbDisruptionsAllowed.emit(ils.Metrics())
+	mb.metricK8sPdbExpectedPods.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeCapacity.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumePhase.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeclaimCapacity.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeclaimPhase.emit(ils.Metrics())
+	mb.metricK8sPersistentvolumeclaimRequested.emit(ils.Metrics())
 	mb.metricK8sPodPhase.emit(ils.Metrics())
 	mb.metricK8sReplicasetAvailable.emit(ils.Metrics())
 	mb.metricK8sReplicasetDesired.emit(ils.Metrics())
@@ -2423,6 +2995,16 @@ func (mb *MetricsBuilder) RecordK8sHpaMinReplicasDataPoint(ts pcommon.Timestamp,
 	mb.metricK8sHpaMinReplicas.recordDataPoint(mb.startTime, ts, val)
 }
 
+// RecordK8sIngressLoadBalancerAddressesDataPoint adds a data point to k8s.ingress.load_balancer_addresses metric.
+func (mb *MetricsBuilder) RecordK8sIngressLoadBalancerAddressesDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sIngressLoadBalancerAddresses.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sIngressRulesDataPoint adds a data point to k8s.ingress.rules metric.
+func (mb *MetricsBuilder) RecordK8sIngressRulesDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sIngressRules.recordDataPoint(mb.startTime, ts, val)
+}
+
 // RecordK8sJobActivePodsDataPoint adds a data point to k8s.job.active_pods metric.
 func (mb *MetricsBuilder) RecordK8sJobActivePodsDataPoint(ts pcommon.Timestamp, val int64) {
 	mb.metricK8sJobActivePods.recordDataPoint(mb.startTime, ts, val)
@@ -2453,6 +3035,51 @@ func (mb *MetricsBuilder) RecordK8sNamespacePhaseDataPoint(ts pcommon.Timestamp,
 	mb.metricK8sNamespacePhase.recordDataPoint(mb.startTime, ts, val)
 }
 
+// RecordK8sPdbCurrentHealthyDataPoint adds a data point to k8s.pdb.current_healthy metric.
+func (mb *MetricsBuilder) RecordK8sPdbCurrentHealthyDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPdbCurrentHealthy.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPdbDesiredHealthyDataPoint adds a data point to k8s.pdb.desired_healthy metric.
+func (mb *MetricsBuilder) RecordK8sPdbDesiredHealthyDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPdbDesiredHealthy.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPdbDisruptionsAllowedDataPoint adds a data point to k8s.pdb.disruptions_allowed metric.
+func (mb *MetricsBuilder) RecordK8sPdbDisruptionsAllowedDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPdbDisruptionsAllowed.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPdbExpectedPodsDataPoint adds a data point to k8s.pdb.expected_pods metric.
+func (mb *MetricsBuilder) RecordK8sPdbExpectedPodsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPdbExpectedPods.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumeCapacityDataPoint adds a data point to k8s.persistentvolume.capacity metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeCapacityDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeCapacity.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumePhaseDataPoint adds a data point to k8s.persistentvolume.phase metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumePhaseDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumePhase.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumeclaimCapacityDataPoint adds a data point to k8s.persistentvolumeclaim.capacity metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeclaimCapacityDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeclaimCapacity.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumeclaimPhaseDataPoint adds a data point to k8s.persistentvolumeclaim.phase metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeclaimPhaseDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeclaimPhase.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordK8sPersistentvolumeclaimRequestedDataPoint adds a data point to k8s.persistentvolumeclaim.requested metric.
+func (mb *MetricsBuilder) RecordK8sPersistentvolumeclaimRequestedDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricK8sPersistentvolumeclaimRequested.recordDataPoint(mb.startTime, ts, val)
+}
+
 // RecordK8sPodPhaseDataPoint adds a data point to k8s.pod.phase metric.
 func (mb *MetricsBuilder) RecordK8sPodPhaseDataPoint(ts pcommon.Timestamp, val int64) {
 	mb.metricK8sPodPhase.recordDataPoint(mb.startTime, ts, val)
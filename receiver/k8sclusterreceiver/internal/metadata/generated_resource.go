@@ -105,6 +105,20 @@ func (rb *ResourceBuilder) SetK8sHpaUID(val string) {
 	}
 }
 
+// SetK8sIngressName sets provided value as "k8s.ingress.name" attribute.
+func (rb *ResourceBuilder) SetK8sIngressName(val string) {
+	if rb.config.K8sIngressName.Enabled {
+		rb.res.Attributes().PutStr("k8s.ingress.name", val)
+	}
+}
+
+// SetK8sIngressUID sets provided value as "k8s.ingress.uid" attribute.
+func (rb *ResourceBuilder) SetK8sIngressUID(val string) {
+	if rb.config.K8sIngressUID.Enabled {
+		rb.res.Attributes().PutStr("k8s.ingress.uid", val)
+	}
+}
+
 // SetK8sJobName sets provided value as "k8s.job.name" attribute.
 func (rb *ResourceBuilder) SetK8sJobName(val string) {
 	if rb.config.K8sJobName.Enabled {
@@ -147,6 +161,48 @@ func (rb *ResourceBuilder) SetK8sNodeUID(val string) {
 	}
 }
 
+// SetK8sPdbName sets provided value as "k8s.pdb.name" attribute.
+func (rb *ResourceBuilder) SetK8sPdbName(val string) {
+	if rb.config.K8sPdbName.Enabled {
+		rb.res.Attributes().PutStr("k8s.pdb.name", val)
+	}
+}
+
+// SetK8sPdbUID sets provided value as "k8s.pdb.uid" attribute.
+func (rb *ResourceBuilder) SetK8sPdbUID(val string) {
+	if rb.config.K8sPdbUID.Enabled {
+		rb.res.Attributes().PutStr("k8s.pdb.uid", val)
+	}
+}
+
+// SetK8sPersistentvolumeName sets provided value as "k8s.persistentvolume.name" attribute.
+func (rb *ResourceBuilder) SetK8sPersistentvolumeName(val string) {
+	if rb.config.K8sPersistentvolumeName.Enabled {
+		rb.res.Attributes().PutStr("k8s.persistentvolume.name", val)
+	}
+}
+
+// SetK8sPersistentvolumeUID sets provided value as "k8s.persistentvolume.uid" attribute.
+func (rb *ResourceBuilder) SetK8sPersistentvolumeUID(val string) {
+	if rb.config.K8sPersistentvolumeUID.Enabled {
+		rb.res.Attributes().PutStr("k8s.persistentvolume.uid", val)
+	}
+}
+
+// SetK8sPersistentvolumeclaimName sets provided value as "k8s.persistentvolumeclaim.name" attribute.
+func (rb *ResourceBuilder) SetK8sPersistentvolumeclaimName(val string) {
+	if rb.config.K8sPersistentvolumeclaimName.Enabled {
+		rb.res.Attributes().PutStr("k8s.persistentvolumeclaim.name", val)
+	}
+}
+
+// SetK8sPersistentvolumeclaimUID sets provided value as "k8s.persistentvolumeclaim.uid" attribute.
+func (rb *ResourceBuilder) SetK8sPersistentvolumeclaimUID(val string) {
+	if rb.config.K8sPersistentvolumeclaimUID.Enabled {
+		rb.res.Attributes().PutStr("k8s.persistentvolumeclaim.uid", val)
+	}
+}
+
 // SetK8sPodName sets provided value as "k8s.pod.name" attribute.
 func (rb *ResourceBuilder) SetK8sPodName(val string) {
 	if rb.config.K8sPodName.Enabled {
@@ -217,6 +273,13 @@ func (rb *ResourceBuilder) SetK8sStatefulsetUID(val string) {
 	}
 }
 
+// SetK8sStorageclassName sets provided value as "k8s.storageclass.name" attribute.
+func (rb *ResourceBuilder) SetK8sStorageclassName(val string) {
+	if rb.config.K8sStorageclassName.Enabled {
+		rb.res.Attributes().PutStr("k8s.storageclass.name", val)
+	}
+}
+
 // SetOpencensusResourcetype sets provided value as "opencensus.resourcetype" attribute.
 func (rb *ResourceBuilder) SetOpencensusResourcetype(val string) {
 	if rb.config.OpencensusResourcetype.Enabled {
@@ -25,12 +25,20 @@ func TestResourceBuilder(t *testing.T) {
 			rb.SetK8sDeploymentUID("k8s.deployment.uid-val")
 			rb.SetK8sHpaName("k8s.hpa.name-val")
 			rb.SetK8sHpaUID("k8s.hpa.uid-val")
+			rb.SetK8sIngressName("k8s.ingress.name-val")
+			rb.SetK8sIngressUID("k8s.ingress.uid-val")
 			rb.SetK8sJobName("k8s.job.name-val")
 			rb.SetK8sJobUID("k8s.job.uid-val")
 			rb.SetK8sNamespaceName("k8s.namespace.name-val")
 			rb.SetK8sNamespaceUID("k8s.namespace.uid-val")
 			rb.SetK8sNodeName("k8s.node.name-val")
 			rb.SetK8sNodeUID("k8s.node.uid-val")
+			rb.SetK8sPdbName("k8s.pdb.name-val")
+			rb.SetK8sPdbUID("k8s.pdb.uid-val")
+			rb.SetK8sPersistentvolumeName("k8s.persistentvolume.name-val")
+			rb.SetK8sPersistentvolumeUID("k8s.persistentvolume.uid-val")
+			rb.SetK8sPersistentvolumeclaimName("k8s.persistentvolumeclaim.name-val")
+			rb.SetK8sPersistentvolumeclaimUID("k8s.persistentvolumeclaim.uid-val")
 			rb.SetK8sPodName("k8s.pod.name-val")
 			rb.SetK8sPodUID("k8s.pod.uid-val")
 			rb.SetK8sReplicasetName("k8s.replicaset.name-val")
@@ -41,6 +49,7 @@ func TestResourceBuilder(t *testing.T) {
 			rb.SetK8sResourcequotaUID("k8s.resourcequota.uid-val")
 			rb.SetK8sStatefulsetName("k8s.statefulset.name-val")
 			rb.SetK8sStatefulsetUID("k8s.statefulset.uid-val")
+			rb.SetK8sStorageclassName("k8s.storageclass.name-val")
 			rb.SetOpencensusResourcetype("opencensus.resourcetype-val")
 			rb.SetOpenshiftClusterquotaName("openshift.clusterquota.name-val")
 			rb.SetOpenshiftClusterquotaUID("openshift.clusterquota.uid-val")
@@ -50,9 +59,9 @@ func TestResourceBuilder(t *testing.T) {
 
 			switch test {
 			case "default":
-				assert.Equal(t, 31, res.Attributes().Len())
+				assert.Equal(t, 40, res.Attributes().Len())
 			case "all_set":
-				assert.Equal(t, 31, res.Attributes().Len())
+				assert.Equal(t, 40, res.Attributes().Len())
 			case "none_set":
 				assert.Equal(t, 0, res.Attributes().Len())
 				return
@@ -120,6 +129,16 @@ func TestResourceBuilder(t *testing.T) {
 			if ok {
 				assert.EqualValues(t, "k8s.hpa.uid-val", val.Str())
 			}
+			val, ok = res.Attributes().Get("k8s.ingress.name")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.ingress.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("k8s.ingress.uid")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.ingress.uid-val", val.Str())
+			}
 			val, ok = res.Attributes().Get("k8s.job.name")
 			assert.True(t, ok)
 			if ok {
@@ -150,6 +169,36 @@ func TestResourceBuilder(t *testing.T) {
 			if ok {
 				assert.EqualValues(t, "k8s.node.uid-val", val.Str())
 			}
+			val, ok = res.Attributes().Get("k8s.pdb.name")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.pdb.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("k8s.pdb.uid")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.pdb.uid-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("k8s.persistentvolume.name")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.persistentvolume.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("k8s.persistentvolume.uid")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.persistentvolume.uid-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("k8s.persistentvolumeclaim.name")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.persistentvolumeclaim.name-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("k8s.persistentvolumeclaim.uid")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.persistentvolumeclaim.uid-val", val.Str())
+			}
 			val, ok = res.Attributes().Get("k8s.pod.name")
 			assert.True(t, ok)
 			if ok {
@@ -200,6 +249,11 @@ func TestResourceBuilder(t *testing.T) {
 			if ok {
 				assert.EqualValues(t, "k8s.statefulset.uid-val", val.Str())
 			}
+			val, ok = res.Attributes().Get("k8s.storageclass.name")
+			assert.True(t, ok)
+			if ok {
+				assert.EqualValues(t, "k8s.storageclass.name-val", val.Str())
+			}
 			val, ok = res.Attributes().Get("opencensus.resourcetype")
 			assert.True(t, ok)
 			if ok {
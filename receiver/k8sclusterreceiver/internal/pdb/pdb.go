@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdb // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/pdb"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	policyv1 "k8s.io/api/policy/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/constants"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+const (
+	// Keys for pod disruption budget metadata.
+	minAvailable   = "min_available"
+	maxUnavailable = "max_unavailable"
+)
+
+func RecordMetrics(mb *metadata.MetricsBuilder, pdb *policyv1.PodDisruptionBudget, ts pcommon.Timestamp) {
+	mb.RecordK8sPdbDisruptionsAllowedDataPoint(ts, int64(pdb.Status.DisruptionsAllowed))
+	mb.RecordK8sPdbCurrentHealthyDataPoint(ts, int64(pdb.Status.CurrentHealthy))
+	mb.RecordK8sPdbDesiredHealthyDataPoint(ts, int64(pdb.Status.DesiredHealthy))
+	mb.RecordK8sPdbExpectedPodsDataPoint(ts, int64(pdb.Status.ExpectedPods))
+	rb := mb.NewResourceBuilder()
+	rb.SetK8sPdbUID(string(pdb.UID))
+	rb.SetK8sPdbName(pdb.Name)
+	rb.SetK8sNamespaceName(pdb.Namespace)
+	mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+func GetMetadata(pdb *policyv1.PodDisruptionBudget) map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata {
+	km := metadata.GetGenericMetadata(&pdb.ObjectMeta, constants.K8sKindPodDisruptionBudget)
+	// The metrics identify a pod disruption budget with the k8s.pdb.uid resource attribute
+	km.ResourceIDKey = metadata.GetOTelUIDFromKind("pdb")
+	if pdb.Spec.MinAvailable != nil {
+		km.Metadata[minAvailable] = pdb.Spec.MinAvailable.String()
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		km.Metadata[maxUnavailable] = pdb.Spec.MaxUnavailable.String()
+	}
+
+	return map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{experimentalmetricmetadata.ResourceID(pdb.UID): km}
+}
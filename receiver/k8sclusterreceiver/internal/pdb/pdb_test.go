@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func TestPDBMetrics(t *testing.T) {
+	pdb := testutils.NewPodDisruptionBudget("1")
+
+	ts := pcommon.Timestamp(time.Now().UnixNano())
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.K8sPdbDisruptionsAllowed.Enabled = true
+	mbc.Metrics.K8sPdbCurrentHealthy.Enabled = true
+	mbc.Metrics.K8sPdbDesiredHealthy.Enabled = true
+	mbc.Metrics.K8sPdbExpectedPods.Enabled = true
+	mb := metadata.NewMetricsBuilder(mbc, receivertest.NewNopCreateSettings())
+	RecordMetrics(mb, pdb, ts)
+	m := mb.Emit()
+
+	require.Equal(t, 1, m.ResourceMetrics().Len())
+	rm := m.ResourceMetrics().At(0)
+	assert.Equal(t,
+		map[string]any{
+			"k8s.pdb.uid":        "test-pdb-1-uid",
+			"k8s.pdb.name":       "test-pdb-1",
+			"k8s.namespace.name": "test-namespace",
+		},
+		rm.Resource().Attributes().AsRaw())
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	sms := rm.ScopeMetrics().At(0)
+	require.Equal(t, 4, sms.Metrics().Len())
+	sms.Metrics().Sort(func(a, b pmetric.Metric) bool {
+		return a.Name() < b.Name()
+	})
+	testutils.AssertMetricInt(t, sms.Metrics().At(0), "k8s.pdb.current_healthy", pmetric.MetricTypeGauge, 3)
+	testutils.AssertMetricInt(t, sms.Metrics().At(1), "k8s.pdb.desired_healthy", pmetric.MetricTypeGauge, 2)
+	testutils.AssertMetricInt(t, sms.Metrics().At(2), "k8s.pdb.disruptions_allowed", pmetric.MetricTypeGauge, 1)
+	testutils.AssertMetricInt(t, sms.Metrics().At(3), "k8s.pdb.expected_pods", pmetric.MetricTypeGauge, 4)
+}
+
+func TestPDBMetadata(t *testing.T) {
+	pdb := testutils.NewPodDisruptionBudget("1")
+
+	actualMetadata := GetMetadata(pdb)
+
+	require.Equal(t, 1, len(actualMetadata))
+	require.Equal(t,
+		metadata.KubernetesMetadata{
+			EntityType:    "k8s.poddisruptionbudget",
+			ResourceIDKey: "k8s.pdb.uid",
+			ResourceID:    "test-pdb-1-uid",
+			Metadata: map[string]string{
+				"k8s.workload.name":                      "test-pdb-1",
+				"k8s.workload.kind":                      "PodDisruptionBudget",
+				"poddisruptionbudget.creation_timestamp": "0001-01-01T00:00:00Z",
+				"min_available":                          "50%",
+			},
+		},
+		*actualMetadata["test-pdb-1-uid"],
+	)
+}
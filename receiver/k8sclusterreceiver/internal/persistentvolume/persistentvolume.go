@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolume // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolume"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/constants"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+const (
+	// Keys for persistent volume metadata.
+	storageClass  = "storage_class"
+	reclaimPolicy = "reclaim_policy"
+	claim         = "claim"
+)
+
+func RecordMetrics(mb *metadata.MetricsBuilder, pv *corev1.PersistentVolume, ts pcommon.Timestamp) {
+	if phase, ok := phaseToInt(pv.Status.Phase); ok {
+		mb.RecordK8sPersistentvolumePhaseDataPoint(ts, int64(phase))
+	}
+	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+		mb.RecordK8sPersistentvolumeCapacityDataPoint(ts, capacity.Value())
+	}
+	rb := mb.NewResourceBuilder()
+	rb.SetK8sPersistentvolumeUID(string(pv.UID))
+	rb.SetK8sPersistentvolumeName(pv.Name)
+	if pv.Spec.StorageClassName != "" {
+		rb.SetK8sStorageclassName(pv.Spec.StorageClassName)
+	}
+	mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+func phaseToInt(phase corev1.PersistentVolumePhase) (int32, bool) {
+	switch phase {
+	case corev1.VolumePending:
+		return 1, true
+	case corev1.VolumeAvailable:
+		return 2, true
+	case corev1.VolumeBound:
+		return 3, true
+	case corev1.VolumeReleased:
+		return 4, true
+	case corev1.VolumeFailed:
+		return 5, true
+	default:
+		return 0, false
+	}
+}
+
+func GetMetadata(pv *corev1.PersistentVolume) map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata {
+	km := metadata.GetGenericMetadata(&pv.ObjectMeta, constants.K8sKindPersistentVolume)
+	if pv.Spec.StorageClassName != "" {
+		km.Metadata[storageClass] = pv.Spec.StorageClassName
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy != "" {
+		km.Metadata[reclaimPolicy] = string(pv.Spec.PersistentVolumeReclaimPolicy)
+	}
+	if pv.Spec.ClaimRef != nil {
+		km.Metadata[claim] = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+	}
+
+	return map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{experimentalmetricmetadata.ResourceID(pv.UID): km}
+}
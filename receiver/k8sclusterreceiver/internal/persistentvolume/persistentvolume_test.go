@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolume
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func newMetricsBuilder() *metadata.MetricsBuilder {
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.K8sPersistentvolumePhase.Enabled = true
+	mbc.Metrics.K8sPersistentvolumeCapacity.Enabled = true
+	return metadata.NewMetricsBuilder(mbc, receivertest.NewNopCreateSettings())
+}
+
+func TestPersistentVolumeMetrics(t *testing.T) {
+	pv := testutils.NewPersistentVolume("1")
+
+	ts := pcommon.Timestamp(time.Now().UnixNano())
+	mb := newMetricsBuilder()
+	RecordMetrics(mb, pv, ts)
+	m := mb.Emit()
+
+	require.Equal(t, 1, m.ResourceMetrics().Len())
+	rm := m.ResourceMetrics().At(0)
+	assert.Equal(t,
+		map[string]any{
+			"k8s.persistentvolume.uid":  "test-pv-1-uid",
+			"k8s.persistentvolume.name": "test-pv-1",
+			"k8s.storageclass.name":     "standard",
+		},
+		rm.Resource().Attributes().AsRaw())
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	sms := rm.ScopeMetrics().At(0)
+	require.Equal(t, 2, sms.Metrics().Len())
+	sms.Metrics().Sort(func(a, b pmetric.Metric) bool {
+		return a.Name() < b.Name()
+	})
+	testutils.AssertMetricInt(t, sms.Metrics().At(0), "k8s.persistentvolume.capacity", pmetric.MetricTypeGauge, 10*1024*1024*1024)
+	testutils.AssertMetricInt(t, sms.Metrics().At(1), "k8s.persistentvolume.phase", pmetric.MetricTypeGauge, 3)
+}
+
+func TestPersistentVolumePhases(t *testing.T) {
+	tests := []struct {
+		phase    corev1.PersistentVolumePhase
+		expected int64
+	}{
+		{phase: corev1.VolumePending, expected: 1},
+		{phase: corev1.VolumeAvailable, expected: 2},
+		{phase: corev1.VolumeBound, expected: 3},
+		{phase: corev1.VolumeReleased, expected: 4},
+		{phase: corev1.VolumeFailed, expected: 5},
+		{phase: "Unknown", expected: -1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.phase), func(t *testing.T) {
+			pv := testutils.NewPersistentVolume("1")
+			pv.Status.Phase = tt.phase
+
+			mb := newMetricsBuilder()
+			RecordMetrics(mb, pv, pcommon.Timestamp(time.Now().UnixNano()))
+			sms := mb.Emit().ResourceMetrics().At(0).ScopeMetrics().At(0)
+			if tt.expected < 0 {
+				// Unknown phases aren't reported.
+				require.Equal(t, 1, sms.Metrics().Len())
+				assert.Equal(t, "k8s.persistentvolume.capacity", sms.Metrics().At(0).Name())
+				return
+			}
+			require.Equal(t, 2, sms.Metrics().Len())
+			sms.Metrics().Sort(func(a, b pmetric.Metric) bool {
+				return a.Name() < b.Name()
+			})
+			testutils.AssertMetricInt(t, sms.Metrics().At(1), "k8s.persistentvolume.phase", pmetric.MetricTypeGauge, tt.expected)
+		})
+	}
+}
+
+func TestPersistentVolumeMetadata(t *testing.T) {
+	pv := testutils.NewPersistentVolume("1")
+
+	actualMetadata := GetMetadata(pv)
+
+	require.Equal(t, 1, len(actualMetadata))
+	require.Equal(t,
+		metadata.KubernetesMetadata{
+			EntityType:    "k8s.persistentvolume",
+			ResourceIDKey: "k8s.persistentvolume.uid",
+			ResourceID:    "test-pv-1-uid",
+			Metadata: map[string]string{
+				"k8s.workload.name":                   "test-pv-1",
+				"k8s.workload.kind":                   "PersistentVolume",
+				"persistentvolume.creation_timestamp": "0001-01-01T00:00:00Z",
+				"storage_class":                       "standard",
+				"reclaim_policy":                      "Delete",
+				"claim":                               "test-namespace/test-pvc-1",
+			},
+		},
+		*actualMetadata["test-pv-1-uid"],
+	)
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolumeclaim // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolumeclaim"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/constants"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+)
+
+const (
+	// Keys for persistent volume claim metadata.
+	storageClass = "storage_class"
+)
+
+func RecordMetrics(mb *metadata.MetricsBuilder, pvc *corev1.PersistentVolumeClaim, ts pcommon.Timestamp) {
+	if phase, ok := phaseToInt(pvc.Status.Phase); ok {
+		mb.RecordK8sPersistentvolumeclaimPhaseDataPoint(ts, int64(phase))
+	}
+	if requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		mb.RecordK8sPersistentvolumeclaimRequestedDataPoint(ts, requested.Value())
+	}
+	if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+		mb.RecordK8sPersistentvolumeclaimCapacityDataPoint(ts, capacity.Value())
+	}
+	rb := mb.NewResourceBuilder()
+	rb.SetK8sPersistentvolumeclaimUID(string(pvc.UID))
+	rb.SetK8sPersistentvolumeclaimName(pvc.Name)
+	rb.SetK8sNamespaceName(pvc.Namespace)
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		rb.SetK8sStorageclassName(*pvc.Spec.StorageClassName)
+	}
+	mb.EmitForResource(metadata.WithResource(rb.Emit()))
+}
+
+func phaseToInt(phase corev1.PersistentVolumeClaimPhase) (int32, bool) {
+	switch phase {
+	case corev1.ClaimPending:
+		return 1, true
+	case corev1.ClaimBound:
+		return 2, true
+	case corev1.ClaimLost:
+		return 3, true
+	default:
+		return 0, false
+	}
+}
+
+func GetMetadata(pvc *corev1.PersistentVolumeClaim) map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata {
+	km := metadata.GetGenericMetadata(&pvc.ObjectMeta, constants.K8sKindPersistentVolumeClaim)
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		km.Metadata[storageClass] = *pvc.Spec.StorageClassName
+	}
+	if pvc.Spec.VolumeName != "" {
+		km.Metadata[metadata.GetOTelNameFromKind("persistentvolume")] = pvc.Spec.VolumeName
+	}
+
+	return map[experimentalmetricmetadata.ResourceID]*metadata.KubernetesMetadata{experimentalmetricmetadata.ResourceID(pvc.UID): km}
+}
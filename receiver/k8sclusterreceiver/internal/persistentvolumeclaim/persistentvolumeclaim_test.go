@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package persistentvolumeclaim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/testutils"
+)
+
+func TestPersistentVolumeClaimMetrics(t *testing.T) {
+	pvc := testutils.NewPersistentVolumeClaim("1")
+
+	ts := pcommon.Timestamp(time.Now().UnixNano())
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.K8sPersistentvolumeclaimPhase.Enabled = true
+	mbc.Metrics.K8sPersistentvolumeclaimRequested.Enabled = true
+	mbc.Metrics.K8sPersistentvolumeclaimCapacity.Enabled = true
+	mb := metadata.NewMetricsBuilder(mbc, receivertest.NewNopCreateSettings())
+	RecordMetrics(mb, pvc, ts)
+	m := mb.Emit()
+
+	require.Equal(t, 1, m.ResourceMetrics().Len())
+	rm := m.ResourceMetrics().At(0)
+	assert.Equal(t,
+		map[string]any{
+			"k8s.persistentvolumeclaim.uid":  "test-pvc-1-uid",
+			"k8s.persistentvolumeclaim.name": "test-pvc-1",
+			"k8s.namespace.name":             "test-namespace",
+			"k8s.storageclass.name":          "standard",
+		},
+		rm.Resource().Attributes().AsRaw())
+
+	require.Equal(t, 1, rm.ScopeMetrics().Len())
+	sms := rm.ScopeMetrics().At(0)
+	require.Equal(t, 3, sms.Metrics().Len())
+	sms.Metrics().Sort(func(a, b pmetric.Metric) bool {
+		return a.Name() < b.Name()
+	})
+	testutils.AssertMetricInt(t, sms.Metrics().At(0), "k8s.persistentvolumeclaim.capacity", pmetric.MetricTypeGauge, 10*1024*1024*1024)
+	testutils.AssertMetricInt(t, sms.Metrics().At(1), "k8s.persistentvolumeclaim.phase", pmetric.MetricTypeGauge, 2)
+	testutils.AssertMetricInt(t, sms.Metrics().At(2), "k8s.persistentvolumeclaim.requested", pmetric.MetricTypeGauge, 5*1024*1024*1024)
+}
+
+func TestPersistentVolumeClaimPending(t *testing.T) {
+	pvc := testutils.NewPersistentVolumeClaim("1")
+	pvc.Status = corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending}
+
+	mbc := metadata.DefaultMetricsBuilderConfig()
+	mbc.Metrics.K8sPersistentvolumeclaimPhase.Enabled = true
+	mbc.Metrics.K8sPersistentvolumeclaimCapacity.Enabled = true
+	mb := metadata.NewMetricsBuilder(mbc, receivertest.NewNopCreateSettings())
+	RecordMetrics(mb, pvc, pcommon.Timestamp(time.Now().UnixNano()))
+	m := mb.Emit()
+
+	// The capacity isn't known until the claim is bound.
+	require.Equal(t, 1, m.MetricCount())
+	testutils.AssertMetricInt(t, m.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0),
+		"k8s.persistentvolumeclaim.phase", pmetric.MetricTypeGauge, 1)
+}
+
+func TestPersistentVolumeClaimMetadata(t *testing.T) {
+	pvc := testutils.NewPersistentVolumeClaim("1")
+
+	actualMetadata := GetMetadata(pvc)
+
+	require.Equal(t, 1, len(actualMetadata))
+	require.Equal(t,
+		metadata.KubernetesMetadata{
+			EntityType:    "k8s.persistentvolumeclaim",
+			ResourceIDKey: "k8s.persistentvolumeclaim.uid",
+			ResourceID:    "test-pvc-1-uid",
+			Metadata: map[string]string{
+				"k8s.workload.name":                        "test-pvc-1",
+				"k8s.workload.kind":                        "PersistentVolumeClaim",
+				"persistentvolumeclaim.creation_timestamp": "0001-01-01T00:00:00Z",
+				"storage_class":                            "standard",
+				"k8s.persistentvolume.name":                "test-pv-1",
+			},
+		},
+		*actualMetadata["test-pvc-1-uid"],
+	)
+}
@@ -24,6 +24,8 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/informers"
@@ -37,9 +39,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/deployment"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/gvk"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/hpa"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/ingress"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/jobs"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/node"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/pdb"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolume"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/persistentvolumeclaim"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/pod"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/replicaset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/replicationcontroller"
@@ -140,9 +146,17 @@ func (rw *resourceWatcher) prepareSharedInformerFactory() error {
 		"Job":                     {gvk.Job},
 		"CronJob":                 {gvk.CronJob, gvk.CronJobBeta},
 		"HorizontalPodAutoscaler": {gvk.HorizontalPodAutoscaler, gvk.HorizontalPodAutoscalerBeta},
+		"Ingress":                 {gvk.Ingress},
+		"PersistentVolume":        {gvk.PersistentVolume},
+		"PersistentVolumeClaim":   {gvk.PersistentVolumeClaim},
+		"PodDisruptionBudget":     {gvk.PodDisruptionBudget},
 	}
 
 	for kind, gvks := range supportedKinds {
+		if !rw.isKindEnabled(kind) {
+			rw.logger.Debug("No metrics enabled for the kind, not watching it", zap.String("kind", kind))
+			continue
+		}
 		anySupported := false
 		for _, gvk := range gvks {
 			supported, err := rw.isKindSupported(gvk)
@@ -170,6 +184,26 @@ func (rw *resourceWatcher) prepareSharedInformerFactory() error {
 	return nil
 }
 
+// isKindEnabled returns whether the kind has to be watched. The kinds which were added after the
+// initial set are only watched when at least one of their metrics is enabled, so that the receiver
+// doesn't require additional RBAC permissions unless they are needed.
+func (rw *resourceWatcher) isKindEnabled(kind string) bool {
+	m := rw.config.MetricsBuilderConfig.Metrics
+	switch kind {
+	case "Ingress":
+		return m.K8sIngressRules.Enabled || m.K8sIngressLoadBalancerAddresses.Enabled
+	case "PersistentVolume":
+		return m.K8sPersistentvolumePhase.Enabled || m.K8sPersistentvolumeCapacity.Enabled
+	case "PersistentVolumeClaim":
+		return m.K8sPersistentvolumeclaimPhase.Enabled || m.K8sPersistentvolumeclaimRequested.Enabled ||
+			m.K8sPersistentvolumeclaimCapacity.Enabled
+	case "PodDisruptionBudget":
+		return m.K8sPdbDisruptionsAllowed.Enabled || m.K8sPdbCurrentHealthy.Enabled ||
+			m.K8sPdbDesiredHealthy.Enabled || m.K8sPdbExpectedPods.Enabled
+	}
+	return true
+}
+
 func (rw *resourceWatcher) isKindSupported(gvk schema.GroupVersionKind) (bool, error) {
 	resources, err := rw.client.Discovery().ServerResourcesForGroupVersion(gvk.GroupVersion().String())
 	if err != nil {
@@ -220,6 +254,14 @@ func (rw *resourceWatcher) setupInformerForKind(kind schema.GroupVersionKind, fa
 		rw.setupInformer(kind, factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer())
 	case gvk.HorizontalPodAutoscalerBeta:
 		rw.setupInformer(kind, factory.Autoscaling().V2beta2().HorizontalPodAutoscalers().Informer())
+	case gvk.Ingress:
+		rw.setupInformer(kind, factory.Networking().V1().Ingresses().Informer())
+	case gvk.PersistentVolume:
+		rw.setupInformer(kind, factory.Core().V1().PersistentVolumes().Informer())
+	case gvk.PersistentVolumeClaim:
+		rw.setupInformer(kind, factory.Core().V1().PersistentVolumeClaims().Informer())
+	case gvk.PodDisruptionBudget:
+		rw.setupInformer(kind, factory.Policy().V1().PodDisruptionBudgets().Informer())
 	default:
 		rw.logger.Error("Could not setup an informer for provided group version kind",
 			zap.String("group version kind", kind.String()))
@@ -314,6 +356,14 @@ func (rw *resourceWatcher) objMetadata(obj interface{}) map[experimentalmetricme
 		return hpa.GetMetadata(o)
 	case *autoscalingv2beta2.HorizontalPodAutoscaler:
 		return hpa.GetMetadataBeta(o)
+	case *networkingv1.Ingress:
+		return ingress.GetMetadata(o)
+	case *corev1.PersistentVolume:
+		return persistentvolume.GetMetadata(o)
+	case *corev1.PersistentVolumeClaim:
+		return persistentvolumeclaim.GetMetadata(o)
+	case *policyv1.PodDisruptionBudget:
+		return pdb.GetMetadata(o)
 	}
 	return nil
 }
@@ -221,6 +221,46 @@ func TestPrepareSharedInformerFactory(t *testing.T) {
 	}
 }
 
+func TestPrepareSharedInformerFactoryOptionalKinds(t *testing.T) {
+	optionalKinds := []schema.GroupVersionKind{gvk.Ingress, gvk.PersistentVolume, gvk.PersistentVolumeClaim, gvk.PodDisruptionBudget}
+
+	t.Run("metrics_disabled", func(t *testing.T) {
+		rw := &resourceWatcher{
+			client:        newFakeClientWithAllResources(),
+			logger:        zap.NewNop(),
+			metadataStore: metadata.NewStore(),
+			config:        &Config{MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig()},
+		}
+		require.NoError(t, rw.prepareSharedInformerFactory())
+
+		assert.NotNil(t, rw.metadataStore.Get(gvk.Pod))
+		for _, kind := range optionalKinds {
+			assert.Nil(t, rw.metadataStore.Get(kind), kind.Kind)
+		}
+	})
+
+	t.Run("metrics_enabled", func(t *testing.T) {
+		mbc := metadata.DefaultMetricsBuilderConfig()
+		mbc.Metrics.K8sIngressRules.Enabled = true
+		mbc.Metrics.K8sPersistentvolumeCapacity.Enabled = true
+		mbc.Metrics.K8sPersistentvolumeclaimPhase.Enabled = true
+		mbc.Metrics.K8sPdbExpectedPods.Enabled = true
+		obs, logs := observer.New(zap.WarnLevel)
+		rw := &resourceWatcher{
+			client:        newFakeClientWithAllResources(),
+			logger:        zap.New(obs),
+			metadataStore: metadata.NewStore(),
+			config:        &Config{MetricsBuilderConfig: mbc},
+		}
+		require.NoError(t, rw.prepareSharedInformerFactory())
+
+		for _, kind := range optionalKinds {
+			assert.NotNil(t, rw.metadataStore.Get(kind), kind.Kind)
+		}
+		assert.Equal(t, 0, logs.Len())
+	})
+}
+
 func TestSetupInformerForKind(t *testing.T) {
 	obs, logs := observer.New(zap.WarnLevel)
 	obsLogger := zap.New(obs)
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver"
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// eventTTL is how long the Kubernetes API server keeps the events by default. The count of an
+// event not updated for longer than that isn't needed anymore to compute its new occurrences.
+const eventTTL = time.Hour
+
+// aggregationKey identifies the events considered as repetitions of each other.
+type aggregationKey struct {
+	involvedObject types.UID
+	eventType      string
+	reason         string
+	component      string
+	message        string
+}
+
+// aggregatedEvent is the aggregation of the occurrences of an event within an interval.
+type aggregatedEvent struct {
+	// event is the last update of the event.
+	event *corev1.Event
+	count int64
+	first time.Time
+	last  time.Time
+}
+
+type seenEvent struct {
+	count int32
+	seen  time.Time
+}
+
+// eventAggregator aggregates the occurrences of the repeated events until they are flushed.
+// Kubernetes reports the repetitions of an event by updating its count, so the occurrences
+// of an update are the difference between its count and the count previously seen. The first
+// time an event is seen, a single occurrence is counted.
+type eventAggregator struct {
+	mu     sync.Mutex
+	events map[aggregationKey]*aggregatedEvent
+	// keys keeps the order in which the events were first seen.
+	keys []aggregationKey
+	seen map[types.UID]seenEvent
+}
+
+func newEventAggregator() *eventAggregator {
+	return &eventAggregator{
+		events: map[aggregationKey]*aggregatedEvent{},
+		seen:   map[types.UID]seenEvent{},
+	}
+}
+
+func (a *eventAggregator) add(ev *corev1.Event, now time.Time) {
+	count := ev.Count
+	if count == 0 {
+		count = 1
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	previous, seenBefore := a.seen[ev.UID]
+	a.seen[ev.UID] = seenEvent{count: count, seen: now}
+	// The count of an event seen for the first time includes its occurrences before the receiver
+	// started, or before the interval, only its last occurrence is known to be new.
+	occurrences := int32(1)
+	if seenBefore {
+		occurrences = count - previous.count
+	}
+	if occurrences <= 0 {
+		// The update doesn't report new occurrences of the event.
+		return
+	}
+
+	last := getEventTimestamp(ev)
+	first := last
+
+	key := aggregationKey{
+		involvedObject: ev.InvolvedObject.UID,
+		eventType:      ev.Type,
+		reason:         ev.Reason,
+		component:      ev.Source.Component,
+		message:        ev.Message,
+	}
+	aggregated, ok := a.events[key]
+	if !ok {
+		a.events[key] = &aggregatedEvent{event: ev, count: int64(occurrences), first: first, last: last}
+		a.keys = append(a.keys, key)
+		return
+	}
+
+	aggregated.event = ev
+	aggregated.count += int64(occurrences)
+	if first.Before(aggregated.first) {
+		aggregated.first = first
+	}
+	if last.After(aggregated.last) {
+		aggregated.last = last
+	}
+}
+
+// flush returns the events aggregated since the previous flush, in the order they were first seen.
+func (a *eventAggregator) flush(now time.Time) []*aggregatedEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	events := make([]*aggregatedEvent, 0, len(a.keys))
+	for _, key := range a.keys {
+		events = append(events, a.events[key])
+	}
+	a.events = map[aggregationKey]*aggregatedEvent{}
+	a.keys = nil
+
+	for uid, seen := range a.seen {
+		if now.Sub(seen.seen) > eventTTL {
+			delete(a.seen, uid)
+		}
+	}
+	return events
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8seventsreceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestEventAggregator(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	aggregator := newEventAggregator()
+
+	ev := getEvent()
+	ev.Count = 2
+	ev.FirstTimestamp = v1.NewTime(now.Add(-time.Minute))
+	ev.LastTimestamp = v1.NewTime(now.Add(-30 * time.Second))
+	aggregator.add(ev, now)
+
+	// Kubernetes updates the count of the event when it repeats.
+	updated := ev.DeepCopy()
+	updated.Count = 5
+	updated.LastTimestamp = v1.NewTime(now)
+	aggregator.add(updated, now)
+
+	// An update without a new occurrence.
+	aggregator.add(updated.DeepCopy(), now)
+
+	other := getEvent()
+	other.UID = types.UID("other-event")
+	other.Reason = "other_reason"
+	other.Count = 0
+	other.FirstTimestamp = v1.NewTime(now)
+	aggregator.add(other, now)
+
+	// The occurrences of an event before it is first seen are not counted.
+	events := aggregator.flush(now)
+	require.Len(t, events, 2)
+	assert.Equal(t, updated, events[0].event)
+	assert.Equal(t, int64(4), events[0].count)
+	assert.Equal(t, now.Add(-30*time.Second), events[0].first)
+	assert.Equal(t, now, events[0].last)
+	assert.Equal(t, other, events[1].event)
+	assert.Equal(t, int64(1), events[1].count)
+
+	assert.Empty(t, aggregator.flush(now))
+
+	// Only the new occurrences are counted after a flush.
+	next := updated.DeepCopy()
+	next.Count = 6
+	next.LastTimestamp = v1.NewTime(now.Add(time.Minute))
+	aggregator.add(next, now.Add(time.Minute))
+	events = aggregator.flush(now.Add(time.Minute))
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(1), events[0].count)
+	assert.Equal(t, now.Add(time.Minute), events[0].first)
+
+	// The counts of the events which are not updated anymore are forgotten.
+	aggregator.flush(now.Add(2 * eventTTL))
+	assert.Empty(t, aggregator.seen)
+}
@@ -4,6 +4,9 @@
 package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver"
 
 import (
+	"errors"
+	"time"
+
 	k8s "k8s.io/client-go/kubernetes"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -16,11 +19,40 @@ type Config struct {
 	// List of ‘namespaces’ to collect events from.
 	Namespaces []string `mapstructure:"namespaces"`
 
+	// Aggregation of the events repeating within an interval into a single log record.
+	Aggregation AggregationConfig `mapstructure:"aggregation"`
+
+	// Enrichment of the log records with the metadata of the objects involved in the events.
+	InvolvedObject InvolvedObjectConfig `mapstructure:"involved_object"`
+
 	// For mocking
 	makeClient func(apiConf k8sconfig.APIConfig) (k8s.Interface, error)
 }
 
+// AggregationConfig defines the aggregation of the repeated events.
+type AggregationConfig struct {
+	// Enabled turns on the aggregation. The events having the same involved object, type,
+	// reason, source and message are then emitted once per interval.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval at which the aggregated events are emitted.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// InvolvedObjectConfig defines the metadata of the involved objects added to the log records.
+type InvolvedObjectConfig struct {
+	// Labels adds the labels of the involved object.
+	Labels bool `mapstructure:"labels"`
+
+	// Owners adds the name and UID of the workloads controlling the involved object,
+	// e.g. the ReplicaSet and Deployment of a Pod.
+	Owners bool `mapstructure:"owners"`
+}
+
 func (cfg *Config) Validate() error {
+	if cfg.Aggregation.Enabled && cfg.Aggregation.Interval <= 0 {
+		return errors.New("aggregation interval must be positive")
+	}
 	return cfg.APIConfig.Validate()
 }
 
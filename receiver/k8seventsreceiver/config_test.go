@@ -6,6 +6,7 @@ package k8seventsreceiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,6 +39,14 @@ func TestLoadConfig(t *testing.T) {
 				APIConfig: k8sconfig.APIConfig{
 					AuthType: k8sconfig.AuthTypeServiceAccount,
 				},
+				Aggregation: AggregationConfig{
+					Enabled:  true,
+					Interval: 30 * time.Second,
+				},
+				InvolvedObject: InvolvedObjectConfig{
+					Labels: true,
+					Owners: true,
+				},
 			},
 		},
 	}
@@ -56,3 +65,10 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAggregationInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Aggregation.Enabled = true
+	cfg.Aggregation.Interval = 0
+	assert.EqualError(t, cfg.Validate(), "aggregation interval must be positive")
+}
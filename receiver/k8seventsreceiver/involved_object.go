@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	k8s "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const involvedObjectLabelsPrefix = "k8s.object.labels."
+
+// ownerAttributes are the resource attributes holding the name and UID of the owners by kind.
+var ownerAttributes = map[string][2]string{
+	"ReplicaSet":  {conventions.AttributeK8SReplicaSetName, conventions.AttributeK8SReplicaSetUID},
+	"Deployment":  {conventions.AttributeK8SDeploymentName, conventions.AttributeK8SDeploymentUID},
+	"StatefulSet": {conventions.AttributeK8SStatefulSetName, conventions.AttributeK8SStatefulSetUID},
+	"DaemonSet":   {conventions.AttributeK8SDaemonSetName, conventions.AttributeK8SDaemonSetUID},
+	"Job":         {conventions.AttributeK8SJobName, conventions.AttributeK8SJobUID},
+	"CronJob":     {conventions.AttributeK8SCronJobName, conventions.AttributeK8SCronJobUID},
+}
+
+// involvedObjectSyncTimeout is the maximum time the events wait for the informers of a kind to
+// be synced, e.g. when the permissions to list the objects are missing. Past it, the events are
+// enriched with the objects found in the stores so far.
+const involvedObjectSyncTimeout = 10 * time.Second
+
+// newInformerFuncs holds the constructors of the informers of the kinds of objects that can be looked up.
+var newInformerFuncs = map[string]func(informers.SharedInformerFactory) cache.SharedIndexInformer{
+	"Pod": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Core().V1().Pods().Informer()
+	},
+	"ReplicaSet": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().ReplicaSets().Informer()
+	},
+	"Deployment": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().Deployments().Informer()
+	},
+	"StatefulSet": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().StatefulSets().Informer()
+	},
+	"DaemonSet": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Apps().V1().DaemonSets().Informer()
+	},
+	"Job": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Batch().V1().Jobs().Informer()
+	},
+	"CronJob": func(f informers.SharedInformerFactory) cache.SharedIndexInformer {
+		return f.Batch().V1().CronJobs().Informer()
+	},
+}
+
+// kindStore holds the stores of the informers of a kind, one per watched namespace.
+type kindStore struct {
+	stores []cache.Store
+	synced chan struct{}
+	// deadline is the time after which the events no longer wait for the informers to be synced.
+	deadline time.Time
+}
+
+// involvedObjectStore looks up the metadata of the objects involved in the events in the
+// stores of informers watching the Pods and the workloads. The informers of a kind are started
+// with the first event involving an object of this kind, or at start for the workloads when
+// the owners are looked up.
+type involvedObjectStore struct {
+	logger      *zap.Logger
+	config      InvolvedObjectConfig
+	factories   []informers.SharedInformerFactory
+	syncTimeout time.Duration
+	stopCh      <-chan struct{}
+
+	mu    sync.Mutex
+	kinds map[string]*kindStore
+}
+
+func newInvolvedObjectStore(logger *zap.Logger, client k8s.Interface, config InvolvedObjectConfig, namespaces []string) *involvedObjectStore {
+	s := &involvedObjectStore{
+		logger:      logger,
+		config:      config,
+		syncTimeout: involvedObjectSyncTimeout,
+		kinds:       map[string]*kindStore{},
+	}
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+	for _, ns := range namespaces {
+		s.factories = append(s.factories, informers.NewSharedInformerFactoryWithOptions(client, 0, informers.WithNamespace(ns)))
+	}
+	return s
+}
+
+// start starts the informers of the workloads if the owners are looked up, the others are
+// started when needed.
+func (s *involvedObjectStore) start(stopCh <-chan struct{}) {
+	s.stopCh = stopCh
+	if s.config.Owners {
+		s.kindStore("Pod")
+		for kind := range ownerAttributes {
+			s.kindStore(kind)
+		}
+	}
+}
+
+// kindStore returns the stores of a kind, starting its informers if needed. It returns nil for
+// the kinds that can't be looked up.
+func (s *involvedObjectStore) kindStore(kind string) *kindStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ks, ok := s.kinds[kind]; ok {
+		return ks
+	}
+	newInformer, ok := newInformerFuncs[kind]
+	if !ok {
+		return nil
+	}
+
+	ks := &kindStore{
+		synced:   make(chan struct{}),
+		deadline: time.Now().Add(s.syncTimeout),
+	}
+	var hasSynced []cache.InformerSynced
+	for _, factory := range s.factories {
+		informer := newInformer(factory)
+		setMetadataTransform(informer)
+		ks.stores = append(ks.stores, informer.GetStore())
+		hasSynced = append(hasSynced, informer.HasSynced)
+		// Start only starts the informers that are not running yet
+		factory.Start(s.stopCh)
+	}
+	go func() {
+		if cache.WaitForCacheSync(s.stopCh, hasSynced...) {
+			close(ks.synced)
+		}
+	}()
+	s.kinds[kind] = ks
+	return ks
+}
+
+// setMetadataTransform drops all but the metadata of the objects to save memory.
+func setMetadataTransform(informer cache.SharedIndexInformer) {
+	_ = informer.SetTransform(func(obj interface{}) (interface{}, error) {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			// Tombstones of the deleted objects are kept as is.
+			return obj, nil
+		}
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Name:            accessor.GetName(),
+			Namespace:       accessor.GetNamespace(),
+			UID:             accessor.GetUID(),
+			ResourceVersion: accessor.GetResourceVersion(),
+			Labels:          accessor.GetLabels(),
+			OwnerReferences: accessor.GetOwnerReferences(),
+		}}, nil
+	})
+}
+
+// waitForSync waits for the informers of a kind to be synced until the sync deadline, and
+// reports whether the receiver is still running.
+func (s *involvedObjectStore) waitForSync(kind string, ks *kindStore) bool {
+	select {
+	case <-ks.synced:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(time.Until(ks.deadline))
+	defer timer.Stop()
+	select {
+	case <-ks.synced:
+	case <-timer.C:
+		s.logger.Debug("informers of the involved objects are not synced, enriching from the objects found so far",
+			zap.String("kind", kind))
+	case <-s.stopCh:
+		return false
+	}
+	return true
+}
+
+// get returns the metadata of an object, nil if it isn't found.
+func (s *involvedObjectStore) get(kind, namespace, name string, uid string) metav1.Object {
+	ks := s.kindStore(kind)
+	if ks == nil || !s.waitForSync(kind, ks) {
+		return nil
+	}
+
+	key := name
+	if namespace != "" {
+		key = namespace + "/" + name
+	}
+	for _, store := range ks.stores {
+		obj, exists, err := store.GetByKey(key)
+		if err != nil || !exists {
+			continue
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil || (uid != "" && string(accessor.GetUID()) != uid) {
+			continue
+		}
+		return accessor
+	}
+	return nil
+}
+
+// enrich adds the metadata of the involved object of an event to the resource attributes.
+func (s *involvedObjectStore) enrich(attrs pcommon.Map, ref corev1.ObjectReference) {
+	obj := s.get(ref.Kind, ref.Namespace, ref.Name, string(ref.UID))
+	if obj == nil {
+		return
+	}
+
+	if s.config.Labels {
+		for key, value := range obj.GetLabels() {
+			attrs.PutStr(involvedObjectLabelsPrefix+key, value)
+		}
+	}
+	if s.config.Owners {
+		s.addOwners(attrs, obj)
+	}
+}
+
+// addOwners adds the controllers of the object, and theirs in turn.
+func (s *involvedObjectStore) addOwners(attrs pcommon.Map, obj metav1.Object) {
+	ref := metav1.GetControllerOf(obj)
+	if ref == nil {
+		return
+	}
+	names, ok := ownerAttributes[ref.Kind]
+	if !ok {
+		return
+	}
+	attrs.PutStr(names[0], ref.Name)
+	attrs.PutStr(names[1], string(ref.UID))
+
+	if owner := s.get(ref.Kind, obj.GetNamespace(), ref.Name, string(ref.UID)); owner != nil {
+		s.addOwners(attrs, owner)
+	}
+}
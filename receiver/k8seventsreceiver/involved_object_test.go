@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8seventsreceiver
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func newFakeClientWithWorkload(t *testing.T) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	controller := true
+	ctx := context.Background()
+
+	_, err := client.AppsV1().Deployments("test").Create(ctx, &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{Name: "test", Namespace: "test", UID: "deployment-uid"},
+	}, v1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.AppsV1().ReplicaSets("test").Create(ctx, &appsv1.ReplicaSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-34bcd",
+			Namespace: "test",
+			UID:       "replicaset-uid",
+			OwnerReferences: []v1.OwnerReference{
+				{Kind: "Deployment", Name: "test", UID: "deployment-uid", Controller: &controller},
+			},
+		},
+	}, v1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("test").Create(ctx, &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "test-34bcd-rn54",
+			Namespace: "test",
+			UID:       "059f3edc-b5a9",
+			Labels:    map[string]string{"app": "test"},
+			OwnerReferences: []v1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "test-34bcd", UID: "replicaset-uid", Controller: &controller},
+			},
+		},
+	}, v1.CreateOptions{})
+	require.NoError(t, err)
+	return client
+}
+
+func TestInvolvedObjectStoreEnrich(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   InvolvedObjectConfig
+		ref      corev1.ObjectReference
+		expected map[string]any
+	}{
+		{
+			name:   "labels_and_owners",
+			config: InvolvedObjectConfig{Labels: true, Owners: true},
+			ref:    getEvent().InvolvedObject,
+			expected: map[string]any{
+				"k8s.object.labels.app": "test",
+				"k8s.replicaset.name":   "test-34bcd",
+				"k8s.replicaset.uid":    "replicaset-uid",
+				"k8s.deployment.name":   "test",
+				"k8s.deployment.uid":    "deployment-uid",
+			},
+		},
+		{
+			name:   "labels",
+			config: InvolvedObjectConfig{Labels: true},
+			ref:    getEvent().InvolvedObject,
+			expected: map[string]any{
+				"k8s.object.labels.app": "test",
+			},
+		},
+		{
+			name:   "owner_of_workload",
+			config: InvolvedObjectConfig{Owners: true},
+			ref:    corev1.ObjectReference{Kind: "ReplicaSet", Namespace: "test", Name: "test-34bcd"},
+			expected: map[string]any{
+				"k8s.deployment.name": "test",
+				"k8s.deployment.uid":  "deployment-uid",
+			},
+		},
+		{
+			name:   "uid_mismatch",
+			config: InvolvedObjectConfig{Labels: true, Owners: true},
+			ref: corev1.ObjectReference{
+				Kind: "Pod", Namespace: "test", Name: "test-34bcd-rn54", UID: types.UID("previous-pod-uid"),
+			},
+			expected: map[string]any{},
+		},
+		{
+			name:     "unsupported_kind",
+			config:   InvolvedObjectConfig{Labels: true, Owners: true},
+			ref:      corev1.ObjectReference{Kind: "Node", Name: "node"},
+			expected: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			store := newInvolvedObjectStore(zap.NewNop(), newFakeClientWithWorkload(t), tt.config, []string{"test"})
+			store.start(stopCh)
+
+			attrs := pcommon.NewMap()
+			store.enrich(attrs, tt.ref)
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func TestInvolvedObjectStoreInformers(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   InvolvedObjectConfig
+		expected []string
+	}{
+		{
+			name:     "labels",
+			config:   InvolvedObjectConfig{Labels: true},
+			expected: []string{"Pod"},
+		},
+		{
+			name:     "owners",
+			config:   InvolvedObjectConfig{Owners: true},
+			expected: []string{"CronJob", "DaemonSet", "Deployment", "Job", "Pod", "ReplicaSet", "StatefulSet"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			store := newInvolvedObjectStore(zap.NewNop(), newFakeClientWithWorkload(t), tt.config, []string{"test"})
+			store.start(stopCh)
+			store.enrich(pcommon.NewMap(), getEvent().InvolvedObject)
+
+			var kinds []string
+			for kind := range store.kinds {
+				kinds = append(kinds, kind)
+			}
+			sort.Strings(kinds)
+			assert.Equal(t, tt.expected, kinds)
+		})
+	}
+}
+
+func TestInvolvedObjectStoreSyncTimeout(t *testing.T) {
+	client := newFakeClientWithWorkload(t)
+	// the informers never sync without the permission to list the Pods
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	store := newInvolvedObjectStore(zap.NewNop(), client, InvolvedObjectConfig{Labels: true}, []string{"test"})
+	store.syncTimeout = 100 * time.Millisecond
+	store.start(stopCh)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		attrs := pcommon.NewMap()
+		store.enrich(attrs, getEvent().InvolvedObject)
+		assert.Equal(t, map[string]any{}, attrs.AsRaw())
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "enrich is blocked by the informers not being synced")
+	}
+}
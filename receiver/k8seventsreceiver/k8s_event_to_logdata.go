@@ -5,6 +5,7 @@ package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -81,3 +82,18 @@ func k8sEventToLogData(logger *zap.Logger, ev *corev1.Event) plog.Logs {
 
 	return ld
 }
+
+// aggregatedEventToLogData converts the aggregation of the occurrences of an event to plog.Logs.
+// The record holds the last update of the event, with the count and time range of the occurrences
+// aggregated within the interval.
+func aggregatedEventToLogData(logger *zap.Logger, aggregated *aggregatedEvent) plog.Logs {
+	ld := k8sEventToLogData(logger, aggregated.event)
+	lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(aggregated.last))
+
+	attrs := lr.Attributes()
+	attrs.PutInt("k8s.event.count", aggregated.count)
+	attrs.PutStr("k8s.event.first_timestamp", aggregated.first.Format(time.RFC3339))
+	attrs.PutStr("k8s.event.last_timestamp", aggregated.last.Format(time.RFC3339))
+	return ld
+}
@@ -5,11 +5,13 @@ package k8seventsreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -19,6 +21,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8seventsreceiver/internal/metadata"
 )
 
+// enrichQueueSize is the number of events waiting to be enriched with their involved object before
+// the event handlers block.
+const enrichQueueSize = 1000
+
 type k8seventsReceiver struct {
 	config          *Config
 	settings        receiver.CreateSettings
@@ -29,6 +35,12 @@ type k8seventsReceiver struct {
 	ctx             context.Context
 	cancel          context.CancelFunc
 	obsrecv         *obsreport.Receiver
+	aggregator      *eventAggregator
+	involvedObjects *involvedObjectStore
+	// enrichQueue holds the events to enrich and emit, so that the event handlers don't wait for the
+	// informers of the involved objects to be synced.
+	enrichQueue chan *corev1.Event
+	wg          sync.WaitGroup
 }
 
 // newReceiver creates the Kubernetes events receiver with the given configuration.
@@ -49,19 +61,38 @@ func newReceiver(
 		return nil, err
 	}
 
-	return &k8seventsReceiver{
+	kr := &k8seventsReceiver{
 		settings:     set,
 		config:       config,
 		client:       client,
 		logsConsumer: consumer,
 		startTime:    time.Now(),
 		obsrecv:      obsrecv,
-	}, nil
+	}
+	if config.Aggregation.Enabled {
+		kr.aggregator = newEventAggregator()
+	}
+	if config.InvolvedObject.Labels || config.InvolvedObject.Owners {
+		kr.involvedObjects = newInvolvedObjectStore(set.Logger, client, config.InvolvedObject, config.Namespaces)
+	}
+	return kr, nil
 }
 
 func (kr *k8seventsReceiver) Start(ctx context.Context, _ component.Host) error {
 	kr.ctx, kr.cancel = context.WithCancel(ctx)
 
+	if kr.involvedObjects != nil {
+		kr.involvedObjects.start(kr.ctx.Done())
+	}
+	if kr.aggregator != nil {
+		kr.wg.Add(1)
+		go kr.flushAggregatedEvents()
+	} else if kr.involvedObjects != nil {
+		kr.enrichQueue = make(chan *corev1.Event, enrichQueueSize)
+		kr.wg.Add(1)
+		go kr.enrichEvents()
+	}
+
 	kr.settings.Logger.Info("starting to watch namespaces for the events.")
 	if len(kr.config.Namespaces) == 0 {
 		kr.startWatch(corev1.NamespaceAll)
@@ -79,7 +110,10 @@ func (kr *k8seventsReceiver) Shutdown(context.Context) error {
 	for _, stopperChan := range kr.stopperChanList {
 		close(stopperChan)
 	}
-	kr.cancel()
+	if kr.cancel != nil {
+		kr.cancel()
+	}
+	kr.wg.Wait()
 	return nil
 }
 
@@ -102,13 +136,96 @@ func (kr *k8seventsReceiver) startWatch(ns string) {
 }
 
 func (kr *k8seventsReceiver) handleEvent(ev *corev1.Event) {
-	if kr.allowEvent(ev) {
-		ld := k8sEventToLogData(kr.settings.Logger, ev)
+	if !kr.allowEvent(ev) {
+		return
+	}
+	if kr.aggregator != nil {
+		kr.aggregator.add(ev, time.Now())
+		return
+	}
+	if kr.enrichQueue != nil {
+		select {
+		case kr.enrichQueue <- ev:
+		case <-kr.ctx.Done():
+		}
+		return
+	}
+	kr.emitEvent(kr.ctx, ev)
+}
+
+func (kr *k8seventsReceiver) emitEvent(ctx context.Context, ev *corev1.Event) {
+	ld := k8sEventToLogData(kr.settings.Logger, ev)
+	kr.enrich(ld, ev)
+
+	ctx = kr.obsrecv.StartLogsOp(ctx)
+	consumerErr := kr.logsConsumer.ConsumeLogs(ctx, ld)
+	kr.obsrecv.EndLogsOp(ctx, metadata.Type, 1, consumerErr)
+}
+
+// enrichEvents emits the queued events, in order, until the receiver stops. The events still queued
+// then are emitted without waiting for the informers.
+func (kr *k8seventsReceiver) enrichEvents() {
+	defer kr.wg.Done()
+
+	for {
+		select {
+		case ev := <-kr.enrichQueue:
+			kr.emitEvent(kr.ctx, ev)
+		case <-kr.ctx.Done():
+			for {
+				select {
+				case ev := <-kr.enrichQueue:
+					kr.emitEvent(context.Background(), ev)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// flushAggregatedEvents emits the aggregated events at every interval, and once more when the receiver stops.
+func (kr *k8seventsReceiver) flushAggregatedEvents() {
+	defer kr.wg.Done()
+
+	ticker := time.NewTicker(kr.config.Aggregation.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			kr.emitAggregatedEvents(kr.ctx)
+		case <-kr.ctx.Done():
+			// The context of the receiver is cancelled already, the last events are flushed without it.
+			kr.emitAggregatedEvents(context.Background())
+			return
+		}
+	}
+}
+
+func (kr *k8seventsReceiver) emitAggregatedEvents(ctx context.Context) {
+	events := kr.aggregator.flush(time.Now())
+	if len(events) == 0 {
+		return
+	}
+
+	ld := plog.NewLogs()
+	for _, aggregated := range events {
+		eventLogs := aggregatedEventToLogData(kr.settings.Logger, aggregated)
+		kr.enrich(eventLogs, aggregated.event)
+		eventLogs.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	}
+
+	ctx = kr.obsrecv.StartLogsOp(ctx)
+	consumerErr := kr.logsConsumer.ConsumeLogs(ctx, ld)
+	kr.obsrecv.EndLogsOp(ctx, metadata.Type, len(events), consumerErr)
+}
 
-		ctx := kr.obsrecv.StartLogsOp(kr.ctx)
-		consumerErr := kr.logsConsumer.ConsumeLogs(ctx, ld)
-		kr.obsrecv.EndLogsOp(ctx, metadata.Type, 1, consumerErr)
+// enrich adds the metadata of the involved object of the event to the resource of the logs.
+func (kr *k8seventsReceiver) enrich(ld plog.Logs, ev *corev1.Event) {
+	if kr.involvedObjects == nil {
+		return
 	}
+	kr.involvedObjects.enrich(ld.ResourceLogs().At(0).Resource().Attributes(), ev.InvolvedObject)
 }
 
 // startWatchingNamespace creates an informer and starts
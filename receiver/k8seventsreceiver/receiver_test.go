@@ -5,6 +5,7 @@ package k8seventsreceiver
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -15,8 +16,10 @@ import (
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestNewReceiver(t *testing.T) {
@@ -68,6 +71,102 @@ func TestHandleEvent(t *testing.T) {
 	assert.Equal(t, sink.LogRecordCount(), 1)
 }
 
+func TestHandleEventAggregation(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.Aggregation.Enabled = true
+	client := fake.NewSimpleClientset()
+	sink := new(consumertest.LogsSink)
+	r, err := newReceiver(
+		receivertest.NewNopCreateSettings(),
+		rCfg,
+		sink,
+		client,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+	recv := r.(*k8seventsReceiver)
+	recv.ctx = context.Background()
+	k8sEvent := getEvent()
+	recv.handleEvent(k8sEvent)
+	updated := k8sEvent.DeepCopy()
+	updated.Count = 4
+	updated.LastTimestamp = v1.Now()
+	recv.handleEvent(updated)
+	assert.Equal(t, 0, sink.LogRecordCount())
+
+	recv.emitAggregatedEvents(context.Background())
+	require.Equal(t, 1, sink.LogRecordCount())
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	count, ok := lr.Attributes().Get("k8s.event.count")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), count.Int())
+	firstTimestamp, ok := lr.Attributes().Get("k8s.event.first_timestamp")
+	require.True(t, ok)
+	assert.Equal(t, k8sEvent.FirstTimestamp.Format(time.RFC3339), firstTimestamp.Str())
+	assert.Equal(t, updated.LastTimestamp.Unix(), lr.Timestamp().AsTime().Unix())
+}
+
+func TestHandleEventInvolvedObject(t *testing.T) {
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.InvolvedObject = InvolvedObjectConfig{Labels: true, Owners: true}
+	sink := new(consumertest.LogsSink)
+	r, err := newReceiver(
+		receivertest.NewNopCreateSettings(),
+		rCfg,
+		sink,
+		newFakeClientWithWorkload(t),
+	)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	r.(*k8seventsReceiver).handleEvent(getEvent())
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	attrs := sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes()
+	deployment, ok := attrs.Get("k8s.deployment.name")
+	require.True(t, ok)
+	assert.Equal(t, "test", deployment.Str())
+	label, ok := attrs.Get("k8s.object.labels.app")
+	require.True(t, ok)
+	assert.Equal(t, "test", label.Str())
+}
+
+func TestHandleEventInvolvedObjectNotSynced(t *testing.T) {
+	client := newFakeClientWithWorkload(t)
+	// the informers never sync without the permission to list the Pods
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.InvolvedObject = InvolvedObjectConfig{Labels: true}
+	sink := new(consumertest.LogsSink)
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), rCfg, sink, client)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	// the event handler doesn't wait for the informers to be synced
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.(*k8seventsReceiver).handleEvent(getEvent())
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "the event handler is blocked by the informers not being synced")
+	}
+	assert.Equal(t, 0, sink.LogRecordCount())
+
+	// the queued events are emitted when the receiver stops
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
 func TestDropEventsOlderThanStartupTime(t *testing.T) {
 	rCfg := createDefaultConfig().(*Config)
 	client := fake.NewSimpleClientset()
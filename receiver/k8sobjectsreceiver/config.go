@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
@@ -40,6 +41,7 @@ type K8sObjectsConfig struct {
 	FieldSelector   string        `mapstructure:"field_selector"`
 	Interval        time.Duration `mapstructure:"interval"`
 	ResourceVersion string        `mapstructure:"resource_version"`
+	EmitDiffs       bool          `mapstructure:"emit_diffs"`
 	gvr             *schema.GroupVersionResource
 }
 
@@ -48,6 +50,10 @@ type Config struct {
 
 	Objects []*K8sObjectsConfig `mapstructure:"objects"`
 
+	// StorageID is the ID of the storage extension used to persist the last seen resourceVersion
+	// of the watched objects, so that watches resume where they stopped when the collector restarts.
+	StorageID *component.ID `mapstructure:"storage"`
+
 	// For mocking purposes only.
 	makeDiscoveryClient func() (discovery.ServerResourcesInterface, error)
 	makeDynamicClient   func() (dynamic.Interface, error)
@@ -87,6 +93,10 @@ func (c *Config) Validate() error {
 			object.Interval = defaultPullInterval
 		}
 
+		if object.Mode == PullMode && object.EmitDiffs {
+			return fmt.Errorf("emit_diffs is only supported in %v mode", WatchMode)
+		}
+
 		object.gvr = gvr
 	}
 	return nil
@@ -56,6 +56,7 @@ func TestLoadConfig(t *testing.T) {
 			Namespaces:      []string{"default"},
 			Group:           "events.k8s.io",
 			ResourceVersion: "",
+			EmitDiffs:       true,
 			gvr: &schema.GroupVersionResource{
 				Group:    "events.k8s.io",
 				Version:  "v1",
@@ -65,6 +66,8 @@ func TestLoadConfig(t *testing.T) {
 	}
 	assert.EqualValues(t, expected, cfg.Objects)
 
+	storageID := component.NewID("file_storage")
+	assert.Equal(t, &storageID, cfg.StorageID)
 }
 
 func TestValidConfigs(t *testing.T) {
@@ -85,6 +88,15 @@ func TestValidConfigs(t *testing.T) {
 	err = component.ValidateConfig(cfg)
 	assert.ErrorContains(t, err, "resource fake_resource not found")
 
+	cfg = factory.CreateDefaultConfig().(*Config)
+	sub, err = cm.Sub("k8sobjects/invalid_emit_diffs")
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	cfg.makeDiscoveryClient = getMockDiscoveryClient
+
+	err = component.ValidateConfig(cfg)
+	assert.ErrorContains(t, err, "emit_diffs is only supported in watch mode")
 }
 
 func TestValidateResourceConflict(t *testing.T) {
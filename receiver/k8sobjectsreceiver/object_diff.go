@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+// ignoredDiffFields are the metadata fields which change along with any update of an object,
+// they don't make an update worth reporting on their own.
+var ignoredDiffFields = []string{"resourceVersion", "managedFields", "generation"}
+
+// objectCache keeps the last seen state of the watched objects, so that only the fields
+// changed by an update are emitted.
+type objectCache struct {
+	objects map[types.UID]map[string]interface{}
+}
+
+func newObjectCache() *objectCache {
+	return &objectCache{objects: map[types.UID]map[string]interface{}{}}
+}
+
+// diff records the state of the object of the event and returns the event to emit. The object of
+// a MODIFIED event is replaced by a JSON merge patch (RFC 7386) from its previous state, which
+// holds the changed fields, null for the removed ones and the fields identifying the object.
+// False is returned when nothing worth reporting changed.
+func (c *objectCache) diff(event *apiWatch.Event) (*apiWatch.Event, bool) {
+	udata, ok := event.Object.(*unstructured.Unstructured)
+	if !ok {
+		return event, true
+	}
+
+	uid := udata.GetUID()
+	switch event.Type {
+	case apiWatch.Deleted:
+		delete(c.objects, uid)
+		return event, true
+	case apiWatch.Modified:
+		previous, ok := c.objects[uid]
+		c.objects[uid] = udata.Object
+		if !ok {
+			return event, true
+		}
+
+		patch := mergePatch(previous, udata.Object)
+		if metadata, ok := patch["metadata"].(map[string]interface{}); ok {
+			for _, field := range ignoredDiffFields {
+				delete(metadata, field)
+			}
+			if len(metadata) == 0 {
+				delete(patch, "metadata")
+			}
+		}
+		if len(patch) == 0 {
+			return nil, false
+		}
+
+		diff := &unstructured.Unstructured{Object: patch}
+		diff.SetAPIVersion(udata.GetAPIVersion())
+		diff.SetKind(udata.GetKind())
+		diff.SetName(udata.GetName())
+		diff.SetNamespace(udata.GetNamespace())
+		diff.SetUID(uid)
+		diff.SetResourceVersion(udata.GetResourceVersion())
+		return &apiWatch.Event{Type: event.Type, Object: diff}, true
+	default:
+		c.objects[uid] = udata.Object
+		return event, true
+	}
+}
+
+// seed records the state of the listed objects, without emitting them.
+func (c *objectCache) seed(objects []unstructured.Unstructured) {
+	for i := range objects {
+		c.objects[objects[i].GetUID()] = objects[i].Object
+	}
+}
+
+// resync records the state of the listed objects, and returns the events turning the last seen state
+// into the listed one: ADDED for the objects not seen before, MODIFIED for the objects which changed,
+// as emitted by diff, and DELETED for the objects which aren't listed anymore.
+func (c *objectCache) resync(objects []unstructured.Unstructured) []*apiWatch.Event {
+	var events []*apiWatch.Event
+	listed := make(map[types.UID]bool, len(objects))
+	for i := range objects {
+		object := &objects[i]
+		listed[object.GetUID()] = true
+		eventType := apiWatch.Modified
+		if _, ok := c.objects[object.GetUID()]; !ok {
+			eventType = apiWatch.Added
+		}
+		if event, ok := c.diff(&apiWatch.Event{Type: eventType, Object: object}); ok {
+			events = append(events, event)
+		}
+	}
+	for uid, object := range c.objects {
+		if listed[uid] {
+			continue
+		}
+		delete(c.objects, uid)
+		events = append(events, &apiWatch.Event{Type: apiWatch.Deleted, Object: &unstructured.Unstructured{Object: object}})
+	}
+	return events
+}
+
+// mergePatch returns the JSON merge patch turning the previous state of an object into the current one.
+// Lists are compared, and replaced, as a whole.
+func mergePatch(previous, current map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for field, value := range current {
+		previousValue, ok := previous[field]
+		if !ok {
+			patch[field] = value
+			continue
+		}
+
+		previousMap, previousIsMap := previousValue.(map[string]interface{})
+		currentMap, currentIsMap := value.(map[string]interface{})
+		if previousIsMap && currentIsMap {
+			if fieldPatch := mergePatch(previousMap, currentMap); len(fieldPatch) > 0 {
+				patch[field] = fieldPatch
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(previousValue, value) {
+			patch[field] = value
+		}
+	}
+	for field := range previous {
+		if _, ok := current[field]; !ok {
+			patch[field] = nil
+		}
+	}
+	return patch
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+)
+
+func TestMergePatch(t *testing.T) {
+	previous := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"paused":   true,
+			"ports":    []interface{}{int64(80)},
+		},
+		"status": map[string]interface{}{
+			"ready": int64(1),
+		},
+	}
+	current := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"ports":    []interface{}{int64(80), int64(443)},
+			"image":    "nginx",
+		},
+		"status": map[string]interface{}{
+			"ready": int64(1),
+		},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"paused":   nil,
+			"ports":    []interface{}{int64(80), int64(443)},
+			"image":    "nginx",
+		},
+	}, mergePatch(previous, current))
+	assert.Empty(t, mergePatch(current, current))
+}
+
+func TestObjectCacheDiff(t *testing.T) {
+	objects := newObjectCache()
+
+	pod := generatePod("pod1", "default", map[string]interface{}{"environment": "production"}, "1")
+	pod.SetUID("pod1-uid")
+	event, ok := objects.diff(&apiWatch.Event{Type: apiWatch.Added, Object: pod})
+	require.True(t, ok)
+	assert.Equal(t, pod, event.Object, "added objects are emitted as a whole")
+
+	updated := pod.DeepCopy()
+	updated.SetLabels(map[string]string{"environment": "test"})
+	updated.SetResourceVersion("2")
+	event, ok = objects.diff(&apiWatch.Event{Type: apiWatch.Modified, Object: updated})
+	require.True(t, ok)
+	assert.Equal(t, apiWatch.Modified, event.Type)
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pods",
+		"metadata": map[string]interface{}{
+			"name":            "pod1",
+			"namespace":       "default",
+			"uid":             "pod1-uid",
+			"resourceVersion": "2",
+			"labels":          map[string]interface{}{"environment": "test"},
+		},
+	}, event.Object.(*unstructured.Unstructured).Object)
+
+	// Only the resourceVersion changed.
+	touched := updated.DeepCopy()
+	touched.SetResourceVersion("3")
+	_, ok = objects.diff(&apiWatch.Event{Type: apiWatch.Modified, Object: touched})
+	assert.False(t, ok)
+
+	event, ok = objects.diff(&apiWatch.Event{Type: apiWatch.Deleted, Object: touched})
+	require.True(t, ok)
+	assert.Equal(t, touched, event.Object, "deleted objects are emitted as a whole")
+
+	// The state of deleted objects is forgotten.
+	event, ok = objects.diff(&apiWatch.Event{Type: apiWatch.Modified, Object: touched})
+	require.True(t, ok)
+	assert.Equal(t, touched, event.Object)
+}
+
+func TestObjectCacheResync(t *testing.T) {
+	objects := newObjectCache()
+
+	unchanged := generatePod("pod1", "default", map[string]interface{}{"environment": "production"}, "1")
+	unchanged.SetUID("pod1-uid")
+	modified := generatePod("pod2", "default", map[string]interface{}{"environment": "production"}, "1")
+	modified.SetUID("pod2-uid")
+	deleted := generatePod("pod3", "default", map[string]interface{}{"environment": "production"}, "1")
+	deleted.SetUID("pod3-uid")
+	objects.seed([]unstructured.Unstructured{*unchanged, *modified, *deleted})
+
+	updated := modified.DeepCopy()
+	updated.SetLabels(map[string]string{"environment": "test"})
+	updated.SetResourceVersion("2")
+	added := generatePod("pod4", "default", map[string]interface{}{"environment": "production"}, "2")
+	added.SetUID("pod4-uid")
+
+	events := objects.resync([]unstructured.Unstructured{*unchanged, *updated, *added})
+	require.Len(t, events, 3)
+	eventTypes := map[string]apiWatch.EventType{}
+	for _, event := range events {
+		eventTypes[event.Object.(*unstructured.Unstructured).GetName()] = event.Type
+	}
+	assert.Equal(t, map[string]apiWatch.EventType{
+		"pod2": apiWatch.Modified,
+		"pod3": apiWatch.Deleted,
+		"pod4": apiWatch.Added,
+	}, eventTypes)
+
+	// The listed state is the new last seen state.
+	assert.Empty(t, objects.resync([]unstructured.Unstructured{*unchanged, *updated, *added}))
+}
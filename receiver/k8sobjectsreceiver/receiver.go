@@ -6,15 +6,20 @@ package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-co
 import (
 	"context"
 	"fmt"
+	"path"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apiWatch "k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
@@ -23,6 +28,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver/internal/metadata"
 )
 
+// resourceVersionStoreInterval is the interval at which the last resourceVersion seen by a watch is stored,
+// so that the storage isn't written for each event.
+const resourceVersionStoreInterval = 5 * time.Second
+
 type k8sobjectsreceiver struct {
 	setting         receiver.CreateSettings
 	objects         []*K8sObjectsConfig
@@ -30,6 +39,10 @@ type k8sobjectsreceiver struct {
 	client          dynamic.Interface
 	consumer        consumer.Logs
 	obsrecv         *obsreport.Receiver
+	storageID       *component.ID
+	storageClient   storage.Client
+	cancel          context.CancelFunc
+	wg              sync.WaitGroup
 	mu              sync.Mutex
 }
 
@@ -50,34 +63,69 @@ func newReceiver(params receiver.CreateSettings, config *Config, consumer consum
 	}
 
 	return &k8sobjectsreceiver{
-		client:   client,
-		setting:  params,
-		consumer: consumer,
-		objects:  config.Objects,
-		obsrecv:  obsrecv,
-		mu:       sync.Mutex{},
+		client:    client,
+		setting:   params,
+		consumer:  consumer,
+		objects:   config.Objects,
+		obsrecv:   obsrecv,
+		storageID: config.StorageID,
+		cancel:    func() {},
+		mu:        sync.Mutex{},
 	}, nil
 }
 
-func (kr *k8sobjectsreceiver) Start(ctx context.Context, _ component.Host) error {
+func (kr *k8sobjectsreceiver) Start(ctx context.Context, host component.Host) error {
+	storageClient, err := getStorageClient(ctx, host, kr.storageID, kr.setting.ID)
+	if err != nil {
+		return err
+	}
+	kr.storageClient = storageClient
+
 	kr.setting.Logger.Info("Object Receiver started")
 
+	ctx, kr.cancel = context.WithCancel(ctx)
 	for _, object := range kr.objects {
 		kr.start(ctx, object)
 	}
 	return nil
 }
 
-func (kr *k8sobjectsreceiver) Shutdown(context.Context) error {
+func (kr *k8sobjectsreceiver) Shutdown(ctx context.Context) error {
 	kr.setting.Logger.Info("Object Receiver stopped")
 	kr.mu.Lock()
 	for _, stopperChan := range kr.stopperChanList {
 		close(stopperChan)
 	}
+	kr.stopperChanList = nil
 	kr.mu.Unlock()
+	// Cancel the requests in progress, such as the list of the objects when a watch starts
+	kr.cancel()
+	kr.wg.Wait()
+
+	if kr.storageClient != nil {
+		return kr.storageClient.Close(ctx)
+	}
 	return nil
 }
 
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
+}
+
 func (kr *k8sobjectsreceiver) start(ctx context.Context, object *K8sObjectsConfig) {
 	resource := kr.client.Resource(*object.gvr)
 	kr.setting.Logger.Info("Started collecting", zap.Any("gvr", object.gvr), zap.Any("mode", object.Mode), zap.Any("namespaces", object.Namespaces))
@@ -85,29 +133,37 @@ func (kr *k8sobjectsreceiver) start(ctx context.Context, object *K8sObjectsConfi
 	switch object.Mode {
 	case PullMode:
 		if len(object.Namespaces) == 0 {
-			go kr.startPull(ctx, object, resource)
+			go kr.startPull(ctx, object, resource, kr.newStopperChan())
 		} else {
 			for _, ns := range object.Namespaces {
-				go kr.startPull(ctx, object, resource.Namespace(ns))
+				go kr.startPull(ctx, object, resource.Namespace(ns), kr.newStopperChan())
 			}
 		}
 
 	case WatchMode:
 		if len(object.Namespaces) == 0 {
-			go kr.startWatch(ctx, object, resource)
+			kr.wg.Add(1)
+			go kr.startWatch(ctx, object, resource, "", kr.newStopperChan())
 		} else {
 			for _, ns := range object.Namespaces {
-				go kr.startWatch(ctx, object, resource.Namespace(ns))
+				kr.wg.Add(1)
+				go kr.startWatch(ctx, object, resource.Namespace(ns), ns, kr.newStopperChan())
 			}
 		}
 	}
 }
 
-func (kr *k8sobjectsreceiver) startPull(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) {
+// newStopperChan returns a channel closed when the receiver is shut down. It is registered before the
+// goroutine using it is started, so that a shutdown happening meanwhile closes it.
+func (kr *k8sobjectsreceiver) newStopperChan() chan struct{} {
 	stopperChan := make(chan struct{})
 	kr.mu.Lock()
 	kr.stopperChanList = append(kr.stopperChanList, stopperChan)
 	kr.mu.Unlock()
+	return stopperChan
+}
+
+func (kr *k8sobjectsreceiver) startPull(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, stopperChan chan struct{}) {
 	ticker := NewTicker(config.Interval)
 	listOption := metav1.ListOptions{
 		FieldSelector: config.FieldSelector,
@@ -140,18 +196,54 @@ func (kr *k8sobjectsreceiver) startPull(ctx context.Context, config *K8sObjectsC
 
 }
 
-func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) {
+func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface,
+	namespace string, stopperChan chan struct{}) {
+	defer kr.wg.Done()
 
-	stopperChan := make(chan struct{})
-	kr.mu.Lock()
-	kr.stopperChanList = append(kr.stopperChanList, stopperChan)
-	kr.mu.Unlock()
+	var objects *objectCache
+	if config.EmitDiffs {
+		objects = newObjectCache()
+	}
 
-	resourceVersion, err := getResourceVersion(ctx, config, resource)
-	if err != nil {
-		kr.setting.Logger.Error("could not retrieve an initial resourceVersion", zap.String("resource", config.gvr.String()), zap.Error(err))
-		return
+	storageKey := resourceVersionStorageKey(config, namespace)
+	resourceVersion := kr.loadResourceVersion(ctx, storageKey)
+	if resourceVersion == "" {
+		var err error
+		resourceVersion, err = getResourceVersion(ctx, config, resource, objects)
+		if err != nil {
+			kr.setting.Logger.Error("could not retrieve an initial resourceVersion", zap.String("resource", config.gvr.String()), zap.Error(err))
+			return
+		}
+	}
+
+	versions := &resourceVersionWriter{storageClient: kr.storageClient, logger: kr.setting.Logger, key: storageKey}
+	// The context may be cancelled, store the last resourceVersion seen regardless
+	defer versions.flush(context.Background())
+
+	for {
+		if expired := kr.doWatch(ctx, config, resource, resourceVersion, versions, objects, stopperChan); !expired {
+			return
+		}
+
+		// The events between the expired resourceVersion and the current state are lost, start again from a fresh list
+		// and emit the listed objects instead.
+		kr.setting.Logger.Warn("resourceVersion is too old, re-listing objects", zap.String("resource", config.gvr.String()), zap.String("resourceVersion", resourceVersion))
+		list, err := listObjects(ctx, config, resource)
+		if err != nil {
+			kr.setting.Logger.Error("could not retrieve a new resourceVersion", zap.String("resource", config.gvr.String()), zap.Error(err))
+			return
+		}
+		kr.emitEvents(ctx, config, relistEvents(list, objects))
+		resourceVersion = listedResourceVersion(list)
+		versions.set(resourceVersion)
+		versions.flush(ctx)
 	}
+}
+
+// doWatch watches the objects from the resourceVersion until the receiver is stopped.
+// It returns true if the watch stopped because the resourceVersion expired.
+func (kr *k8sobjectsreceiver) doWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface,
+	resourceVersion string, versions *resourceVersionWriter, objects *objectCache, stopperChan chan struct{}) bool {
 	watchFunc := func(options metav1.ListOptions) (apiWatch.Interface, error) {
 		options.FieldSelector = config.FieldSelector
 		options.LabelSelector = config.LabelSelector
@@ -161,60 +253,172 @@ func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjects
 	watcher, err := watch.NewRetryWatcher(resourceVersion, &cache.ListWatch{WatchFunc: watchFunc})
 	if err != nil {
 		kr.setting.Logger.Error("error in watching object", zap.String("resource", config.gvr.String()), zap.Error(err))
-		return
+		return false
 	}
+	defer watcher.Stop()
+
+	storeTicker := time.NewTicker(resourceVersionStoreInterval)
+	defer storeTicker.Stop()
 
 	res := watcher.ResultChan()
 	for {
 		select {
+		case <-storeTicker.C:
+			versions.flush(ctx)
 		case data, ok := <-res:
 			if !ok {
 				kr.setting.Logger.Warn("Watch channel closed unexpectedly", zap.String("resource", config.gvr.String()))
-				return
+				return false
 			}
-			logs, err := watchObjectsToLogData(&data, time.Now(), config)
-			if err != nil {
-				kr.setting.Logger.Error("error converting objects to log data", zap.Error(err))
-			} else {
-				obsCtx := kr.obsrecv.StartLogsOp(ctx)
-				err := kr.consumer.ConsumeLogs(obsCtx, logs)
-				kr.obsrecv.EndLogsOp(obsCtx, metadata.Type, 1, err)
+			if data.Type == apiWatch.Error {
+				err := apierrors.FromObject(data.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return true
+				}
+				kr.setting.Logger.Error("error in watching object", zap.String("resource", config.gvr.String()), zap.Error(err))
+				continue
 			}
+
+			event := &data
+			if objects != nil {
+				if event, ok = objects.diff(event); !ok {
+					versions.setFromObject(data.Object)
+					continue
+				}
+			}
+
+			kr.emitEvents(ctx, config, []*apiWatch.Event{event})
+			versions.setFromObject(data.Object)
 		case <-stopperChan:
-			watcher.Stop()
-			return
+			return false
+		}
+	}
+}
+
+func (kr *k8sobjectsreceiver) emitEvents(ctx context.Context, config *K8sObjectsConfig, events []*apiWatch.Event) {
+	for _, event := range events {
+		logs, err := watchObjectsToLogData(event, time.Now(), config)
+		if err != nil {
+			kr.setting.Logger.Error("error converting objects to log data", zap.Error(err))
+			continue
 		}
+		obsCtx := kr.obsrecv.StartLogsOp(ctx)
+		err = kr.consumer.ConsumeLogs(obsCtx, logs)
+		kr.obsrecv.EndLogsOp(obsCtx, metadata.Type, 1, err)
+	}
+}
+
+// relistEvents returns the events to emit for the objects listed again after the resourceVersion of a watch expired.
+// Without the last seen state of the objects, all the listed objects are emitted as MODIFIED.
+func relistEvents(list *unstructured.UnstructuredList, objects *objectCache) []*apiWatch.Event {
+	if objects != nil {
+		return objects.resync(list.Items)
+	}
+	events := make([]*apiWatch.Event, 0, len(list.Items))
+	for i := range list.Items {
+		events = append(events, &apiWatch.Event{Type: apiWatch.Modified, Object: &list.Items[i]})
+	}
+	return events
+}
+
+// resourceVersionStorageKey returns the key under which the last seen resourceVersion of a watch is stored.
+func resourceVersionStorageKey(config *K8sObjectsConfig, namespace string) string {
+	key := path.Join("resource_version", config.gvr.Group, config.gvr.Version, config.gvr.Resource, namespace)
+	if config.LabelSelector != "" || config.FieldSelector != "" {
+		key += fmt.Sprintf("?labels=%s&fields=%s", config.LabelSelector, config.FieldSelector)
+	}
+	return key
+}
+
+func (kr *k8sobjectsreceiver) loadResourceVersion(ctx context.Context, key string) string {
+	resourceVersion, err := kr.storageClient.Get(ctx, key)
+	if err != nil {
+		kr.setting.Logger.Warn("unable to load the resourceVersion from the storage, continuing without it", zap.String("key", key), zap.Error(err))
+		return ""
+	}
+	return string(resourceVersion)
+}
+
+// resourceVersionWriter keeps the last resourceVersion seen by a watch, to store it when flushed
+// rather than on each event.
+type resourceVersionWriter struct {
+	storageClient storage.Client
+	logger        *zap.Logger
+	key           string
+	latest        string
+	stored        string
+}
+
+func (w *resourceVersionWriter) set(resourceVersion string) {
+	w.latest = resourceVersion
+}
+
+func (w *resourceVersionWriter) setFromObject(object interface{}) {
+	accessor, err := meta.Accessor(object)
+	if err != nil || accessor.GetResourceVersion() == "" {
+		return
 	}
+	w.set(accessor.GetResourceVersion())
+}
 
+// flush stores the last resourceVersion seen, if it changed since it was last stored.
+func (w *resourceVersionWriter) flush(ctx context.Context) {
+	if w.latest == w.stored {
+		return
+	}
+	if err := w.storageClient.Set(ctx, w.key, []byte(w.latest)); err != nil {
+		w.logger.Error("unable to store the resourceVersion", zap.String("key", w.key), zap.Error(err))
+		return
+	}
+	w.stored = w.latest
 }
 
-func getResourceVersion(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) (string, error) {
+// getResourceVersion returns the resourceVersion to start watching from. When the objects are listed to get it,
+// their state is recorded in the objects cache, if any.
+func getResourceVersion(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, objects *objectCache) (string, error) {
 	resourceVersion := config.ResourceVersion
 	if resourceVersion == "" || resourceVersion == "0" {
-		// Proper use of the Kubernetes API Watch capability when no resourceVersion is supplied is to do a list first
-		// to get the initial state and a useable resourceVersion.
-		// See https://kubernetes.io/docs/reference/using-api/api-concepts/#efficient-detection-of-changes for details.
-		objects, err := resource.List(ctx, metav1.ListOptions{
-			FieldSelector: config.FieldSelector,
-			LabelSelector: config.LabelSelector,
-		})
+		list, err := listObjects(ctx, config, resource)
 		if err != nil {
-			return "", fmt.Errorf("could not perform initial list for watch on %v, %w", config.gvr.String(), err)
+			return "", err
 		}
-		if objects == nil {
-			return "", fmt.Errorf("nil objects returned, this is an error in the k8sobjectsreceiver")
+		if objects != nil {
+			objects.seed(list.Items)
 		}
+		return listedResourceVersion(list), nil
+	}
+	return resourceVersion, nil
+}
 
-		resourceVersion = objects.GetResourceVersion()
+// listObjects lists the objects to get their state and a resourceVersion to start watching from.
+func listObjects(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) (*unstructured.UnstructuredList, error) {
+	// Proper use of the Kubernetes API Watch capability when no resourceVersion is supplied is to do a list first
+	// to get the initial state and a useable resourceVersion.
+	// See https://kubernetes.io/docs/reference/using-api/api-concepts/#efficient-detection-of-changes for details.
+	objects, err := resource.List(ctx, metav1.ListOptions{
+		FieldSelector: config.FieldSelector,
+		LabelSelector: config.LabelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not perform initial list for watch on %v, %w", config.gvr.String(), err)
+	}
+	if objects == nil {
+		return nil, fmt.Errorf("nil objects returned, this is an error in the k8sobjectsreceiver")
+	}
+	return objects, nil
+}
 
-		// If we still don't have a resourceVersion we can try 1 as a last ditch effort.
-		// This also helps our unit tests since the fake client can't handle returning resource versions
-		// as part of a list of objects.
-		if resourceVersion == "" || resourceVersion == "0" {
-			resourceVersion = defaultResourceVersion
-		}
+// listedResourceVersion returns the resourceVersion of a list of objects.
+func listedResourceVersion(objects *unstructured.UnstructuredList) string {
+	resourceVersion := objects.GetResourceVersion()
+
+	// If we still don't have a resourceVersion we can try 1 as a last ditch effort.
+	// This also helps our unit tests since the fake client can't handle returning resource versions
+	// as part of a list of objects.
+	if resourceVersion == "" || resourceVersion == "0" {
+		resourceVersion = defaultResourceVersion
 	}
-	return resourceVersion, nil
+	return resourceVersion
 }
 
 // Start ticking immediately.
@@ -5,6 +5,8 @@ package k8sobjectsreceiver
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,7 +14,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiWatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestNewReceiver(t *testing.T) {
@@ -138,3 +150,257 @@ func TestWatchObject(t *testing.T) {
 
 	assert.NoError(t, r.Shutdown(ctx))
 }
+
+func TestShutdownRightAfterStart(t *testing.T) {
+	t.Parallel()
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = newMockDynamicClient().getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default", "other"},
+		},
+		{
+			Name: "pods",
+			Mode: PullMode,
+		},
+	}
+	require.NoError(t, rCfg.Validate())
+
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), rCfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The watches are stopped even if they didn't start yet
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "Shutdown is blocked")
+	}
+}
+
+// countingClient counts the values set in the storage.
+type countingClient struct {
+	storage.Client
+	sets []string
+}
+
+func (c *countingClient) Set(_ context.Context, _ string, value []byte) error {
+	c.sets = append(c.sets, string(value))
+	return nil
+}
+
+func TestResourceVersionWriter(t *testing.T) {
+	client := &countingClient{Client: storage.NewNopClient()}
+	w := &resourceVersionWriter{storageClient: client, logger: zap.NewNop(), key: "key"}
+	ctx := context.Background()
+
+	w.flush(ctx)
+	assert.Empty(t, client.sets)
+
+	// Only the last resourceVersion seen is stored
+	for _, rv := range []string{"1", "2", "3"} {
+		w.setFromObject(generatePod("pod", "default", map[string]interface{}{}, rv))
+	}
+	assert.Empty(t, client.sets)
+	w.flush(ctx)
+	assert.Equal(t, []string{"3"}, client.sets)
+
+	// It isn't stored again while it doesn't change
+	w.flush(ctx)
+	assert.Equal(t, []string{"3"}, client.sets)
+	w.set("4")
+	w.flush(ctx)
+	assert.Equal(t, []string{"3", "4"}, client.sets)
+}
+
+func TestWatchObjectResumesFromStorage(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockDynamicClient()
+	fakeClient := mockClient.client.(*fake.FakeDynamicClient)
+	var watchResourceVersions []string
+	var mu sync.Mutex
+	fakeClient.PrependWatchReactor("pods", func(action clienttesting.Action) (bool, apiWatch.Interface, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		watchResourceVersions = append(watchResourceVersions, action.(clienttesting.WatchActionImpl).WatchRestrictions.ResourceVersion)
+		return false, nil, nil
+	})
+
+	storageID := storagetest.NewStorageID("test")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.StorageID = &storageID
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default"},
+		},
+	}
+	require.NoError(t, rCfg.Validate())
+
+	ctx := context.Background()
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), rCfg, consumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(ctx, host))
+
+	time.Sleep(time.Millisecond * 100)
+	mockClient.createPods(generatePod("pod1", "default", map[string]interface{}{}, "5"))
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(ctx))
+
+	// The second receiver resumes the watch from the last seen resourceVersion, without listing the objects again.
+	fakeClient.ClearActions()
+	r, err = newReceiver(receivertest.NewNopCreateSettings(), rCfg, newMockLogConsumer())
+	require.NoError(t, err)
+	require.NoError(t, r.Start(ctx, host))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(watchResourceVersions) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(ctx))
+
+	assert.Equal(t, []string{"1", "5"}, watchResourceVersions)
+	for _, action := range fakeClient.Actions() {
+		assert.NotEqual(t, "list", action.GetVerb())
+	}
+}
+
+func TestWatchObjectResourceVersionExpired(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockDynamicClient()
+	fakeClient := mockClient.client.(*fake.FakeDynamicClient)
+	var expired atomic.Bool
+	fakeClient.PrependWatchReactor("pods", func(clienttesting.Action) (bool, apiWatch.Interface, error) {
+		if expired.Swap(true) {
+			return false, nil, nil
+		}
+		watcher := apiWatch.NewFakeWithChanSize(1, false)
+		watcher.Error(&apierrors.NewResourceExpired("too old resource version").ErrStatus)
+		return true, watcher, nil
+	})
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default"},
+		},
+	}
+	require.NoError(t, rCfg.Validate())
+
+	mockClient.createPods(generatePod("pod1", "default", map[string]interface{}{}, "1"))
+
+	ctx := context.Background()
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), rCfg, consumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+
+	// The objects are listed again to get a new resourceVersion and emitted, then the watch goes on.
+	require.Eventually(t, func() bool {
+		lists := 0
+		for _, action := range fakeClient.Actions() {
+			if action.GetVerb() == "list" {
+				lists++
+			}
+		}
+		return lists == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(time.Millisecond * 100)
+
+	mockClient.createPods(generatePod("pod2", "default", map[string]interface{}{}, "2"))
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(ctx))
+
+	body := consumer.Logs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw()
+	assert.Equal(t, "MODIFIED", body["type"])
+	assert.Equal(t, "pod1", body["object"].(map[string]interface{})["metadata"].(map[string]interface{})["name"])
+}
+
+func TestWatchObjectEmitDiffs(t *testing.T) {
+	t.Parallel()
+
+	mockClient := newMockDynamicClient()
+
+	rCfg := createDefaultConfig().(*Config)
+	rCfg.makeDynamicClient = mockClient.getMockDynamicClient
+	rCfg.makeDiscoveryClient = getMockDiscoveryClient
+	rCfg.Objects = []*K8sObjectsConfig{
+		{
+			Name:       "pods",
+			Mode:       WatchMode,
+			Namespaces: []string{"default"},
+			EmitDiffs:  true,
+		},
+	}
+	require.NoError(t, rCfg.Validate())
+
+	ctx := context.Background()
+	consumer := newMockLogConsumer()
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), rCfg, consumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(ctx, componenttest.NewNopHost()))
+	time.Sleep(time.Millisecond * 100)
+
+	pod := generatePod("pod1", "default", map[string]interface{}{"environment": "production"}, "2")
+	pod.SetUID("pod1-uid")
+	mockClient.createPods(pod)
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	updated := pod.DeepCopy()
+	updated.SetLabels(map[string]string{"environment": "test"})
+	updated.SetResourceVersion("3")
+	_, err = mockClient.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "pods"}).
+		Namespace("default").Update(ctx, updated, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return consumer.Count() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Shutdown(ctx))
+
+	logs := consumer.Logs()
+	body := logs[1].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw()
+	assert.Equal(t, map[string]interface{}{
+		"type": "MODIFIED",
+		"object": map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pods",
+			"metadata": map[string]interface{}{
+				"name":            "pod1",
+				"namespace":       "default",
+				"uid":             "pod1-uid",
+				"resourceVersion": "3",
+				"labels":          map[string]interface{}{"environment": "test"},
+			},
+		},
+	}, body)
+}
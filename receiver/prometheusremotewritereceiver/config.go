@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+var (
+	errMissingEndpoint = errors.New("missing receiver server endpoint from config")
+	errInvalidPath     = errors.New("path must start with a '/'")
+	errInvalidMaxSize  = errors.New("max_request_body_size must be positive")
+)
+
+// Config defines configuration for the Prometheus remote write receiver.
+type Config struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path of the remote write endpoint. Default is /api/v1/write.
+	Path string `mapstructure:"path"`
+
+	// TrimMetricSuffixes removes the type and unit suffixes from the received metric names,
+	// in the same way as the prometheus receiver.
+	TrimMetricSuffixes bool `mapstructure:"trim_metric_suffixes"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errMissingEndpoint
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errInvalidPath
+	}
+	if cfg.MaxRequestBodySize <= 0 {
+		return errInvalidMaxSize
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver/internal/metadata"
+)
+
+const (
+	scopeName = "otelcol/prometheusremotewritereceiver"
+
+	// writeRequestProto is the protobuf message of version 1 of the remote write protocol.
+	writeRequestProto = "prometheus.WriteRequest"
+)
+
+var (
+	errNilNextConsumer      = errors.New("nil next consumer")
+	errInvalidMethod        = errors.New("invalid method, remote write requests must be sent with POST")
+	errUnsupportedEncoding  = errors.New("unsupported content encoding, remote write requests must be snappy compressed")
+	errUnsupportedProtoType = errors.New("unsupported protobuf message, only prometheus.WriteRequest is supported")
+	errRequestTooLarge      = errors.New("request body exceeds max_request_body_size")
+)
+
+type prometheusRemoteWriteReceiver struct {
+	settings     receiver.CreateSettings
+	cfg          *Config
+	nextConsumer consumer.Metrics
+	translator   *translator
+	obsrecv      *obsreport.Receiver
+	server       *http.Server
+	shutdownWG   sync.WaitGroup
+}
+
+func newMetricsReceiver(params receiver.CreateSettings, cfg *Config, nextConsumer consumer.Metrics) (receiver.Metrics, error) {
+	if nextConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	transport := "http"
+	if cfg.TLSSetting != nil {
+		transport = "https"
+	}
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             params.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &prometheusRemoteWriteReceiver{
+		settings:     params,
+		cfg:          cfg,
+		nextConsumer: nextConsumer,
+		translator: &translator{
+			scopeName:    scopeName,
+			scopeVersion: params.BuildInfo.Version,
+			trimSuffixes: cfg.TrimMetricSuffixes,
+		},
+		obsrecv: obsrecv,
+	}, nil
+}
+
+// Start starts the HTTP server receiving remote write requests.
+func (r *prometheusRemoteWriteReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.cfg.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.cfg.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(r.cfg.Path, r.handleWrite)
+	// snappy compressed bodies are decoded by the handler, once their decoded size is checked
+	r.server, err = r.cfg.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, mux,
+		confighttp.WithDecoder("snappy", func(body io.ReadCloser) (io.ReadCloser, error) { return body, nil }))
+	if err != nil {
+		return err
+	}
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the HTTP server.
+func (r *prometheusRemoteWriteReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.shutdownWG.Wait()
+	return err
+}
+
+// handleWrite decodes a snappy compressed remote write request. Senders retry requests failing with a
+// 5xx status code, so malformed requests and permanent consumer errors are answered with a 400.
+func (r *prometheusRemoteWriteReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, errInvalidMethod.Error(), http.StatusMethodNotAllowed)
+		return
+	}
+	if encoding := req.Header.Get("Content-Encoding"); encoding != "" && encoding != "snappy" {
+		http.Error(w, errUnsupportedEncoding.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+	if _, params, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil && params["proto"] != "" && params["proto"] != writeRequestProto {
+		http.Error(w, errUnsupportedProtoType.Error(), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	writeReq, err := decodeWriteRequest(http.MaxBytesReader(w, req.Body, r.cfg.MaxRequestBodySize), r.cfg.MaxRequestBodySize)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, metadata.Type, 0, err)
+		status := http.StatusBadRequest
+		if errors.Is(err, errRequestTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	md, err := r.translator.toMetrics(writeReq)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, metadata.Type, 0, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dataPoints := md.DataPointCount()
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, metadata.Type, dataPoints, err)
+	if err != nil {
+		r.settings.Logger.Debug("failed to consume remote write request", zap.Error(err))
+		status := http.StatusInternalServerError
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeWriteRequest decodes a write request whose body, compressed or not, is at most maxSize bytes long.
+func decodeWriteRequest(body io.ReadCloser, maxSize int64) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(body)
+	_ = body.Close()
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return nil, errRequestTooLarge
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	if int64(size) > maxSize {
+		return nil, errRequestTooLarge
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	writeReq := &prompb.WriteRequest{}
+	if err = writeReq.Unmarshal(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal write request: %w", err)
+	}
+	return writeReq, nil
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func newTestReceiver(t *testing.T, next consumer.Metrics) *prometheusRemoteWriteReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	r, err := newMetricsReceiver(receivertest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	return r.(*prometheusRemoteWriteReceiver)
+}
+
+func newWriteRequest(t *testing.T, req *prompb.WriteRequest) *http.Request {
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	httpReq := httptest.NewRequest(http.MethodPost, defaultPath, bytes.NewReader(snappy.Encode(nil, data)))
+	httpReq.Header.Set("Content-Encoding", "snappy")
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	httpReq.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	return httpReq
+}
+
+func TestHandleWrite(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	r := newTestReceiver(t, sink)
+
+	w := httptest.NewRecorder()
+	r.handleWrite(w, newWriteRequest(t, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("up", []string{"job", "api"}, 1, 1),
+		},
+	}))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.AllMetrics()[0].DataPointCount())
+}
+
+func TestHandleWriteErrors(t *testing.T) {
+	valid := &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{series("up", nil, 1)}}
+
+	tests := []struct {
+		name     string
+		consumer consumer.Metrics
+		request  func(t *testing.T) *http.Request
+		status   int
+	}{
+		{
+			name: "invalid method",
+			request: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodGet, defaultPath, nil)
+			},
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "unsupported encoding",
+			request: func(t *testing.T) *http.Request {
+				req := newWriteRequest(t, valid)
+				req.Header.Set("Content-Encoding", "gzip")
+				return req
+			},
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "unsupported protobuf message",
+			request: func(t *testing.T) *http.Request {
+				req := newWriteRequest(t, valid)
+				req.Header.Set("Content-Type", "application/x-protobuf;proto=io.prometheus.write.v2.Request")
+				return req
+			},
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "not snappy compressed",
+			request: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodPost, defaultPath, bytes.NewReader([]byte("not snappy")))
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "decoded body too large",
+			request: func(t *testing.T) *http.Request {
+				// the header of a snappy block announcing 1GiB of decoded data
+				header := binary.AppendUvarint(nil, 1<<30)
+				req := httptest.NewRequest(http.MethodPost, defaultPath, bytes.NewReader(header))
+				req.Header.Set("Content-Encoding", "snappy")
+				return req
+			},
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "missing metric name",
+			request: func(t *testing.T) *http.Request {
+				return newWriteRequest(t, &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{Samples: []prompb.Sample{{Value: 1}}}}})
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:     "retryable consumer error",
+			consumer: consumertest.NewErr(errors.New("queue is full")),
+			request: func(t *testing.T) *http.Request {
+				return newWriteRequest(t, valid)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:     "permanent consumer error",
+			consumer: consumertest.NewErr(consumererror.NewPermanent(errors.New("invalid data"))),
+			request: func(t *testing.T) *http.Request {
+				return newWriteRequest(t, valid)
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := tt.consumer
+			if next == nil {
+				next = consumertest.NewNop()
+			}
+			r := newTestReceiver(t, next)
+
+			w := httptest.NewRecorder()
+			r.handleWrite(w, tt.request(t))
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestHandleWriteMaxRequestBodySize(t *testing.T) {
+	large := &prompb.WriteRequest{}
+	for i := 0; i < 100; i++ {
+		large.Timeseries = append(large.Timeseries, series("up", []string{"job", "api"}, 1))
+	}
+	data, err := large.Marshal()
+	require.NoError(t, err)
+	compressed := snappy.Encode(nil, data)
+	require.Less(t, len(compressed), len(data)/2)
+
+	for _, maxSize := range []int{len(compressed) - 1, len(data) - 1} {
+		r := newTestReceiver(t, consumertest.NewNop())
+		r.cfg.MaxRequestBodySize = int64(maxSize)
+		w := httptest.NewRecorder()
+		r.handleWrite(w, newWriteRequest(t, large))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	}
+
+	r := newTestReceiver(t, consumertest.NewNop())
+	r.cfg.MaxRequestBodySize = int64(len(data))
+	w := httptest.NewRecorder()
+	r.handleWrite(w, newWriteRequest(t, large))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestServeWrite(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	sink := new(consumertest.MetricsSink)
+	r := newTestReceiver(t, sink)
+	r.cfg.Endpoint = endpoint
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	// snappy compressed bodies reach the handler as sent
+	req := newWriteRequest(t, &prompb.WriteRequest{Timeseries: []prompb.TimeSeries{series("up", nil, 1)}})
+	httpReq, err := http.NewRequest(http.MethodPost, "http://"+endpoint+defaultPath, req.Body)
+	require.NoError(t, err)
+	httpReq.Header = req.Header
+	resp, err := http.DefaultClient.Do(httpReq)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, 1, sink.DataPointCount())
+}
+
+func TestStartShutdown(t *testing.T) {
+	r := newTestReceiver(t, consumertest.NewNop())
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(context.Background()))
+
+	// shutting down a receiver that was never started is a no-op
+	assert.NoError(t, newTestReceiver(t, consumertest.NewNop()).Shutdown(context.Background()))
+}
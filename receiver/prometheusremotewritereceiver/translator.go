@@ -0,0 +1,568 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const (
+	targetInfoMetricName = "target_info"
+	traceIDLabel         = "trace_id"
+	spanIDLabel          = "span_id"
+
+	suffixBucket  = "_bucket"
+	suffixSum     = "_sum"
+	suffixCount   = "_count"
+	suffixTotal   = "_total"
+	suffixCreated = "_created"
+)
+
+var errMissingMetricName = errors.New("time series is missing the __name__ label")
+
+// translator converts remote write requests to metrics.
+type translator struct {
+	scopeName    string
+	scopeVersion string
+	trimSuffixes bool
+}
+
+// toMetrics converts a remote write request to metrics. Series are grouped in a resource per job and instance,
+// and the samples of classic histograms and summaries are merged into a single data point per timestamp.
+func (t *translator) toMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	b := &metricsBuilder{
+		translator: t,
+		metadata:   make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		metrics:    pmetric.NewMetrics(),
+		resources:  map[resourceKey]*resourceBuilder{},
+	}
+	for _, md := range req.Metadata {
+		b.metadata[md.MetricFamilyName] = md
+	}
+	for i := range req.Timeseries {
+		if err := b.addSeries(&req.Timeseries[i]); err != nil {
+			return pmetric.Metrics{}, err
+		}
+	}
+	for _, rb := range b.resources {
+		rb.flush()
+	}
+	return b.metrics, nil
+}
+
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+type metricsBuilder struct {
+	*translator
+	metadata  map[string]prompb.MetricMetadata
+	metrics   pmetric.Metrics
+	resources map[resourceKey]*resourceBuilder
+}
+
+func (b *metricsBuilder) addSeries(ts *prompb.TimeSeries) error {
+	var name string
+	var key resourceKey
+	attrs := make([]prompb.Label, 0, len(ts.Labels))
+	for _, l := range ts.Labels {
+		switch l.Name {
+		case model.MetricNameLabel:
+			name = l.Value
+		case model.JobLabel:
+			key.job = l.Value
+		case model.InstanceLabel:
+			key.instance = l.Value
+		default:
+			attrs = append(attrs, l)
+		}
+	}
+	if name == "" {
+		return errMissingMetricName
+	}
+
+	rb := b.resource(key)
+	if name == targetInfoMetricName {
+		// target_info carries the attributes of the resource, as written by the prometheus remote write exporter.
+		for _, l := range attrs {
+			rb.resource.Resource().Attributes().PutStr(l.Name, l.Value)
+		}
+		return nil
+	}
+
+	if len(ts.Histograms) > 0 {
+		if err := rb.addNativeHistograms(b.metricFor(rb, name, pmetric.MetricTypeExponentialHistogram, b.metadata[name]), attrs, ts.Histograms); err != nil {
+			return fmt.Errorf("invalid native histogram %q: %w", name, err)
+		}
+	}
+	if len(ts.Samples) == 0 {
+		return nil
+	}
+
+	family, suffix, md := b.familyOf(name)
+	switch md.Type {
+	case prompb.MetricMetadata_COUNTER:
+		if suffix == suffixCreated {
+			return nil
+		}
+		rb.addNumberPoints(b.metricFor(rb, family, pmetric.MetricTypeSum, md), attrs, ts.Samples, ts.Exemplars)
+	case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_GAUGEHISTOGRAM:
+		if suffix == "" {
+			// classic histograms only have _bucket, _sum and _count series
+			rb.addNumberPoints(b.metricFor(rb, name, pmetric.MetricTypeGauge, prompb.MetricMetadata{}), attrs, ts.Samples, ts.Exemplars)
+			return nil
+		}
+		if suffix == suffixCreated {
+			return nil
+		}
+		rb.addHistogramSamples(b.metricFor(rb, family, pmetric.MetricTypeHistogram, md), suffix, attrs, ts.Samples, ts.Exemplars)
+	case prompb.MetricMetadata_SUMMARY:
+		if suffix == suffixCreated {
+			return nil
+		}
+		rb.addSummarySamples(b.metricFor(rb, family, pmetric.MetricTypeSummary, md), suffix, attrs, ts.Samples)
+	default:
+		rb.addNumberPoints(b.metricFor(rb, family, pmetric.MetricTypeGauge, md), attrs, ts.Samples, ts.Exemplars)
+	}
+	return nil
+}
+
+// familyOf returns the metric family of a series, the suffix of the series name within the family,
+// and the metadata of the family. Series without metadata are their own family of unknown type.
+func (b *metricsBuilder) familyOf(name string) (string, string, prompb.MetricMetadata) {
+	if md, ok := b.metadata[name]; ok {
+		return name, "", md
+	}
+	for _, suffix := range []string{suffixBucket, suffixSum, suffixCount, suffixTotal, suffixCreated} {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		familyName := strings.TrimSuffix(name, suffix)
+		md, ok := b.metadata[familyName]
+		if !ok {
+			continue
+		}
+		switch {
+		case md.Type == prompb.MetricMetadata_COUNTER && (suffix == suffixTotal || suffix == suffixCreated):
+			// counters keep their _total suffix unless trimmed, like in the prometheus receiver
+			return familyName + suffixTotal, suffix, md
+		case (md.Type == prompb.MetricMetadata_HISTOGRAM || md.Type == prompb.MetricMetadata_GAUGEHISTOGRAM) && suffix != suffixTotal,
+			md.Type == prompb.MetricMetadata_SUMMARY && suffix != suffixTotal && suffix != suffixBucket:
+			return familyName, suffix, md
+		}
+	}
+	return name, "", prompb.MetricMetadata{MetricFamilyName: name, Type: prompb.MetricMetadata_UNKNOWN}
+}
+
+func (b *metricsBuilder) resource(key resourceKey) *resourceBuilder {
+	if rb, ok := b.resources[key]; ok {
+		return rb
+	}
+	rm := b.metrics.ResourceMetrics().AppendEmpty()
+	attrs := rm.Resource().Attributes()
+	if key.job != "" {
+		attrs.PutStr(conventions.AttributeServiceName, key.job)
+	}
+	if key.instance != "" {
+		attrs.PutStr(conventions.AttributeServiceInstanceID, key.instance)
+		host, port, err := net.SplitHostPort(key.instance)
+		if err != nil {
+			host = key.instance
+		}
+		if isDiscernibleHost(host) {
+			attrs.PutStr(conventions.AttributeNetHostName, host)
+		}
+		if port != "" {
+			attrs.PutStr(conventions.AttributeNetHostPort, port)
+		}
+	}
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(b.scopeName)
+	sm.Scope().SetVersion(b.scopeVersion)
+
+	rb := &resourceBuilder{
+		resource: rm,
+		metrics:  sm.Metrics(),
+		byName:   map[string]pmetric.Metric{},
+		groups:   map[string]*pointGroup{},
+	}
+	b.resources[key] = rb
+	return rb
+}
+
+// metricFor returns the metric of a family within a resource, creating it on first use.
+func (b *metricsBuilder) metricFor(rb *resourceBuilder, family string, metricType pmetric.MetricType, md prompb.MetricMetadata) pmetric.Metric {
+	key := metricType.String() + "/" + family
+	if metric, ok := rb.byName[key]; ok {
+		return metric
+	}
+	metric := rb.metrics.AppendEmpty()
+	name := family
+	if b.trimSuffixes {
+		name = prometheustranslator.TrimPromSuffixes(family, metricType, md.Unit)
+	}
+	metric.SetName(name)
+	metric.SetDescription(md.Help)
+	metric.SetUnit(md.Unit)
+	switch metricType {
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeHistogram:
+		metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeSummary:
+		metric.SetEmptySummary()
+	default:
+		metric.SetEmptyGauge()
+	}
+	rb.byName[key] = metric
+	return metric
+}
+
+type resourceBuilder struct {
+	resource pmetric.ResourceMetrics
+	metrics  pmetric.MetricSlice
+	byName   map[string]pmetric.Metric
+	// groups merges the series of classic histograms and summaries into data points
+	groups     map[string]*pointGroup
+	groupOrder []*pointGroup
+}
+
+// pointGroup holds the samples of the series making up a single histogram or summary data point.
+type pointGroup struct {
+	metric    pmetric.Metric
+	attrs     []prompb.Label
+	timestamp int64
+	buckets   map[float64]float64
+	sum       float64
+	count     float64
+	hasCount  bool
+	stale     bool
+	exemplars []prompb.Exemplar
+}
+
+func (rb *resourceBuilder) addNumberPoints(metric pmetric.Metric, attrs []prompb.Label, samples []prompb.Sample, exemplars []prompb.Exemplar) {
+	var points pmetric.NumberDataPointSlice
+	if metric.Type() == pmetric.MetricTypeSum {
+		points = metric.Sum().DataPoints()
+	} else {
+		points = metric.Gauge().DataPoints()
+	}
+	for i, sample := range samples {
+		dp := points.AppendEmpty()
+		putAttributes(dp.Attributes(), attrs)
+		dp.SetTimestamp(toTimestamp(sample.Timestamp))
+		if value.IsStaleNaN(sample.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		} else {
+			dp.SetDoubleValue(sample.Value)
+		}
+		if i == len(samples)-1 {
+			appendExemplars(dp.Exemplars(), exemplars)
+		}
+	}
+}
+
+func (rb *resourceBuilder) addHistogramSamples(metric pmetric.Metric, suffix string, attrs []prompb.Label, samples []prompb.Sample, exemplars []prompb.Exemplar) {
+	bound := math.Inf(1)
+	if suffix == suffixBucket {
+		var le string
+		attrs, le = removeLabel(attrs, model.BucketLabel)
+		bound, _ = strconv.ParseFloat(le, 64)
+	}
+	var group *pointGroup
+	for _, sample := range samples {
+		group = rb.group(metric, attrs, sample)
+		switch suffix {
+		case suffixBucket:
+			group.buckets[bound] = sample.Value
+		case suffixSum:
+			group.sum = sample.Value
+		case suffixCount:
+			group.count = sample.Value
+			group.hasCount = true
+		}
+	}
+	group.exemplars = append(group.exemplars, exemplars...)
+}
+
+func (rb *resourceBuilder) addSummarySamples(metric pmetric.Metric, suffix string, attrs []prompb.Label, samples []prompb.Sample) {
+	var quantile float64
+	if suffix == "" {
+		var q string
+		attrs, q = removeLabel(attrs, model.QuantileLabel)
+		quantile, _ = strconv.ParseFloat(q, 64)
+	}
+	for _, sample := range samples {
+		group := rb.group(metric, attrs, sample)
+		switch suffix {
+		case "":
+			group.buckets[quantile] = sample.Value
+		case suffixSum:
+			group.sum = sample.Value
+		case suffixCount:
+			group.count = sample.Value
+			group.hasCount = true
+		}
+	}
+}
+
+// group returns the data point of metric with the given attributes at the time of the sample.
+func (rb *resourceBuilder) group(metric pmetric.Metric, attrs []prompb.Label, sample prompb.Sample) *pointGroup {
+	var sb strings.Builder
+	sb.WriteString(metric.Name())
+	sb.WriteByte(0)
+	sb.WriteString(strconv.FormatInt(sample.Timestamp, 10))
+	for _, l := range attrs {
+		sb.WriteByte(0)
+		sb.WriteString(l.Name)
+		sb.WriteByte(0)
+		sb.WriteString(l.Value)
+	}
+	key := sb.String()
+	group, ok := rb.groups[key]
+	if !ok {
+		group = &pointGroup{metric: metric, attrs: attrs, timestamp: sample.Timestamp, buckets: map[float64]float64{}}
+		rb.groups[key] = group
+		rb.groupOrder = append(rb.groupOrder, group)
+	}
+	group.stale = group.stale || value.IsStaleNaN(sample.Value)
+	return group
+}
+
+// flush appends the data points of the classic histograms and summaries to their metric.
+func (rb *resourceBuilder) flush() {
+	for _, group := range rb.groupOrder {
+		bounds := make([]float64, 0, len(group.buckets))
+		for bound := range group.buckets {
+			bounds = append(bounds, bound)
+		}
+		sort.Float64s(bounds)
+
+		if group.metric.Type() == pmetric.MetricTypeSummary {
+			dp := group.metric.Summary().DataPoints().AppendEmpty()
+			putAttributes(dp.Attributes(), group.attrs)
+			dp.SetTimestamp(toTimestamp(group.timestamp))
+			if group.stale {
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				continue
+			}
+			dp.SetSum(group.sum)
+			dp.SetCount(uint64(group.count))
+			for _, quantile := range bounds {
+				qv := dp.QuantileValues().AppendEmpty()
+				qv.SetQuantile(quantile)
+				qv.SetValue(group.buckets[quantile])
+			}
+			continue
+		}
+
+		dp := group.metric.Histogram().DataPoints().AppendEmpty()
+		putAttributes(dp.Attributes(), group.attrs)
+		dp.SetTimestamp(toTimestamp(group.timestamp))
+		if group.stale {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+		dp.SetSum(group.sum)
+		count := group.count
+		if !group.hasCount && len(bounds) > 0 {
+			count = group.buckets[bounds[len(bounds)-1]]
+		}
+		dp.SetCount(uint64(count))
+
+		// bucket counts are cumulative in prometheus, and the +Inf bucket is implicit in OTLP
+		var previous float64
+		for _, bound := range bounds {
+			if math.IsInf(bound, 1) {
+				break
+			}
+			dp.ExplicitBounds().Append(bound)
+			dp.BucketCounts().Append(uint64(math.Max(0, group.buckets[bound]-previous)))
+			previous = group.buckets[bound]
+		}
+		if len(bounds) > 0 {
+			dp.BucketCounts().Append(uint64(math.Max(0, count-previous)))
+		}
+		appendExemplars(dp.Exemplars(), group.exemplars)
+	}
+}
+
+func (rb *resourceBuilder) addNativeHistograms(metric pmetric.Metric, attrs []prompb.Label, histograms []prompb.Histogram) error {
+	for _, h := range histograms {
+		dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+		putAttributes(dp.Attributes(), attrs)
+		if err := convertNativeHistogram(h, dp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertNativeHistogram converts a prometheus native histogram to an exponential histogram data point.
+// Both use the same base for a given schema and scale, but prometheus buckets are upper inclusive
+// while OTLP buckets are lower inclusive, so bucket indexes are shifted by one.
+func convertNativeHistogram(h prompb.Histogram, dp pmetric.ExponentialHistogramDataPoint) error {
+	if h.Schema < -4 || h.Schema > 8 {
+		return fmt.Errorf("unsupported schema %d", h.Schema)
+	}
+	dp.SetTimestamp(toTimestamp(h.Timestamp))
+	dp.SetScale(h.Schema)
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return nil
+	}
+	dp.SetSum(h.Sum)
+	if h.IsFloatHistogram() {
+		dp.SetCount(uint64(h.GetCountFloat()))
+		dp.SetZeroCount(uint64(h.GetZeroCountFloat()))
+	} else {
+		dp.SetCount(h.GetCountInt())
+		dp.SetZeroCount(h.GetZeroCountInt())
+	}
+
+	offset, counts, err := expandBuckets(h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts)
+	if err != nil {
+		return fmt.Errorf("invalid positive buckets: %w", err)
+	}
+	dp.Positive().SetOffset(offset - 1)
+	dp.Positive().BucketCounts().FromRaw(counts)
+
+	offset, counts, err = expandBuckets(h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts)
+	if err != nil {
+		return fmt.Errorf("invalid negative buckets: %w", err)
+	}
+	dp.Negative().SetOffset(offset - 1)
+	dp.Negative().BucketCounts().FromRaw(counts)
+	return nil
+}
+
+// maxHistogramBuckets bounds the number of dense buckets of each side of a native histogram, since the gaps
+// between the spans of buckets are expanded with empty buckets.
+const maxHistogramBuckets = 1 << 14
+
+// expandBuckets turns the sparse buckets of a native histogram into the offset of the first bucket,
+// and the dense counts of all buckets from there. Integer histograms encode each count as the delta
+// to the previous bucket, float histograms hold absolute counts.
+func expandBuckets(spans []prompb.BucketSpan, deltas []int64, absolute []float64) (int32, []uint64, error) {
+	if len(spans) == 0 {
+		return 0, nil, nil
+	}
+	var counts []uint64
+	var current int64
+	i := 0
+	for j, span := range spans {
+		// the offset of the first span is the index of the first bucket, the others are gaps from the previous span
+		gap := int32(0)
+		if j > 0 {
+			if span.Offset < 0 {
+				return 0, nil, fmt.Errorf("span %d has a negative offset", j)
+			}
+			gap = span.Offset
+		}
+		if int64(len(counts))+int64(gap)+int64(span.Length) > maxHistogramBuckets {
+			return 0, nil, fmt.Errorf("spans have more than %d buckets", maxHistogramBuckets)
+		}
+		for k := int32(0); k < gap; k++ {
+			counts = append(counts, 0)
+		}
+		for k := uint32(0); k < span.Length; k++ {
+			if len(absolute) > 0 {
+				if i >= len(absolute) {
+					return 0, nil, errors.New("spans have more buckets than counts")
+				}
+				counts = append(counts, uint64(absolute[i]))
+			} else {
+				if i >= len(deltas) {
+					return 0, nil, errors.New("spans have more buckets than counts")
+				}
+				current += deltas[i]
+				if current < 0 {
+					return 0, nil, errors.New("bucket count is negative")
+				}
+				counts = append(counts, uint64(current))
+			}
+			i++
+		}
+	}
+	return spans[0].Offset, counts, nil
+}
+
+func appendExemplars(dest pmetric.ExemplarSlice, exemplars []prompb.Exemplar) {
+	for _, e := range exemplars {
+		exemplar := dest.AppendEmpty()
+		exemplar.SetTimestamp(toTimestamp(e.Timestamp))
+		exemplar.SetDoubleValue(e.Value)
+		for _, l := range e.Labels {
+			switch l.Name {
+			case traceIDLabel:
+				var traceID pcommon.TraceID
+				if n, err := hex.Decode(traceID[:], []byte(l.Value)); err == nil && n == len(traceID) {
+					exemplar.SetTraceID(traceID)
+					continue
+				}
+			case spanIDLabel:
+				var spanID pcommon.SpanID
+				if n, err := hex.Decode(spanID[:], []byte(l.Value)); err == nil && n == len(spanID) {
+					exemplar.SetSpanID(spanID)
+					continue
+				}
+			}
+			exemplar.FilteredAttributes().PutStr(l.Name, l.Value)
+		}
+	}
+}
+
+func putAttributes(dest pcommon.Map, labels []prompb.Label) {
+	dest.EnsureCapacity(len(labels))
+	for _, l := range labels {
+		dest.PutStr(l.Name, l.Value)
+	}
+}
+
+// removeLabel returns the labels without the named label, and the value of the removed label.
+func removeLabel(labels []prompb.Label, name string) ([]prompb.Label, string) {
+	for i, l := range labels {
+		if l.Name == name {
+			rest := make([]prompb.Label, 0, len(labels)-1)
+			rest = append(rest, labels[:i]...)
+			return append(rest, labels[i+1:]...), l.Value
+		}
+	}
+	return labels, ""
+}
+
+func toTimestamp(ms int64) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(time.UnixMilli(ms))
+}
+
+// isDiscernibleHost checks if a host can be used as a value for the 'net.host.name' key.
+// localhost-like hosts and unspecified (0.0.0.0) hosts are not discernible.
+func isDiscernibleHost(host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		return !ip.IsLoopback() && !ip.IsUnspecified()
+	}
+	return host != "localhost"
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const testTimestamp = int64(1692000000000)
+
+func series(name string, labels []string, samples ...float64) prompb.TimeSeries {
+	ts := prompb.TimeSeries{Labels: []prompb.Label{{Name: "__name__", Value: name}}}
+	for i := 0; i < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	for i, v := range samples {
+		ts.Samples = append(ts.Samples, prompb.Sample{Value: v, Timestamp: testTimestamp + int64(i)*1000})
+	}
+	return ts
+}
+
+func translate(t *testing.T, trimSuffixes bool, req *prompb.WriteRequest) pmetric.Metrics {
+	tr := &translator{scopeName: scopeName, scopeVersion: "latest", trimSuffixes: trimSuffixes}
+	md, err := tr.toMetrics(req)
+	require.NoError(t, err)
+	return md
+}
+
+func metricsByName(rm pmetric.ResourceMetrics) map[string]pmetric.Metric {
+	metrics := map[string]pmetric.Metric{}
+	for i := 0; i < rm.ScopeMetrics().At(0).Metrics().Len(); i++ {
+		metric := rm.ScopeMetrics().At(0).Metrics().At(i)
+		metrics[metric.Name()] = metric
+	}
+	return metrics
+}
+
+func TestTranslateResources(t *testing.T) {
+	md := translate(t, false, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("up", []string{"job", "api", "instance", "10.0.0.1:8080"}, 1),
+			series("up", []string{"job", "api", "instance", "localhost:8080"}, 0),
+			series("target_info", []string{"job", "api", "instance", "10.0.0.1:8080", "k8s_pod_name", "api-0"}, 1),
+			series("up", nil, 1),
+		},
+	})
+
+	require.Equal(t, 3, md.ResourceMetrics().Len())
+
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]any{
+		"service.name":        "api",
+		"service.instance.id": "10.0.0.1:8080",
+		"net.host.name":       "10.0.0.1",
+		"net.host.port":       "8080",
+		"k8s_pod_name":        "api-0",
+	}, rm.Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, rm.ScopeMetrics().At(0).Scope().Name())
+	assert.Equal(t, "latest", rm.ScopeMetrics().At(0).Scope().Version())
+	require.Equal(t, 1, rm.ScopeMetrics().At(0).Metrics().Len(), "target_info must not be converted to a metric")
+
+	assert.Equal(t, map[string]any{
+		"service.name":        "api",
+		"service.instance.id": "localhost:8080",
+		"net.host.port":       "8080",
+	}, md.ResourceMetrics().At(1).Resource().Attributes().AsRaw())
+	assert.Equal(t, 0, md.ResourceMetrics().At(2).Resource().Attributes().Len())
+}
+
+func TestTranslateCountersAndGauges(t *testing.T) {
+	md := translate(t, false, &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "http_requests", Type: prompb.MetricMetadata_COUNTER, Help: "Requests", Unit: "1"},
+			{MetricFamilyName: "memory_bytes", Type: prompb.MetricMetadata_GAUGE, Help: "Memory", Unit: "bytes"},
+		},
+		Timeseries: []prompb.TimeSeries{
+			series("http_requests_total", []string{"code", "200"}, 10, 12),
+			series("http_requests_created", []string{"code", "200"}, 1692000000),
+			series("memory_bytes", nil, 1024, math.Float64frombits(value.StaleNaN)),
+			series("no_metadata", nil, 3),
+		},
+	})
+
+	metrics := metricsByName(md.ResourceMetrics().At(0))
+	require.Len(t, metrics, 3)
+
+	counter := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, counter.Type())
+	assert.True(t, counter.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, counter.Sum().AggregationTemporality())
+	assert.Equal(t, "Requests", counter.Description())
+	require.Equal(t, 2, counter.Sum().DataPoints().Len())
+	dp := counter.Sum().DataPoints().At(1)
+	assert.Equal(t, 12.0, dp.DoubleValue())
+	assert.Equal(t, pcommon.Timestamp((testTimestamp+1000)*1e6), dp.Timestamp())
+	assert.Equal(t, map[string]any{"code": "200"}, dp.Attributes().AsRaw())
+
+	gauge := metrics["memory_bytes"]
+	require.Equal(t, pmetric.MetricTypeGauge, gauge.Type())
+	assert.Equal(t, "bytes", gauge.Unit())
+	require.Equal(t, 2, gauge.Gauge().DataPoints().Len())
+	assert.False(t, gauge.Gauge().DataPoints().At(0).Flags().NoRecordedValue())
+	assert.True(t, gauge.Gauge().DataPoints().At(1).Flags().NoRecordedValue(), "stale markers must be flagged")
+
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics["no_metadata"].Type())
+}
+
+func TestTranslateTrimSuffixes(t *testing.T) {
+	md := translate(t, true, &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "http_request_duration_seconds", Type: prompb.MetricMetadata_COUNTER, Unit: "seconds"},
+		},
+		Timeseries: []prompb.TimeSeries{
+			series("http_request_duration_seconds_total", nil, 10),
+		},
+	})
+
+	metrics := metricsByName(md.ResourceMetrics().At(0))
+	assert.Contains(t, metrics, "http_request_duration")
+}
+
+func TestTranslateClassicHistogram(t *testing.T) {
+	exemplar := series("request_duration_seconds_bucket", []string{"le", "0.5"}, 4)
+	exemplar.Exemplars = []prompb.Exemplar{{
+		Labels:    []prompb.Label{{Name: "trace_id", Value: "0102030405060708090a0b0c0d0e0f10"}, {Name: "span_id", Value: "0102030405060708"}, {Name: "user", Value: "alice"}},
+		Value:     0.3,
+		Timestamp: testTimestamp,
+	}}
+
+	md := translate(t, false, &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "request_duration_seconds", Type: prompb.MetricMetadata_HISTOGRAM},
+		},
+		Timeseries: []prompb.TimeSeries{
+			series("request_duration_seconds_bucket", []string{"le", "0.1"}, 1),
+			exemplar,
+			series("request_duration_seconds_bucket", []string{"le", "+Inf"}, 6),
+			series("request_duration_seconds_sum", nil, 2.5),
+			series("request_duration_seconds_count", nil, 6),
+		},
+	})
+
+	metrics := metricsByName(md.ResourceMetrics().At(0))
+	require.Len(t, metrics, 1)
+	histogram := metrics["request_duration_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, histogram.Type())
+	require.Equal(t, 1, histogram.Histogram().DataPoints().Len())
+
+	dp := histogram.Histogram().DataPoints().At(0)
+	assert.Equal(t, 0, dp.Attributes().Len())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, 2.5, dp.Sum())
+	assert.Equal(t, []float64{0.1, 0.5}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 3, 2}, dp.BucketCounts().AsRaw())
+
+	require.Equal(t, 1, dp.Exemplars().Len())
+	ex := dp.Exemplars().At(0)
+	assert.Equal(t, 0.3, ex.DoubleValue())
+	assert.Equal(t, pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, ex.TraceID())
+	assert.Equal(t, pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8}, ex.SpanID())
+	assert.Equal(t, map[string]any{"user": "alice"}, ex.FilteredAttributes().AsRaw())
+}
+
+func TestTranslateSummary(t *testing.T) {
+	md := translate(t, false, &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "rpc_latency", Type: prompb.MetricMetadata_SUMMARY},
+		},
+		Timeseries: []prompb.TimeSeries{
+			series("rpc_latency", []string{"quantile", "0.99", "method", "get"}, 0.9),
+			series("rpc_latency", []string{"quantile", "0.5", "method", "get"}, 0.2),
+			series("rpc_latency_sum", []string{"method", "get"}, 30),
+			series("rpc_latency_count", []string{"method", "get"}, 100),
+		},
+	})
+
+	metrics := metricsByName(md.ResourceMetrics().At(0))
+	summary := metrics["rpc_latency"]
+	require.Equal(t, pmetric.MetricTypeSummary, summary.Type())
+	require.Equal(t, 1, summary.Summary().DataPoints().Len())
+
+	dp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, map[string]any{"method": "get"}, dp.Attributes().AsRaw())
+	assert.Equal(t, uint64(100), dp.Count())
+	assert.Equal(t, 30.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+}
+
+func TestTranslateNativeHistogram(t *testing.T) {
+	md := translate(t, false, &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{MetricFamilyName: "response_size", Type: prompb.MetricMetadata_HISTOGRAM, Unit: "bytes"},
+		},
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{{Name: "__name__", Value: "response_size"}, {Name: "job", Value: "api"}},
+				Histograms: []prompb.Histogram{
+					{
+						Count:          &prompb.Histogram_CountInt{CountInt: 12},
+						ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 2},
+						Sum:            100,
+						Schema:         1,
+						PositiveSpans:  []prompb.BucketSpan{{Offset: 2, Length: 2}, {Offset: 1, Length: 1}},
+						PositiveDeltas: []int64{3, -1, 2},
+						NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 1}},
+						NegativeDeltas: []int64{1},
+						Timestamp:      testTimestamp,
+					},
+					{
+						Count:          &prompb.Histogram_CountFloat{CountFloat: 4},
+						ZeroCount:      &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: 1},
+						Sum:            20,
+						Schema:         0,
+						PositiveSpans:  []prompb.BucketSpan{{Offset: -1, Length: 2}},
+						PositiveCounts: []float64{1, 2},
+						Timestamp:      testTimestamp + 1000,
+					},
+				},
+			},
+		},
+	})
+
+	metrics := metricsByName(md.ResourceMetrics().At(0))
+	histogram := metrics["response_size"]
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, histogram.Type())
+	assert.Equal(t, "bytes", histogram.Unit())
+	require.Equal(t, 2, histogram.ExponentialHistogram().DataPoints().Len())
+
+	dp := histogram.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, int32(1), dp.Scale())
+	assert.Equal(t, uint64(12), dp.Count())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	assert.Equal(t, 100.0, dp.Sum())
+	assert.Equal(t, int32(1), dp.Positive().Offset())
+	assert.Equal(t, []uint64{3, 2, 0, 4}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, int32(-1), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts().AsRaw())
+
+	dp = histogram.ExponentialHistogram().DataPoints().At(1)
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, uint64(4), dp.Count())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, int32(-2), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2}, dp.Positive().BucketCounts().AsRaw())
+}
+
+func TestTranslateErrors(t *testing.T) {
+	tr := &translator{}
+
+	_, err := tr.toMetrics(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{series("", []string{"job", "api"}, 1)}})
+	assert.ErrorIs(t, err, errMissingMetricName)
+
+	_, err = tr.toMetrics(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{
+		Labels:     []prompb.Label{{Name: "__name__", Value: "native"}},
+		Histograms: []prompb.Histogram{{Schema: 9}},
+	}}})
+	assert.ErrorContains(t, err, "unsupported schema 9")
+
+	_, err = tr.toMetrics(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{
+		Labels:     []prompb.Label{{Name: "__name__", Value: "native"}},
+		Histograms: []prompb.Histogram{{PositiveSpans: []prompb.BucketSpan{{Length: 2}}, PositiveDeltas: []int64{1}}},
+	}}})
+	assert.ErrorContains(t, err, "spans have more buckets than counts")
+
+	// gaps between spans are expanded with empty buckets, so they are bounded
+	_, err = tr.toMetrics(&prompb.WriteRequest{Timeseries: []prompb.TimeSeries{{
+		Labels: []prompb.Label{{Name: "__name__", Value: "native"}},
+		Histograms: []prompb.Histogram{{
+			PositiveSpans:  []prompb.BucketSpan{{Offset: -5, Length: 1}, {Offset: 1 << 30, Length: 1}},
+			PositiveDeltas: []int64{1, 0},
+		}},
+	}}})
+	assert.ErrorContains(t, err, "spans have more than 16384 buckets")
+}
@@ -37,6 +37,9 @@ func (c Config) Validate() error {
 		if err := query.Validate(); err != nil {
 			return err
 		}
+		if query.CollectionInterval != 0 && query.CollectionInterval < c.CollectionInterval {
+			return fmt.Errorf("'query.collection_interval' (%s) cannot be shorter than 'collection_interval' (%s)", query.CollectionInterval, c.CollectionInterval)
+		}
 	}
 	return nil
 }
@@ -47,6 +50,12 @@ type Query struct {
 	Logs               []LogsCfg   `mapstructure:"logs"`
 	TrackingColumn     string      `mapstructure:"tracking_column"`
 	TrackingStartValue string      `mapstructure:"tracking_start_value"`
+	// CollectionInterval overrides the receiver's collection_interval for this query.
+	// Runs are skipped until the interval has elapsed since the last run.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// SlowQueryThreshold enables emitting an event log record whenever the query
+	// takes at least this long to execute.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
 }
 
 func (q Query) Validate() error {
@@ -57,6 +66,18 @@ func (q Query) Validate() error {
 	if len(q.Logs) == 0 && len(q.Metrics) == 0 {
 		errs = multierr.Append(errs, errors.New("at least one of 'query.logs' and 'query.metrics' must not be empty"))
 	}
+	if q.TrackingStartValue != "" && q.TrackingColumn == "" {
+		errs = multierr.Append(errs, errors.New("'query.tracking_start_value' requires 'query.tracking_column' to be set"))
+	}
+	if q.CollectionInterval < 0 {
+		errs = multierr.Append(errs, errors.New("'query.collection_interval' cannot be negative"))
+	}
+	if q.SlowQueryThreshold < 0 {
+		errs = multierr.Append(errs, errors.New("'query.slow_query_threshold' cannot be negative"))
+	}
+	if q.SlowQueryThreshold > 0 && len(q.Logs) == 0 {
+		errs = multierr.Append(errs, errors.New("'query.slow_query_threshold' requires at least one 'query.logs' section"))
+	}
 	for _, logs := range q.Logs {
 		if err := logs.Validate(); err != nil {
 			errs = multierr.Append(errs, err)
@@ -120,6 +120,8 @@ func TestLoadConfig(t *testing.T) {
 						SQL:                "select * from test_logs where log_id > ?",
 						TrackingColumn:     "log_id",
 						TrackingStartValue: "10",
+						CollectionInterval: time.Minute,
+						SlowQueryThreshold: 5 * time.Second,
 						Logs: []LogsCfg{
 							{
 								BodyColumn: "log_body",
@@ -139,6 +141,16 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, ""),
 			errorMessage: "aggregation=cumulative but data_type=gauge does not support aggregation",
 		},
+		{
+			fname:        "config-invalid-query-collection-interval.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'query.collection_interval' (5s) cannot be shorter than 'collection_interval' (10s)",
+		},
+		{
+			fname:        "config-invalid-slow-query-threshold.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'query.slow_query_threshold' requires at least one 'query.logs' section",
+		},
 	}
 
 	for _, tt := range tests {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -150,9 +151,13 @@ type fakeDBClient struct {
 	requestCounter int
 	stringMaps     [][]stringMap
 	err            error
+	args           [][]any
+	delay          time.Duration
 }
 
-func (c *fakeDBClient) queryRows(context.Context, ...any) ([]stringMap, error) {
+func (c *fakeDBClient) queryRows(_ context.Context, args ...any) ([]stringMap, error) {
+	c.args = append(c.args, args)
+	time.Sleep(c.delay)
 	if c.err != nil {
 		return nil, c.err
 	}
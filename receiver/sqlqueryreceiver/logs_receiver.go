@@ -19,7 +19,6 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
-	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
 )
 
@@ -81,7 +80,7 @@ func (receiver *logsReceiver) Start(ctx context.Context, host component.Host) er
 	receiver.isStarted = true
 
 	var err error
-	receiver.storageClient, err = adapter.GetStorageClient(ctx, host, receiver.config.StorageID, receiver.settings.ID)
+	receiver.storageClient, err = getStorageClient(ctx, host, receiver.config.StorageID, receiver.settings.ID, "")
 	if err != nil {
 		return fmt.Errorf("error connecting to storage: %w", err)
 	}
@@ -116,6 +115,7 @@ func (receiver *logsReceiver) createQueryReceivers() error {
 			receiver.createClient,
 			receiver.settings.Logger,
 			receiver.storageClient,
+			receiver.config.CollectionInterval,
 		)
 		receiver.queryReceivers = append(receiver.queryReceivers, queryReceiver)
 	}
@@ -205,10 +205,10 @@ type logsQueryReceiver struct {
 
 	db            *sql.DB
 	client        dbClient
-	trackingValue string
+	trackingValue *trackingValue
+	schedule      querySchedule
 	// TODO: Extract persistence into its own component
-	storageClient           storage.Client
-	trackingValueStorageKey string
+	storageClient storage.Client
 }
 
 func newLogsQueryReceiver(
@@ -218,6 +218,7 @@ func newLogsQueryReceiver(
 	clientProviderFunc clientProviderFunc,
 	logger *zap.Logger,
 	storageClient storage.Client,
+	receiverInterval time.Duration,
 ) *logsQueryReceiver {
 	queryReceiver := &logsQueryReceiver{
 		id:            id,
@@ -226,9 +227,9 @@ func newLogsQueryReceiver(
 		createClient:  clientProviderFunc,
 		logger:        logger,
 		storageClient: storageClient,
+		trackingValue: newTrackingValue(id, query),
+		schedule:      newQuerySchedule(query.CollectionInterval, receiverInterval),
 	}
-	queryReceiver.trackingValue = queryReceiver.query.TrackingStartValue
-	queryReceiver.trackingValueStorageKey = fmt.Sprintf("%s.%s", queryReceiver.id, "trackingValue")
 	return queryReceiver
 }
 
@@ -244,75 +245,57 @@ func (queryReceiver *logsQueryReceiver) start(ctx context.Context) error {
 	}
 	queryReceiver.client = queryReceiver.createClient(dbWrapper{queryReceiver.db}, queryReceiver.query.SQL, queryReceiver.logger)
 
-	queryReceiver.trackingValue = queryReceiver.retrieveTrackingValue(ctx)
+	queryReceiver.trackingValue.load(ctx, queryReceiver.storageClient)
 
 	return nil
 }
 
-// retrieveTrackingValue retrieves the tracking value from storage, if storage is configured.
-// Otherwise, it returns the tracking value configured in `tracking_start_value`.
-func (queryReceiver *logsQueryReceiver) retrieveTrackingValue(ctx context.Context) string {
-	trackingValueFromConfig := queryReceiver.query.TrackingStartValue
-	if queryReceiver.storageClient == nil {
-		return trackingValueFromConfig
-	}
-
-	storedTrackingValueBytes, err := queryReceiver.storageClient.Get(ctx, queryReceiver.trackingValueStorageKey)
-	if err != nil || storedTrackingValueBytes == nil {
-		return trackingValueFromConfig
-	}
-
-	return string(storedTrackingValueBytes)
-
-}
-
 func (queryReceiver *logsQueryReceiver) collect(ctx context.Context) (plog.Logs, error) {
 	logs := plog.NewLogs()
+	if !queryReceiver.schedule.due(time.Now()) {
+		return logs, nil
+	}
 
-	var rows []stringMap
-	var err error
+	startedAt := time.Now()
+	rows, err := queryReceiver.client.queryRows(ctx, queryReceiver.trackingValue.queryArgs()...)
+	duration := time.Since(startedAt)
 	observedAt := pcommon.NewTimestampFromTime(time.Now())
-	if queryReceiver.query.TrackingColumn != "" {
-		rows, err = queryReceiver.client.queryRows(ctx, queryReceiver.trackingValue)
-	} else {
-		rows, err = queryReceiver.client.queryRows(ctx)
-	}
 	if err != nil {
 		return logs, fmt.Errorf("error getting rows: %w", err)
 	}
 
-	var errs error
 	scopeLogs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
-	for logsConfigIndex, logsConfig := range queryReceiver.query.Logs {
+	for _, logsConfig := range queryReceiver.query.Logs {
 		for _, row := range rows {
 			logRecord := scopeLogs.AppendEmpty()
 			rowToLog(row, logsConfig, logRecord)
 			logRecord.SetObservedTimestamp(observedAt)
-			if logsConfigIndex == 0 {
-				errs = multierr.Append(errs, queryReceiver.storeTrackingValue(ctx, row))
-			}
 		}
 	}
-	return logs, nil
-}
-
-func (queryReceiver *logsQueryReceiver) storeTrackingValue(ctx context.Context, row stringMap) error {
-	if queryReceiver.query.TrackingColumn == "" {
-		return nil
+	if threshold := queryReceiver.query.SlowQueryThreshold; threshold > 0 && duration >= threshold {
+		slowQueryEvent(queryReceiver.query.SQL, startedAt, duration, len(rows), scopeLogs.AppendEmpty())
 	}
-	queryReceiver.trackingValue = row[queryReceiver.query.TrackingColumn]
-	if queryReceiver.storageClient != nil {
-		err := queryReceiver.storageClient.Set(ctx, queryReceiver.trackingValueStorageKey, []byte(queryReceiver.trackingValue))
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return logs, queryReceiver.trackingValue.update(ctx, rows)
 }
 
 func rowToLog(row stringMap, config LogsCfg, logRecord plog.LogRecord) {
 	logRecord.Body().SetStr(row[config.BodyColumn])
 }
 
+// slowQueryEvent fills logRecord with an event describing a query run
+// that took longer than the query's `slow_query_threshold`.
+func slowQueryEvent(sql string, startedAt time.Time, duration time.Duration, rowCount int, logRecord plog.LogRecord) {
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(startedAt))
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	logRecord.SetSeverityNumber(plog.SeverityNumberWarn)
+	logRecord.SetSeverityText(plog.SeverityNumberWarn.String())
+	logRecord.Body().SetStr("slow query")
+	attrs := logRecord.Attributes()
+	attrs.PutStr("event.name", "db.query.slow")
+	attrs.PutStr("db.statement", sql)
+	attrs.PutInt("db.query.duration_ms", duration.Milliseconds())
+	attrs.PutInt("db.query.row_count", int64(rowCount))
+}
+
 func (queryReceiver *logsQueryReceiver) shutdown(_ context.Context) {
 }
@@ -9,7 +9,14 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
 )
 
 func TestLogsQueryReceiver_Collect(t *testing.T) {
@@ -49,3 +56,90 @@ func TestLogsQueryReceiver_Collect(t *testing.T) {
 		"Observed timestamps of all log records collected in a single scrape should be equal",
 	)
 }
+
+func TestLogsQueryReceiver_CollectTracking(t *testing.T) {
+	storageClient := storagetest.NewInMemoryClient(component.KindReceiver, component.NewID(metadata.Type), "")
+	fakeClient := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{{"id": "1", "body": "a"}, {"id": "2", "body": "b"}},
+			{},
+			{{"id": "3", "body": "c"}},
+		},
+	}
+	query := Query{
+		SQL:                "select * from logs where id > ?",
+		TrackingColumn:     "id",
+		TrackingStartValue: "0",
+		Logs:               []LogsCfg{{BodyColumn: "body"}},
+	}
+	queryReceiver := newLogsQueryReceiver("query-0", query, nil, nil, zap.NewNop(), storageClient, 10*time.Second)
+	queryReceiver.client = fakeClient
+	queryReceiver.trackingValue.load(context.Background(), storageClient)
+
+	for i := 0; i < 3; i++ {
+		_, err := queryReceiver.collect(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, [][]any{{"0"}, {"2"}, {"2"}}, fakeClient.args)
+
+	stored, err := storageClient.Get(context.Background(), "query-0.trackingValue")
+	require.NoError(t, err)
+	assert.Equal(t, "3", string(stored))
+
+	restarted := newLogsQueryReceiver("query-0", query, nil, nil, zap.NewNop(), storageClient, 10*time.Second)
+	restarted.trackingValue.load(context.Background(), storageClient)
+	assert.Equal(t, []any{"3"}, restarted.trackingValue.queryArgs())
+}
+
+func TestLogsQueryReceiver_CollectionInterval(t *testing.T) {
+	fakeClient := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{{"body": "a"}},
+		},
+	}
+	queryReceiver := newLogsQueryReceiver("query-0", Query{
+		CollectionInterval: time.Hour,
+		Logs:               []LogsCfg{{BodyColumn: "body"}},
+	}, nil, nil, zap.NewNop(), nil, 10*time.Second)
+	queryReceiver.client = fakeClient
+
+	logs, err := queryReceiver.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, logs.LogRecordCount())
+
+	logs, err = queryReceiver.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.LogRecordCount())
+	assert.Equal(t, 1, fakeClient.requestCounter)
+}
+
+func TestLogsQueryReceiver_SlowQueryEvent(t *testing.T) {
+	fakeClient := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{{"body": "a"}},
+		},
+		delay: 10 * time.Millisecond,
+	}
+	queryReceiver := newLogsQueryReceiver("query-0", Query{
+		SQL:                "select body from logs",
+		SlowQueryThreshold: time.Millisecond,
+		Logs:               []LogsCfg{{BodyColumn: "body"}},
+	}, nil, nil, zap.NewNop(), nil, 10*time.Second)
+	queryReceiver.client = fakeClient
+
+	logs, err := queryReceiver.collect(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	event := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1)
+	assert.Equal(t, "slow query", event.Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, event.SeverityNumber())
+	name, _ := event.Attributes().Get("event.name")
+	assert.Equal(t, "db.query.slow", name.Str())
+	statement, _ := event.Attributes().Get("db.statement")
+	assert.Equal(t, "select body from logs", statement.Str())
+	duration, _ := event.Attributes().Get("db.query.duration_ms")
+	assert.GreaterOrEqual(t, duration.Int(), int64(10))
+	rowCount, _ := event.Attributes().Get("db.query.row_count")
+	assert.Equal(t, int64(1), rowCount.Int())
+}
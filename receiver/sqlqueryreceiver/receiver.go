@@ -10,8 +10,10 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -42,6 +44,7 @@ func createMetricsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc c
 	) (receiver.Metrics, error) {
 		sqlCfg := cfg.(*Config)
 		var opts []scraperhelper.ScraperControllerOption
+		var scrapers []*scraper
 		for i, query := range sqlCfg.Queries {
 			if len(query.Metrics) == 0 {
 				continue
@@ -56,15 +59,57 @@ func createMetricsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc c
 					return sqlOpenerFunc(sqlCfg.Driver, sqlCfg.DataSource)
 				},
 				clientProviderFunc: clientProviderFunc,
+				trackingValue:      newTrackingValue(id.String(), query),
+				schedule:           newQuerySchedule(query.CollectionInterval, sqlCfg.CollectionInterval),
 			}
 			opt := scraperhelper.AddScraper(mp)
 			opts = append(opts, opt)
+			scrapers = append(scrapers, mp)
 		}
-		return scraperhelper.NewScraperControllerReceiver(
+		rcvr, err := scraperhelper.NewScraperControllerReceiver(
 			&sqlCfg.ScraperControllerSettings,
 			settings,
 			consumer,
 			opts...,
 		)
+		if err != nil {
+			return nil, err
+		}
+		return &metricsReceiver{
+			Metrics:   rcvr,
+			id:        settings.ID,
+			storageID: sqlCfg.StorageID,
+			scrapers:  scrapers,
+		}, nil
+	}
+}
+
+// metricsReceiver wraps the scraper controller to share one storage client,
+// used to persist tracking values, between the scrapers of all metrics queries.
+type metricsReceiver struct {
+	receiver.Metrics
+	id            component.ID
+	storageID     *component.ID
+	scrapers      []*scraper
+	storageClient storage.Client
+}
+
+func (r *metricsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.storageClient, err = getStorageClient(ctx, host, r.storageID, r.id, "metrics")
+	if err != nil {
+		return fmt.Errorf("error connecting to storage: %w", err)
+	}
+	for _, s := range r.scrapers {
+		s.storageClient = r.storageClient
+	}
+	return r.Metrics.Start(ctx, host)
+}
+
+func (r *metricsReceiver) Shutdown(ctx context.Context) error {
+	err := r.Metrics.Shutdown(ctx)
+	if r.storageClient != nil {
+		err = multierr.Append(err, r.storageClient.Close(ctx))
 	}
+	return err
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/scrapererror"
@@ -29,6 +30,9 @@ type scraper struct {
 	logger             *zap.Logger
 	client             dbClient
 	db                 *sql.DB
+	trackingValue      *trackingValue
+	schedule           querySchedule
+	storageClient      storage.Client
 }
 
 var _ scraperhelper.Scraper = (*scraper)(nil)
@@ -37,7 +41,7 @@ func (s *scraper) ID() component.ID {
 	return s.id
 }
 
-func (s *scraper) Start(context.Context, component.Host) error {
+func (s *scraper) Start(ctx context.Context, _ component.Host) error {
 	var err error
 	s.db, err = s.dbProviderFunc()
 	if err != nil {
@@ -45,13 +49,17 @@ func (s *scraper) Start(context.Context, component.Host) error {
 	}
 	s.client = s.clientProviderFunc(dbWrapper{s.db}, s.query.SQL, s.logger)
 	s.startTime = pcommon.NewTimestampFromTime(time.Now())
+	s.trackingValue.load(ctx, s.storageClient)
 
 	return nil
 }
 
 func (s *scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
 	out := pmetric.NewMetrics()
-	rows, err := s.client.queryRows(ctx)
+	if !s.schedule.due(time.Now()) {
+		return out, nil
+	}
+	rows, err := s.client.queryRows(ctx, s.trackingValue.queryArgs()...)
 	if err != nil {
 		if errors.Is(err, errNullValueWarning) {
 			s.logger.Warn("problems encountered getting metric rows", zap.Error(err))
@@ -66,6 +74,9 @@ func (s *scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
 	sm := sms.AppendEmpty()
 	ms := sm.Metrics()
 	var errs error
+	if err = s.trackingValue.update(ctx, rows); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to store tracking value: %w", err))
+	}
 	for _, metricCfg := range s.query.Metrics {
 		for i, row := range rows {
 			if err = rowToMetric(row, metricCfg, ms.AppendEmpty(), s.startTime, ts, s.scrapeCfg); err != nil {
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -480,3 +481,75 @@ func TestScraper_StartAndTS_ErrorOnParse(t *testing.T) {
 	_, err := scrpr.Scrape(context.Background())
 	assert.Error(t, err)
 }
+
+func TestScraper_TrackingValue(t *testing.T) {
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{{"id": "5", "count": "1"}, {"id": "7", "count": "2"}},
+			{{"id": "9", "count": "3"}},
+		},
+	}
+	query := Query{
+		TrackingColumn:     "id",
+		TrackingStartValue: "0",
+		Metrics: []MetricCfg{{
+			MetricName:  "my.count",
+			ValueColumn: "count",
+		}},
+	}
+	scrpr := scraper{
+		client:        client,
+		query:         query,
+		trackingValue: newTrackingValue("query-0", query),
+	}
+	metrics, err := scrpr.Scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, metrics.MetricCount())
+
+	_, err = scrpr.Scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, [][]any{{"0"}, {"7"}}, client.args)
+	assert.Equal(t, []any{"9"}, scrpr.trackingValue.queryArgs())
+}
+
+func TestScraper_CollectionInterval(t *testing.T) {
+	client := &fakeDBClient{
+		stringMaps: [][]stringMap{
+			{{"count": "1"}},
+		},
+	}
+	scrpr := scraper{
+		client: client,
+		query: Query{
+			Metrics: []MetricCfg{{
+				MetricName:  "my.count",
+				ValueColumn: "count",
+			}},
+		},
+		schedule: newQuerySchedule(time.Hour, 10*time.Second),
+	}
+	metrics, err := scrpr.Scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics.MetricCount())
+
+	metrics, err = scrpr.Scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, metrics.MetricCount())
+	assert.Equal(t, 1, client.requestCounter)
+}
+
+func TestQuerySchedule_Jitter(t *testing.T) {
+	schedule := newQuerySchedule(10*time.Second, 10*time.Second)
+	start := time.Now()
+	assert.True(t, schedule.due(start))
+	assert.False(t, schedule.due(start.Add(4*time.Second)))
+	// A collection cycle firing slightly early still runs the query.
+	assert.True(t, schedule.due(start.Add(9990*time.Millisecond)))
+	assert.True(t, schedule.due(start.Add(20*time.Second)))
+
+	schedule = newQuerySchedule(30*time.Second, 10*time.Second)
+	assert.True(t, schedule.due(start))
+	assert.False(t, schedule.due(start.Add(10*time.Second)))
+	assert.False(t, schedule.due(start.Add(20*time.Second)))
+	assert.True(t, schedule.due(start.Add(29990*time.Millisecond)))
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+// trackingValue holds the cursor of an incremental query: the value of the tracking column
+// from the last row returned, which is passed as the bind parameter of the next query run.
+type trackingValue struct {
+	column        string
+	value         string
+	storageClient storage.Client
+	storageKey    string
+}
+
+func newTrackingValue(queryID string, query Query) *trackingValue {
+	return &trackingValue{
+		column:     query.TrackingColumn,
+		value:      query.TrackingStartValue,
+		storageKey: fmt.Sprintf("%s.%s", queryID, "trackingValue"),
+	}
+}
+
+// enabled reports whether the query is configured for incremental tracking.
+func (t *trackingValue) enabled() bool {
+	return t != nil && t.column != ""
+}
+
+// queryArgs returns the bind parameters to run the query with.
+func (t *trackingValue) queryArgs() []any {
+	if !t.enabled() {
+		return nil
+	}
+	return []any{t.value}
+}
+
+// load retrieves the tracking value from storage, if storage is configured.
+// Otherwise, the tracking value configured in `tracking_start_value` is kept.
+func (t *trackingValue) load(ctx context.Context, storageClient storage.Client) {
+	if !t.enabled() {
+		return
+	}
+	t.storageClient = storageClient
+	if t.storageClient == nil {
+		return
+	}
+	stored, err := t.storageClient.Get(ctx, t.storageKey)
+	if err != nil || stored == nil {
+		return
+	}
+	t.value = string(stored)
+}
+
+// update advances the tracking value to the tracking column of the last row that has it,
+// persisting it once per query run rather than once per row.
+func (t *trackingValue) update(ctx context.Context, rows []stringMap) error {
+	if !t.enabled() {
+		return nil
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		value, ok := rows[i][t.column]
+		if !ok {
+			continue
+		}
+		if value == t.value {
+			return nil
+		}
+		t.value = value
+		if t.storageClient != nil {
+			return t.storageClient.Set(ctx, t.storageKey, []byte(t.value))
+		}
+		return nil
+	}
+	return nil
+}
+
+// querySchedule tracks when a query with its own `collection_interval` last ran,
+// so that it is skipped on collection cycles of the receiver until it is due.
+type querySchedule struct {
+	interval time.Duration
+	// tolerance absorbs the jitter of the receiver's collection cycles, so that
+	// a cycle firing slightly early doesn't postpone the query by a whole cycle.
+	tolerance time.Duration
+	lastRun   time.Time
+}
+
+// newQuerySchedule returns the schedule of a query with the given interval, run on
+// the collection cycles of a receiver with the given collection interval.
+func newQuerySchedule(interval, receiverInterval time.Duration) querySchedule {
+	return querySchedule{interval: interval, tolerance: receiverInterval / 2}
+}
+
+// due reports whether the query should run at now, recording the run if so.
+func (s *querySchedule) due(now time.Time) bool {
+	if s.interval == 0 || s.lastRun.IsZero() || now.Sub(s.lastRun) >= s.interval-s.tolerance {
+		s.lastRun = now
+		return true
+	}
+	return false
+}
+
+// getStorageClient returns a storage client for the receiver. Metrics and logs receivers
+// with the same ID use distinct client names so they don't lock each other's storage.
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, name string) (storage.Client, error) {
+	if storageID == nil {
+		return nil, nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, name)
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package unixlogreceiver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/unix"
+)
+
+func TestUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix domain sockets aren't fully supported on Windows")
+	}
+
+	for _, socketType := range []string{unix.SocketTypeStream, unix.SocketTypeDatagram} {
+		t.Run(socketType, func(t *testing.T) {
+			// Socket paths are limited in length, so the test directory is kept short
+			dir, err := os.MkdirTemp("", "unixlog")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			cfg := NewFactory().CreateDefaultConfig().(*UnixLogConfig)
+			cfg.InputConfig.SocketPath = filepath.Join(dir, "test.sock")
+			cfg.InputConfig.SocketType = socketType
+			testUnix(t, cfg)
+		})
+	}
+}
+
+func testUnix(t *testing.T, cfg *UnixLogConfig) {
+	numLogs := 5
+
+	f := NewFactory()
+	sink := new(consumertest.LogsSink)
+	rcvr, err := f.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+
+	network := "unix"
+	if cfg.InputConfig.SocketType == unix.SocketTypeDatagram {
+		network = "unixgram"
+	}
+	conn, err := net.Dial(network, cfg.InputConfig.SocketPath)
+	require.NoError(t, err)
+
+	for i := 0; i < numLogs; i++ {
+		msg := fmt.Sprintf("<86>1 2021-02-28T00:0%d:02.003Z test msg %d\n", i, i)
+		_, err = conn.Write([]byte(msg))
+		require.NoError(t, err)
+	}
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, expectNLogs(sink, numLogs), 2*time.Second, time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	expectedLogs := make([]string, numLogs)
+	for i := 0; i < numLogs; i++ {
+		expectedLogs[i] = fmt.Sprintf("<86>1 2021-02-28T00:0%d:02.003Z test msg %d", i, i)
+	}
+
+	for _, ld := range sink.AllLogs() {
+		logs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < logs.Len(); i++ {
+			assert.Contains(t, expectedLogs, logs.At(i).Body().Str())
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub("unixlog")
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	assert.NoError(t, component.ValidateConfig(cfg))
+	assert.Equal(t, testdataConfigYaml(), cfg)
+}
+
+func testdataConfigYaml() *UnixLogConfig {
+	return &UnixLogConfig{
+		BaseConfig: adapter.BaseConfig{
+			Operators: []operator.Config{},
+		},
+		InputConfig: func() unix.Config {
+			c := unix.NewConfig()
+			c.SocketPath = "/var/run/unixlog.sock"
+			c.SocketType = unix.SocketTypeDatagram
+			return *c
+		}(),
+	}
+}
+
+func TestDecodeInputConfigFailure(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	factory := NewFactory()
+	badCfg := &UnixLogConfig{
+		BaseConfig: adapter.BaseConfig{
+			Operators: []operator.Config{},
+		},
+		InputConfig: func() unix.Config {
+			c := unix.NewConfig()
+			c.SocketPath = "/var/run/unixlog.sock"
+			c.Encoding = "fake"
+			return *c
+		}(),
+	}
+	receiver, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), badCfg, sink)
+	require.Error(t, err, "receiver creation should fail if input config isn't valid")
+	require.Nil(t, receiver, "receiver creation should fail if input config isn't valid")
+}
+
+func expectNLogs(sink *consumertest.LogsSink, expected int) func() bool {
+	return func() bool {
+		return sink.LogRecordCount() == expected
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.uber.org/multierr"
 )
 
@@ -16,16 +17,45 @@ var (
 	errReadTimeoutExceedsMaxValue  = errors.New("The duration specified for read_timeout exceeds the maximum allowed value of 10s")
 	errWriteTimeoutExceedsMaxValue = errors.New("The duration specified for write_timeout exceeds the maximum allowed value of 10s")
 	errRequiredHeader              = errors.New("both key and value are required to assign a required_header")
+	errInvalidBodyFormat           = errors.New("body_format must be one of lines, json or raw")
+	errInvalidMaxRequestBodySize   = errors.New("max_request_body_size must be positive")
+)
+
+const (
+	bodyFormatLines = "lines"
+	bodyFormatJSON  = "json"
+	bodyFormatRaw   = "raw"
 )
 
 // Config defines configuration for the Generic Webhook receiver.
 type Config struct {
 	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
-	ReadTimeout                   string                   `mapstructure:"read_timeout"`    // wait time for reading request headers in ms. Default is twenty seconds.
-	WriteTimeout                  string                   `mapstructure:"write_timeout"`   // wait time for writing request response in ms. Default is twenty seconds.
-	Path                          string                   `mapstructure:"path"`            // path for data collection. Default is <host>:<port>/services/collector
-	HealthPath                    string                   `mapstructure:"health_path"`     // path for health check api. Default is /services/collector/health
-	RequiredHeader                RequiredHeader           `mapstructure:"required_header"` // optional setting to set a required header for all requests to have
+	ReadTimeout                   string                   `mapstructure:"read_timeout"`      // wait time for reading request headers in ms. Default is twenty seconds.
+	WriteTimeout                  string                   `mapstructure:"write_timeout"`     // wait time for writing request response in ms. Default is twenty seconds.
+	Path                          string                   `mapstructure:"path"`              // path for data collection. Default is <host>:<port>/services/collector
+	HealthPath                    string                   `mapstructure:"health_path"`       // path for health check api. Default is /services/collector/health
+	RequiredHeader                RequiredHeader           `mapstructure:"required_header"`   // optional setting to set a required header for all requests to have
+	Signature                     *SignatureConfig         `mapstructure:"signature"`         // optional setting to verify the HMAC signature of all requests
+	BodyFormat                    string                   `mapstructure:"body_format"`       // how request bodies are split into log records: lines, json or raw. Default is lines.
+	HeaderAttributes              []string                 `mapstructure:"header_attributes"` // request headers added as attributes to the log records
+}
+
+// SignatureConfig defines how the HMAC signature of requests is verified.
+type SignatureConfig struct {
+	// Secret shared with the webhook sender to sign requests.
+	Secret configopaque.String `mapstructure:"secret"`
+	// Scheme of the signature: generic, github, stripe or slack. Default is generic.
+	Scheme string `mapstructure:"scheme"`
+	// Header containing the signature. Required by the generic scheme, the other schemes have a default.
+	Header string `mapstructure:"header"`
+	// Algorithm of the HMAC for the generic scheme: sha1, sha256 or sha512. Default is sha256.
+	Algorithm string `mapstructure:"algorithm"`
+	// Prefix removed from the header value before decoding the signature for the generic scheme, e.g. "sha256=".
+	Prefix string `mapstructure:"prefix"`
+	// Encoding of the signature for the generic scheme: hex or base64. Default is hex.
+	Encoding string `mapstructure:"encoding"`
+	// Tolerance is the maximum age of the timestamp signed along with the body by the stripe and slack schemes. Default is 5m.
+	Tolerance time.Duration `mapstructure:"tolerance"`
 }
 
 type RequiredHeader struct {
@@ -70,5 +100,21 @@ func (cfg *Config) Validate() error {
 		errs = multierr.Append(errs, errRequiredHeader)
 	}
 
+	if cfg.Signature != nil {
+		if err := cfg.Signature.validate(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if cfg.MaxRequestBodySize <= 0 {
+		errs = multierr.Append(errs, errInvalidMaxRequestBodySize)
+	}
+
+	switch cfg.BodyFormat {
+	case "", bodyFormatLines, bodyFormatJSON, bodyFormatRaw:
+	default:
+		errs = multierr.Append(errs, errInvalidBodyFormat)
+	}
+
 	return errs
 }
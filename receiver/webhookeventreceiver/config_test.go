@@ -4,6 +4,7 @@
 package webhookeventreceiver
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 
@@ -86,6 +87,65 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "Non positive max request body size",
+			expect: errInvalidMaxRequestBodySize,
+			conf: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:0",
+				},
+			},
+		},
+		{
+			desc:   "Invalid body format",
+			expect: errInvalidBodyFormat,
+			conf: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:0",
+				},
+				BodyFormat: "xml",
+			},
+		},
+		{
+			desc:   "Signature without secret",
+			expect: errors.New("signature secret is required to verify signatures"),
+			conf: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:0",
+				},
+				Signature: &SignatureConfig{Scheme: "github"},
+			},
+		},
+		{
+			desc:   "Generic signature without header",
+			expect: errors.New("signature header is required for the generic signature scheme"),
+			conf: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:0",
+				},
+				Signature: &SignatureConfig{Secret: "secret"},
+			},
+		},
+		{
+			desc:   "Unsupported signature scheme",
+			expect: errors.New(`unsupported signature scheme "gitlab"`),
+			conf: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:0",
+				},
+				Signature: &SignatureConfig{Secret: "secret", Scheme: "gitlab"},
+			},
+		},
+		{
+			desc:   "Unsupported signature algorithm",
+			expect: errors.New(`unsupported signature algorithm "md5"`),
+			conf: Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:0",
+				},
+				Signature: &SignatureConfig{Secret: "secret", Header: "X-Signature", Algorithm: "md5"},
+			},
+		},
 		{
 			desc:   "Multiple invalid configs",
 			expect: errs,
@@ -124,7 +184,8 @@ func TestLoadConfig(t *testing.T) {
 
 	expect := &Config{
 		HTTPServerSettings: confighttp.HTTPServerSettings{
-			Endpoint: "localhost:8080",
+			Endpoint:           "localhost:8080",
+			MaxRequestBodySize: 1048576,
 		},
 		ReadTimeout:  "500ms",
 		WriteTimeout: "500ms",
@@ -134,6 +195,12 @@ func TestLoadConfig(t *testing.T) {
 			Key:   "key-present",
 			Value: "value-present",
 		},
+		Signature: &SignatureConfig{
+			Scheme: "github",
+			Secret: "my-webhook-secret",
+		},
+		BodyFormat:       "json",
+		HeaderAttributes: []string{"X-GitHub-Event", "X-GitHub-Delivery"},
 	}
 
 	// create expected config
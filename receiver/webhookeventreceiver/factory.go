@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
@@ -23,6 +24,8 @@ const (
 	defaultWriteTimeout = "500ms"
 	defaultPath         = "/events"
 	defaultHealthPath   = "/health_check"
+
+	defaultMaxRequestBodySize = 20 * 1024 * 1024
 )
 
 // NewFactory creates a factory for Generic Webhook Receiver.
@@ -37,6 +40,9 @@ func NewFactory() receiver.Factory {
 // Default configuration for the generic webhook receiver
 func createDefaultConfig() component.Config {
 	return &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
 		Path:         defaultPath,
 		HealthPath:   defaultHealthPath,
 		ReadTimeout:  defaultReadTimeout,
@@ -4,7 +4,7 @@
 package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
 
 import (
-	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -31,6 +31,7 @@ var (
 	errInvalidEncodingType   = errors.New("invalid encoding type")
 	errEmptyResponseBody     = errors.New("request body content length is zero")
 	errMissingRequiredHeader = errors.New("request was missing required header or incorrect header value")
+	errRequestTooLarge       = errors.New("request body exceeds max_request_body_size")
 )
 
 const healthyResponse = `{"text": "Webhookevent receiver is healthy"}`
@@ -174,29 +175,54 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 		er.failBadReq(ctx, w, http.StatusBadRequest, errEmptyResponseBody)
 	}
 
-	bodyReader := r.Body
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, er.cfg.MaxRequestBodySize))
+	_ = r.Body.Close()
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		er.failBadReq(ctx, w, http.StatusRequestEntityTooLarge, errRequestTooLarge)
+		return
+	}
+	if err != nil {
+		er.failBadReq(ctx, w, http.StatusBadRequest, err)
+		return
+	}
+
+	// signatures are computed over the body as sent by the webhook
+	if er.cfg.Signature != nil {
+		if err = verifySignature(er.cfg.Signature, r.Header, body, time.Now()); err != nil {
+			er.failBadReq(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
 	// gzip encoded case
 	if encoding == "gzip" || encoding == "x-gzip" {
 		reader := er.gzipPool.Get().(*gzip.Reader)
-		err := reader.Reset(bodyReader)
-
+		err = reader.Reset(bytes.NewReader(body))
+		if err == nil {
+			// read one more byte than allowed to tell whether the decompressed body is too large
+			body, err = io.ReadAll(io.LimitReader(reader, er.cfg.MaxRequestBodySize+1))
+		}
+		er.gzipPool.Put(reader)
 		if err != nil {
 			er.failBadReq(ctx, w, http.StatusBadRequest, err)
-			_, _ = io.ReadAll(r.Body)
-			_ = r.Body.Close()
 			return
 		}
-		bodyReader = reader
-		defer er.gzipPool.Put(reader)
+		if int64(len(body)) > er.cfg.MaxRequestBodySize {
+			er.failBadReq(ctx, w, http.StatusRequestEntityTooLarge, errRequestTooLarge)
+			return
+		}
 	}
 
 	// finish reading the body into a log
-	sc := bufio.NewScanner(bodyReader)
-	ld, numLogs := reqToLog(sc, r.URL.Query(), er.cfg, er.settings)
+	ld, numLogs, err := reqToLog(body, r.Header, r.URL.Query(), er.cfg, er.settings)
+	if err != nil {
+		er.failBadReq(ctx, w, http.StatusBadRequest, err)
+		er.obsrecv.EndLogsOp(ctx, metadata.Type, 0, err)
+		return
+	}
 	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
 
-	_ = bodyReader.Close()
-
 	if consumerErr != nil {
 		er.failBadReq(ctx, w, http.StatusInternalServerError, consumerErr)
 		er.obsrecv.EndLogsOp(ctx, metadata.Type, numLogs, nil)
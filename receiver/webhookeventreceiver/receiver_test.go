@@ -121,6 +121,19 @@ func TestHandleReq(t *testing.T) {
 			cfg:  *cfg,
 			req:  httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("log1\nlog2")),
 		},
+		{
+			desc: "Valid signature",
+			cfg: func() Config {
+				signatureCfg := *cfg
+				signatureCfg.Signature = &SignatureConfig{Scheme: signatureSchemeGitHub, Secret: "secret"}
+				return signatureCfg
+			}(),
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(`{"action": "opened"}`))
+				req.Header.Set(githubSignatureHeader, "sha256="+hmacHex("secret", `{"action": "opened"}`))
+				return req
+			}(),
+		},
 	}
 
 	for _, test := range tests {
@@ -155,6 +168,20 @@ func TestFailedReq(t *testing.T) {
 	headerCfg.Endpoint = "localhost:0"
 	headerCfg.RequiredHeader.Key = "key-present"
 	headerCfg.RequiredHeader.Value = "value-present"
+	signatureCfg := createDefaultConfig().(*Config)
+	signatureCfg.Endpoint = "localhost:0"
+	signatureCfg.Signature = &SignatureConfig{Scheme: signatureSchemeGitHub, Secret: "secret"}
+	jsonCfg := createDefaultConfig().(*Config)
+	jsonCfg.Endpoint = "localhost:0"
+	jsonCfg.BodyFormat = bodyFormatJSON
+	smallCfg := createDefaultConfig().(*Config)
+	smallCfg.Endpoint = "localhost:0"
+	smallCfg.MaxRequestBodySize = 64
+	var zipped bytes.Buffer
+	gzipWriter := gzip.NewWriter(&zipped)
+	_, err := gzipWriter.Write(bytes.Repeat([]byte("a"), 1024))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
 
 	tests := []struct {
 		desc   string
@@ -204,6 +231,45 @@ func TestFailedReq(t *testing.T) {
 			}(),
 			status: http.StatusUnauthorized,
 		},
+		{
+			desc:   "Missing signature",
+			cfg:    *signatureCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("test")),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc: "Invalid signature",
+			cfg:  *signatureCfg,
+			req: func() *http.Request {
+				req := httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("test"))
+				req.Header.Set(githubSignatureHeader, "sha256="+hmacHex("other secret", "test"))
+				return req
+			}(),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "Body too large",
+			cfg:    *smallCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(strings.Repeat("a", 65))),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc: "Decompressed body too large",
+			cfg:  *smallCfg,
+			req: func() *http.Request {
+				require.Less(t, zipped.Len(), 64)
+				req := httptest.NewRequest("POST", "http://localhost/events", bytes.NewReader(zipped.Bytes()))
+				req.Header.Set("Content-Encoding", "gzip")
+				return req
+			}(),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:   "Invalid JSON body",
+			cfg:    *jsonCfg,
+			req:    httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("[{")),
+			status: http.StatusBadRequest,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
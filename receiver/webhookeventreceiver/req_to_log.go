@@ -5,7 +5,12 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
@@ -13,10 +18,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
 )
 
-func reqToLog(sc *bufio.Scanner,
+func reqToLog(body []byte,
+	header http.Header,
 	query url.Values,
-	_ *Config,
-	settings receiver.CreateSettings) (plog.Logs, int) {
+	cfg *Config,
+	settings receiver.CreateSettings) (plog.Logs, int, error) {
 	log := plog.NewLogs()
 	resourceLog := log.ResourceLogs().AppendEmpty()
 	appendMetadata(resourceLog, query)
@@ -27,13 +33,57 @@ func reqToLog(sc *bufio.Scanner,
 	scopeLog.Scope().Attributes().PutStr("source", settings.ID.String())
 	scopeLog.Scope().Attributes().PutStr("receiver", metadata.Type)
 
-	for sc.Scan() {
+	records, err := splitBody(body, cfg.BodyFormat)
+	if err != nil {
+		return log, 0, err
+	}
+	for _, record := range records {
 		logRecord := scopeLog.LogRecords().AppendEmpty()
-		line := sc.Text()
-		logRecord.Body().SetStr(line)
+		logRecord.Body().SetStr(record)
+		appendHeaders(logRecord, header, cfg.HeaderAttributes)
+	}
+
+	return log, scopeLog.LogRecords().Len(), nil
+}
+
+// splitBody splits a request body into the bodies of individual log records
+func splitBody(body []byte, format string) ([]string, error) {
+	var records []string
+	switch format {
+	case bodyFormatRaw:
+		records = append(records, string(body))
+	case bodyFormatJSON:
+		trimmed := bytes.TrimSpace(body)
+		if len(trimmed) == 0 || trimmed[0] != '[' {
+			if !json.Valid(trimmed) {
+				return nil, fmt.Errorf("request body is not valid JSON")
+			}
+			return []string{string(trimmed)}, nil
+		}
+		var elements []json.RawMessage
+		if err := json.Unmarshal(trimmed, &elements); err != nil {
+			return nil, fmt.Errorf("request body is not a valid JSON array: %w", err)
+		}
+		for _, element := range elements {
+			records = append(records, string(element))
+		}
+	default:
+		sc := bufio.NewScanner(bytes.NewReader(body))
+		for sc.Scan() {
+			records = append(records, sc.Text())
+		}
 	}
+	return records, nil
+}
 
-	return log, scopeLog.LogRecords().Len()
+// append the configured request headers as log record attributes
+func appendHeaders(logRecord plog.LogRecord, header http.Header, names []string) {
+	for _, name := range names {
+		values := header.Values(name)
+		if len(values) > 0 {
+			logRecord.Attributes().PutStr("http.request.header."+strings.ToLower(name), strings.Join(values, ","))
+		}
+	}
 }
 
 // append query parameters and webhook source as resource attributes
@@ -4,10 +4,8 @@
 package webhookeventreceiver
 
 import (
-	"bufio"
-	"bytes"
-	"io"
 	"log"
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -21,17 +19,16 @@ func TestReqToLog(t *testing.T) {
 	defaultConfig := createDefaultConfig().(*Config)
 
 	tests := []struct {
-		desc  string
-		sc    *bufio.Scanner
-		query url.Values
-		tt    func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings)
+		desc   string
+		body   []byte
+		header http.Header
+		query  url.Values
+		cfg    func(cfg *Config)
+		tt     func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings)
 	}{
 		{
 			desc: "Valid query valid event",
-			sc: func() *bufio.Scanner {
-				reader := io.NopCloser(bytes.NewReader([]byte("this is a: log")))
-				return bufio.NewScanner(reader)
-			}(),
+			body: []byte("this is a: log"),
 			query: func() url.Values {
 				v, err := url.ParseQuery(`qparam1=hello&qparam2=world`)
 				if err != nil {
@@ -62,10 +59,7 @@ func TestReqToLog(t *testing.T) {
 		},
 		{
 			desc: "Query is empty",
-			sc: func() *bufio.Scanner {
-				reader := io.NopCloser(bytes.NewReader([]byte("this is a: log")))
-				return bufio.NewScanner(reader)
-			}(),
+			body: []byte("this is a: log"),
 			tt: func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings) {
 				require.Equal(t, 1, reqLen)
 
@@ -76,12 +70,85 @@ func TestReqToLog(t *testing.T) {
 				require.Equal(t, 2, scopeLogsScope.Attributes().Len())
 			},
 		},
+		{
+			desc: "Newline delimited body",
+			body: []byte("{\"a\": 1}\n{\"a\": 2}\n"),
+			tt: func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings) {
+				require.Equal(t, 2, reqLen)
+				records := reqLog.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				require.Equal(t, `{"a": 1}`, records.At(0).Body().Str())
+				require.Equal(t, `{"a": 2}`, records.At(1).Body().Str())
+			},
+		},
+		{
+			desc: "JSON array body",
+			body: []byte(`[{"a": 1}, {"a": 2}, "three"]`),
+			cfg: func(cfg *Config) {
+				cfg.BodyFormat = bodyFormatJSON
+			},
+			tt: func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings) {
+				require.Equal(t, 3, reqLen)
+				records := reqLog.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				require.Equal(t, `{"a": 1}`, records.At(0).Body().Str())
+				require.Equal(t, `{"a": 2}`, records.At(1).Body().Str())
+				require.Equal(t, `"three"`, records.At(2).Body().Str())
+			},
+		},
+		{
+			desc: "JSON object body",
+			body: []byte("{\n  \"a\": 1\n}\n"),
+			cfg: func(cfg *Config) {
+				cfg.BodyFormat = bodyFormatJSON
+			},
+			tt: func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings) {
+				require.Equal(t, 1, reqLen)
+				records := reqLog.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				require.Equal(t, "{\n  \"a\": 1\n}", records.At(0).Body().Str())
+			},
+		},
+		{
+			desc: "Raw body with header attributes",
+			body: []byte("line one\nline two"),
+			header: http.Header{
+				"X-Github-Event": []string{"push"},
+				"X-Multi":        []string{"a", "b"},
+				"X-Ignored":      []string{"ignored"},
+			},
+			cfg: func(cfg *Config) {
+				cfg.BodyFormat = bodyFormatRaw
+				cfg.HeaderAttributes = []string{"X-GitHub-Event", "X-Multi", "X-Missing"}
+			},
+			tt: func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings) {
+				require.Equal(t, 1, reqLen)
+				record := reqLog.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+				require.Equal(t, "line one\nline two", record.Body().Str())
+				require.Equal(t, map[string]any{
+					"http.request.header.x-github-event": "push",
+					"http.request.header.x-multi":        "a,b",
+				}, record.Attributes().AsRaw())
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			reqLog, reqLen := reqToLog(test.sc, test.query, defaultConfig, receivertest.NewNopCreateSettings())
+			cfg := *defaultConfig
+			if test.cfg != nil {
+				test.cfg(&cfg)
+			}
+			reqLog, reqLen, err := reqToLog(test.body, test.header, test.query, &cfg, receivertest.NewNopCreateSettings())
+			require.NoError(t, err)
 			test.tt(t, reqLog, reqLen, receivertest.NewNopCreateSettings())
 		})
 	}
 }
+
+func TestReqToLogInvalidJSON(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.BodyFormat = bodyFormatJSON
+
+	_, _, err := reqToLog([]byte(`[{"a": 1}`), nil, nil, cfg, receivertest.NewNopCreateSettings())
+	require.Error(t, err)
+	_, _, err = reqToLog([]byte(`not json`), nil, nil, cfg, receivertest.NewNopCreateSettings())
+	require.Error(t, err)
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- sha1 is required to verify legacy webhook signatures
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signatureSchemeGeneric = "generic"
+	signatureSchemeGitHub  = "github"
+	signatureSchemeStripe  = "stripe"
+	signatureSchemeSlack   = "slack"
+
+	signatureEncodingHex    = "hex"
+	signatureEncodingBase64 = "base64"
+
+	githubSignatureHeader = "X-Hub-Signature-256"
+	stripeSignatureHeader = "Stripe-Signature"
+	slackSignatureHeader  = "X-Slack-Signature"
+	slackTimestampHeader  = "X-Slack-Request-Timestamp"
+
+	defaultSignatureTolerance = 5 * time.Minute
+)
+
+var (
+	errMissingSignature     = errors.New("request was missing the signature header")
+	errInvalidSignature     = errors.New("request signature does not match the request body")
+	errExpiredSignature     = errors.New("request signature timestamp is outside of the tolerance")
+	errMalformedSignature   = errors.New("request signature header is malformed")
+	signatureHashAlgorithms = map[string]func() hash.Hash{"sha1": sha1.New, "sha256": sha256.New, "sha512": sha512.New}
+	signatureDefaultHeaders = map[string]string{
+		signatureSchemeGitHub: githubSignatureHeader,
+		signatureSchemeStripe: stripeSignatureHeader,
+		signatureSchemeSlack:  slackSignatureHeader,
+	}
+)
+
+// verifySignature checks that the request body was signed with the configured secret, following the
+// configured signature scheme. now is used to check the age of timestamped signatures.
+func verifySignature(cfg *SignatureConfig, header http.Header, body []byte, now time.Time) error {
+	value := header.Get(cfg.header())
+	if value == "" {
+		return errMissingSignature
+	}
+
+	switch cfg.Scheme {
+	case signatureSchemeGitHub:
+		return checkHMAC(sha256.New, []byte(cfg.Secret), body, strings.TrimPrefix(value, "sha256="), signatureEncodingHex)
+	case signatureSchemeStripe:
+		return verifyStripeSignature(cfg, value, body, now)
+	case signatureSchemeSlack:
+		timestamp := header.Get(slackTimestampHeader)
+		if err := checkTimestamp(timestamp, cfg.tolerance(), now); err != nil {
+			return err
+		}
+		signed := append([]byte("v0:"+timestamp+":"), body...)
+		return checkHMAC(sha256.New, []byte(cfg.Secret), signed, strings.TrimPrefix(value, "v0="), signatureEncodingHex)
+	default:
+		algorithm := cfg.Algorithm
+		if algorithm == "" {
+			algorithm = "sha256"
+		}
+		encoding := cfg.Encoding
+		if encoding == "" {
+			encoding = signatureEncodingHex
+		}
+		return checkHMAC(signatureHashAlgorithms[algorithm], []byte(cfg.Secret), body, strings.TrimPrefix(value, cfg.Prefix), encoding)
+	}
+}
+
+// verifyStripeSignature verifies a header of the form t=<timestamp>,v1=<signature>[,v1=<signature>...]
+// where the signatures are computed over <timestamp>.<body>
+func verifyStripeSignature(cfg *SignatureConfig, value string, body []byte, now time.Time) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(value, ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return errMalformedSignature
+		}
+		switch k {
+		case "t":
+			timestamp = v
+		case "v1":
+			signatures = append(signatures, v)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return errMalformedSignature
+	}
+	if err := checkTimestamp(timestamp, cfg.tolerance(), now); err != nil {
+		return err
+	}
+
+	signed := append([]byte(timestamp+"."), body...)
+	for _, signature := range signatures {
+		if checkHMAC(sha256.New, []byte(cfg.Secret), signed, signature, signatureEncodingHex) == nil {
+			return nil
+		}
+	}
+	return errInvalidSignature
+}
+
+func checkHMAC(h func() hash.Hash, secret []byte, signed []byte, signature string, encoding string) error {
+	var expected []byte
+	var err error
+	if encoding == signatureEncodingBase64 {
+		expected, err = base64.StdEncoding.DecodeString(signature)
+	} else {
+		expected, err = hex.DecodeString(signature)
+	}
+	if err != nil {
+		return errMalformedSignature
+	}
+
+	mac := hmac.New(h, secret)
+	_, _ = mac.Write(signed)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// checkTimestamp guards against replayed requests by checking the age of a unix timestamp in seconds.
+func checkTimestamp(timestamp string, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errMalformedSignature
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > tolerance || age < -tolerance {
+		return errExpiredSignature
+	}
+	return nil
+}
+
+func (cfg *SignatureConfig) header() string {
+	if cfg.Header != "" {
+		return cfg.Header
+	}
+	return signatureDefaultHeaders[cfg.Scheme]
+}
+
+func (cfg *SignatureConfig) tolerance() time.Duration {
+	if cfg.Tolerance > 0 {
+		return cfg.Tolerance
+	}
+	return defaultSignatureTolerance
+}
+
+func (cfg *SignatureConfig) validate() error {
+	if cfg.Secret == "" {
+		return errors.New("signature secret is required to verify signatures")
+	}
+	switch cfg.Scheme {
+	case "", signatureSchemeGeneric:
+		if cfg.Header == "" {
+			return errors.New("signature header is required for the generic signature scheme")
+		}
+	case signatureSchemeGitHub, signatureSchemeStripe, signatureSchemeSlack:
+	default:
+		return fmt.Errorf("unsupported signature scheme %q", cfg.Scheme)
+	}
+	if _, ok := signatureHashAlgorithms[cfg.Algorithm]; cfg.Algorithm != "" && !ok {
+		return fmt.Errorf("unsupported signature algorithm %q", cfg.Algorithm)
+	}
+	if cfg.Encoding != "" && cfg.Encoding != signatureEncodingHex && cfg.Encoding != signatureEncodingBase64 {
+		return fmt.Errorf("unsupported signature encoding %q", cfg.Encoding)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	oldTimestamp := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+	body := `{"event": "test"}`
+
+	tests := []struct {
+		desc   string
+		cfg    SignatureConfig
+		header http.Header
+		err    error
+	}{
+		{
+			desc:   "generic hex",
+			cfg:    SignatureConfig{Secret: "secret", Header: "X-Signature"},
+			header: http.Header{"X-Signature": []string{hmacHex("secret", body)}},
+		},
+		{
+			desc: "generic sha1 base64 with prefix",
+			cfg:  SignatureConfig{Secret: "secret", Header: "X-Signature", Algorithm: "sha1", Encoding: signatureEncodingBase64, Prefix: "sha1="},
+			header: func() http.Header {
+				mac := hmac.New(sha1.New, []byte("secret"))
+				_, _ = mac.Write([]byte(body))
+				return http.Header{"X-Signature": []string{"sha1=" + base64.StdEncoding.EncodeToString(mac.Sum(nil))}}
+			}(),
+		},
+		{
+			desc:   "generic wrong secret",
+			cfg:    SignatureConfig{Secret: "secret", Header: "X-Signature"},
+			header: http.Header{"X-Signature": []string{hmacHex("wrong", body)}},
+			err:    errInvalidSignature,
+		},
+		{
+			desc:   "generic malformed",
+			cfg:    SignatureConfig{Secret: "secret", Header: "X-Signature"},
+			header: http.Header{"X-Signature": []string{"not hex"}},
+			err:    errMalformedSignature,
+		},
+		{
+			desc: "missing header",
+			cfg:  SignatureConfig{Secret: "secret", Scheme: signatureSchemeGitHub},
+			err:  errMissingSignature,
+		},
+		{
+			desc:   "github",
+			cfg:    SignatureConfig{Secret: "secret", Scheme: signatureSchemeGitHub},
+			header: http.Header{githubSignatureHeader: []string{"sha256=" + hmacHex("secret", body)}},
+		},
+		{
+			desc: "stripe",
+			cfg:  SignatureConfig{Secret: "secret", Scheme: signatureSchemeStripe},
+			header: http.Header{stripeSignatureHeader: []string{
+				"t=" + timestamp + ",v1=" + hmacHex("old secret", timestamp+"."+body) + ",v1=" + hmacHex("secret", timestamp+"."+body),
+			}},
+		},
+		{
+			desc:   "stripe expired",
+			cfg:    SignatureConfig{Secret: "secret", Scheme: signatureSchemeStripe},
+			header: http.Header{stripeSignatureHeader: []string{"t=" + oldTimestamp + ",v1=" + hmacHex("secret", oldTimestamp+"."+body)}},
+			err:    errExpiredSignature,
+		},
+		{
+			desc:   "stripe malformed",
+			cfg:    SignatureConfig{Secret: "secret", Scheme: signatureSchemeStripe},
+			header: http.Header{stripeSignatureHeader: []string{"v1=" + hmacHex("secret", body)}},
+			err:    errMalformedSignature,
+		},
+		{
+			desc: "slack",
+			cfg:  SignatureConfig{Secret: "secret", Scheme: signatureSchemeSlack},
+			header: http.Header{
+				slackSignatureHeader: []string{"v0=" + hmacHex("secret", "v0:"+timestamp+":"+body)},
+				slackTimestampHeader: []string{timestamp},
+			},
+		},
+		{
+			desc: "slack with tolerance",
+			cfg:  SignatureConfig{Secret: "secret", Scheme: signatureSchemeSlack, Tolerance: 2 * time.Hour},
+			header: http.Header{
+				slackSignatureHeader: []string{"v0=" + hmacHex("secret", "v0:"+oldTimestamp+":"+body)},
+				slackTimestampHeader: []string{oldTimestamp},
+			},
+		},
+		{
+			desc: "slack expired",
+			cfg:  SignatureConfig{Secret: "secret", Scheme: signatureSchemeSlack},
+			header: http.Header{
+				slackSignatureHeader: []string{"v0=" + hmacHex("secret", "v0:"+oldTimestamp+":"+body)},
+				slackTimestampHeader: []string{oldTimestamp},
+			},
+			err: errExpiredSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := verifySignature(&test.cfg, test.header, []byte(body), now)
+			if test.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func hmacHex(secret string, signed string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(signed))
+	return hex.EncodeToString(mac.Sum(nil))
+}